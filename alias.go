@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/carabiner-dev/burnafter/internal/common"
+)
+
+// StoreAlias creates a metadata-only alias that resolves to an existing
+// secret: Get(alias) returns the target's value and the target's expiry
+// governs both. This lets config templates point several keys at one
+// underlying secret without duplicating the encrypted payload. Deleting the
+// target invalidates the alias.
+//
+// Aliases only exist in server mode: the ephemeral and fallback stores keep
+// no metadata to point an alias at.
+func (c *Client) StoreAlias(ctx context.Context, alias, target string) error {
+	if c.useMemory() || c.useFallback() {
+		return fmt.Errorf("StoreAlias is only supported in server mode")
+	}
+
+	if c.client == nil {
+		return ErrNotConnected
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.StoreAlias(ctx, &pb.StoreAliasRequest{
+		Alias:  alias,
+		Target: target,
+	})
+	if err != nil {
+		return fmt.Errorf("storing alias: %w", err)
+	}
+
+	if !resp.Success {
+		return fmt.Errorf("server error: %s", resp.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/carabiner-dev/burnafter/internal/common"
+)
+
+// Append atomically appends data to the named secret's plaintext on the
+// server, creating the secret (with the default TTL) when it does not exist
+// yet. The decrypt-append-reencrypt cycle runs entirely server-side, so a
+// secret assembled from several sources (e.g. a concatenated certificate
+// chain) never crosses to the client along the way. Use AppendStrict to fail
+// on a missing secret instead of creating it.
+//
+// Appends only exist in server mode, where the plaintext can stay put.
+func (c *Client) Append(ctx context.Context, name, data string) error {
+	return c.append(ctx, name, data, true)
+}
+
+// AppendStrict is Append with strict semantics: it returns ErrSecretNotFound
+// when the named secret does not exist, for callers appending to a secret
+// something else must have created first.
+func (c *Client) AppendStrict(ctx context.Context, name, data string) error {
+	return c.append(ctx, name, data, false)
+}
+
+func (c *Client) append(ctx context.Context, name, data string, createIfMissing bool) error {
+	if c.useMemory() || c.useFallback() {
+		return fmt.Errorf("Append is only supported in server mode")
+	}
+
+	if c.client == nil {
+		return ErrNotConnected
+	}
+
+	// An append changes the value behind any cached copy
+	if c.cache != nil {
+		c.cache.invalidate(name)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.Append(ctx, &pb.AppendRequest{
+		Name:            name,
+		Data:            []byte(data),
+		ClientNonce:     c.nonce(),
+		CreateIfMissing: createIfMissing,
+	})
+	if err != nil {
+		return fmt.Errorf("appending to secret: %w", err)
+	}
+
+	if !resp.Success {
+		if !createIfMissing && resp.Error == "secret not found" {
+			return fmt.Errorf("appending to secret %q: %w", name, ErrSecretNotFound)
+		}
+		return fmt.Errorf("server error: %s", resp.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// Benchmarks for the hot client paths. The server benchmarks run an
+// in-process server over a Unix socket in a temp dir, so they measure the
+// full RPC round trip (peer credential check, binary rehash, key derivation)
+// without the embedded binary extraction. The fallback benchmarks measure
+// PBKDF2 plus the file round trip. Baseline numbers live in
+// docs/benchmarks.md; all benchmarks report allocations because the
+// secret-wiping and copying on these paths matter.
+
+// newBenchServerClient connects a client to an in-process server.
+func newBenchServerClient(b *testing.B) (*Client, func()) {
+	b.Helper()
+
+	opts := *options.DefaultClient
+	dir, err := os.MkdirTemp("", "burnafter-bench-*")
+	if err != nil {
+		b.Fatalf("MkdirTemp failed: %v", err)
+	}
+	opts.SocketPath = filepath.Join(dir, "bench.sock")
+	opts.Nonce = "bench-nonce"
+	opts.NoFallbackMode = true
+
+	launcher := &inProcessLauncher{}
+	client := NewClient(&opts, WithServerLauncher(launcher.launch))
+
+	if err := client.Connect(context.Background()); err != nil {
+		launcher.kill()
+		os.RemoveAll(dir) //nolint:errcheck,gosec
+		b.Fatalf("Connect failed: %v", err)
+	}
+
+	return client, func() {
+		client.Close() //nolint:errcheck,gosec
+		launcher.kill()
+		os.RemoveAll(dir) //nolint:errcheck,gosec
+	}
+}
+
+func BenchmarkStore(b *testing.B) {
+	client, cleanup := newBenchServerClient(b)
+	defer cleanup()
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; b.Loop(); i++ {
+		// Distinct names so each iteration stores rather than overwrites
+		name := fmt.Sprintf("bench-store-%d", i%64)
+		if err := client.Store(ctx, name, "bench-value", options.WithTTL(300)); err != nil {
+			b.Fatalf("Store failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	client, cleanup := newBenchServerClient(b)
+	defer cleanup()
+	ctx := context.Background()
+
+	if err := client.Store(ctx, "bench-get", "bench-value", options.WithTTL(300)); err != nil {
+		b.Fatalf("Store failed: %v", err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for b.Loop() {
+		if _, err := client.Get(ctx, "bench-get"); err != nil {
+			b.Fatalf("Get failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkFallbackStoreGet(b *testing.B) {
+	opts := *options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = "bench-nonce-fallback"
+
+	client := NewClient(&opts)
+	ctx := context.Background()
+
+	if err := client.Connect(ctx); err != nil {
+		b.Fatalf("Connect failed: %v", err)
+	}
+	defer client.deleteFallbackSecret("bench-fallback") //nolint:errcheck
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for b.Loop() {
+		// Each round trip pays two PBKDF2 derivations plus the file write/read
+		if err := client.Store(ctx, "bench-fallback", "bench-value", options.WithTTL(300)); err != nil {
+			b.Fatalf("Store failed: %v", err)
+		}
+		if _, err := client.Get(ctx, "bench-fallback"); err != nil {
+			b.Fatalf("Get failed: %v", err)
+		}
+	}
+}
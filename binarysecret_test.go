@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// binarySecret is deliberately invalid UTF-8 (raw key bytes): proto3 enforces
+// UTF-8 on string fields at marshal time, so this value only survives the
+// wire because the secret fields are bytes.
+const binarySecret = "\xff\xfe\x00raw-key-material\x80\x81"
+
+// TestBinarySecretServerRoundTrip proves a secret containing invalid UTF-8
+// round-trips exactly through the real gRPC transport (not just the
+// in-process handlers, which never marshal).
+func TestBinarySecretServerRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	opts := *options.DefaultClient
+	opts.SocketPath = filepath.Join(t.TempDir(), "binary.sock")
+	opts.Nonce = "test-nonce-binary"
+	opts.NoFallbackMode = true
+
+	launcher := &inProcessLauncher{}
+	client := NewClient(&opts, WithServerLauncher(launcher.launch))
+	defer launcher.kill()
+
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	if err := client.Store(ctx, "binary", binarySecret, options.WithTTL(300)); err != nil {
+		t.Fatalf("Store of a binary secret failed: %v", err)
+	}
+
+	got, err := client.Get(ctx, "binary")
+	if err != nil {
+		t.Fatalf("Get of a binary secret failed: %v", err)
+	}
+	if got != binarySecret {
+		t.Errorf("Round-tripped binary secret does not match: got %q, want %q", got, binarySecret)
+	}
+}
+
+// TestBinarySecretFallbackRoundTrip covers the same invalid-UTF-8 value
+// through the encrypted file fallback, which never involved proto but must
+// stay byte-exact too.
+func TestBinarySecretFallbackRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	opts := *options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = "test-nonce-binary-fallback"
+
+	client := NewClient(&opts)
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	if err := client.Store(ctx, "binary-fallback", binarySecret, options.WithTTL(300)); err != nil {
+		t.Fatalf("Store of a binary secret failed: %v", err)
+	}
+	defer client.Delete(ctx, "binary-fallback") //nolint:errcheck
+
+	got, err := client.Get(ctx, "binary-fallback")
+	if err != nil {
+		t.Fatalf("Get of a binary secret failed: %v", err)
+	}
+	if got != binarySecret {
+		t.Errorf("Round-tripped binary secret does not match: got %q, want %q", got, binarySecret)
+	}
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// rawByteSecret is deliberately not valid UTF-8: embedded NULs, high bytes and
+// a truncated multi-byte sequence, the kind of value a DER key produces.
+var rawByteSecret = []byte{0x00, 0xFF, 0x30, 0x82, 0x00, 0xC3, 0x28, 0xFE, 0x00, 0x80}
+
+func TestBytesRoundTripInMemory(t *testing.T) {
+	c := newInMemoryClient()
+
+	if err := c.StoreBytes(t.Context(), "der-key", rawByteSecret); err != nil {
+		t.Fatalf("StoreBytes failed: %v", err)
+	}
+
+	got, err := c.GetBytes(t.Context(), "der-key")
+	if err != nil {
+		t.Fatalf("GetBytes failed: %v", err)
+	}
+	if !bytes.Equal(got, rawByteSecret) {
+		t.Errorf("Round-trip mismatch: got %x, want %x", got, rawByteSecret)
+	}
+}
+
+func TestGetInto(t *testing.T) {
+	c := newInMemoryClient()
+
+	if err := c.StoreBytes(t.Context(), "der-key", rawByteSecret); err != nil {
+		t.Fatalf("StoreBytes failed: %v", err)
+	}
+
+	dst := make([]byte, 64)
+	n, err := c.GetInto(t.Context(), "der-key", dst)
+	if err != nil {
+		t.Fatalf("GetInto failed: %v", err)
+	}
+	if n != len(rawByteSecret) || !bytes.Equal(dst[:n], rawByteSecret) {
+		t.Errorf("Expected %x, got %x", rawByteSecret, dst[:n])
+	}
+
+	// A buffer that cannot hold the secret must refuse and write nothing
+	small := make([]byte, 2)
+	if n, err := c.GetInto(t.Context(), "der-key", small); err == nil {
+		t.Fatalf("Expected an error for a too-small buffer, wrote %d bytes", n)
+	}
+	if small[0] != 0 || small[1] != 0 {
+		t.Errorf("Expected the too-small buffer to stay untouched, got %x", small)
+	}
+}
+
+func TestBytesRoundTripFallback(t *testing.T) {
+	opts := *options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = testNonce
+	c := NewClient(&opts)
+
+	if err := c.StoreBytes(t.Context(), "der-key-file", rawByteSecret); err != nil {
+		t.Fatalf("StoreBytes failed: %v", err)
+	}
+	t.Cleanup(func() {
+		if path, err := c.getFallbackFilePath("der-key-file"); err == nil {
+			os.Remove(path) //nolint:errcheck,gosec
+		}
+	})
+
+	got, err := c.GetBytes(t.Context(), "der-key-file")
+	if err != nil {
+		t.Fatalf("GetBytes failed: %v", err)
+	}
+	if !bytes.Equal(got, rawByteSecret) {
+		t.Errorf("Round-trip mismatch: got %x, want %x", got, rawByteSecret)
+	}
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"sync"
+	"time"
+
+	pb "github.com/carabiner-dev/burnafter/internal/common"
+)
+
+// secretCache is the opt-in client-side plaintext cache enabled by
+// options.Client.GetCacheTTL. It holds decrypted secrets in process memory so
+// repeated Gets within the window skip the RPC (and the server-side binary
+// rehash and decryption). Entries never outlive the secret's expiry as known
+// at fetch time, are dropped when the client stores or deletes the secret, and
+// are zero-wiped on Close.
+type secretCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	secret    []byte
+	expiresAt time.Time
+}
+
+func newSecretCache(ttl time.Duration) *secretCache {
+	return &secretCache{
+		ttl:     ttl,
+		entries: map[string]*cacheEntry{},
+	}
+}
+
+// get returns the cached plaintext for name, if a live entry exists. Expired
+// entries are wiped and dropped on the way out.
+func (sc *secretCache) get(name string, now time.Time) (string, bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	entry, ok := sc.entries[name]
+	if !ok {
+		return "", false
+	}
+	if now.After(entry.expiresAt) {
+		pb.ZeroBytes(entry.secret)
+		delete(sc.entries, name)
+		return "", false
+	}
+	return string(entry.secret), true
+}
+
+// put caches a freshly fetched secret. The entry lives for the cache TTL,
+// capped at the secret's own remaining lifetime so a cached copy never
+// survives past when the backend would have refused the read.
+func (sc *secretCache) put(name, secret string, now time.Time, meta *SecretMetadata) {
+	expiresAt := now.Add(sc.ttl)
+	if meta != nil {
+		if meta.RemainingTTL > 0 && now.Add(meta.RemainingTTL).Before(expiresAt) {
+			expiresAt = now.Add(meta.RemainingTTL)
+		}
+		if meta.AbsoluteDeadline != nil && meta.AbsoluteDeadline.Before(expiresAt) {
+			expiresAt = *meta.AbsoluteDeadline
+		}
+	}
+	if !expiresAt.After(now) {
+		return
+	}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if old, ok := sc.entries[name]; ok {
+		pb.ZeroBytes(old.secret)
+	}
+	sc.entries[name] = &cacheEntry{
+		secret:    []byte(secret),
+		expiresAt: expiresAt,
+	}
+}
+
+// invalidate wipes and drops the entry for name, if any. Called when the
+// client itself changes the secret (store or delete) so the cache never
+// serves a value the caller knows is stale.
+func (sc *secretCache) invalidate(name string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if entry, ok := sc.entries[name]; ok {
+		pb.ZeroBytes(entry.secret)
+		delete(sc.entries, name)
+	}
+}
+
+// wipe zeroes every cached plaintext and empties the cache.
+func (sc *secretCache) wipe() {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	for name, entry := range sc.entries {
+		pb.ZeroBytes(entry.secret)
+		delete(sc.entries, name)
+	}
+}
@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// newCachedInMemoryClient builds an in-memory client with the read cache
+// enabled for the given window.
+func newCachedInMemoryClient(t *testing.T, ttl time.Duration) *Client {
+	t.Helper()
+
+	opts := *options.DefaultClient
+	opts.InMemory = true
+	opts.Nonce = testNonce
+	opts.GetCacheTTL = ttl
+	c := NewClient(&opts)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	return c
+}
+
+func TestGetCacheHit(t *testing.T) {
+	ctx := context.Background()
+	c := newCachedInMemoryClient(t, time.Minute)
+
+	if err := c.Store(ctx, "hot", "cached-value", options.WithTTL(3600)); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	if got, err := c.Get(ctx, "hot"); err != nil || got != "cached-value" {
+		t.Fatalf("Get = (%q, %v), want (cached-value, nil)", got, err)
+	}
+
+	// Remove the secret behind the cache's back: a hit must not touch the
+	// backend, so the cached copy is still served within the window.
+	c.mem.del(ctx, "hot")
+	if got, err := c.Get(ctx, "hot"); err != nil || got != "cached-value" {
+		t.Fatalf("cached Get = (%q, %v), want (cached-value, nil)", got, err)
+	}
+}
+
+func TestGetCacheExpiry(t *testing.T) {
+	ctx := context.Background()
+	c := newCachedInMemoryClient(t, 50*time.Millisecond)
+
+	if err := c.Store(ctx, "brief", "v1", options.WithTTL(3600)); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	if got, err := c.Get(ctx, "brief"); err != nil || got != "v1" {
+		t.Fatalf("Get = (%q, %v), want (v1, nil)", got, err)
+	}
+
+	// After the cache window passes, Get must go back to the backend
+	c.mem.del(ctx, "brief")
+	time.Sleep(60 * time.Millisecond)
+	if _, err := c.Get(ctx, "brief"); err == nil {
+		t.Fatalf("Expected a backend miss after the cache window expired")
+	}
+}
+
+func TestGetCacheCappedBySecretExpiry(t *testing.T) {
+	ctx := context.Background()
+	c := newCachedInMemoryClient(t, time.Hour)
+
+	// The secret itself dies in one second; a cached copy must not outlive it
+	// even though the cache window is much longer.
+	if err := c.Store(ctx, "dying", "v1", options.WithTTL(1)); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	if got, err := c.Get(ctx, "dying"); err != nil || got != "v1" {
+		t.Fatalf("Get = (%q, %v), want (v1, nil)", got, err)
+	}
+
+	// The backend's expiry check has second granularity, so sleep past the
+	// worst-case boundary
+	time.Sleep(2100 * time.Millisecond)
+	if _, err := c.Get(ctx, "dying"); err == nil {
+		t.Fatalf("Expected the cached copy to die with the secret")
+	}
+}
+
+func TestGetCacheInvalidatedByWrites(t *testing.T) {
+	ctx := context.Background()
+	c := newCachedInMemoryClient(t, time.Minute)
+
+	if err := c.Store(ctx, "k", "v1", options.WithTTL(3600)); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	if got, err := c.Get(ctx, "k"); err != nil || got != "v1" {
+		t.Fatalf("Get = (%q, %v), want (v1, nil)", got, err)
+	}
+
+	// Overwriting through this client must evict the stale cached value
+	if err := c.Store(ctx, "k", "v2", options.WithTTL(3600)); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	if got, err := c.Get(ctx, "k"); err != nil || got != "v2" {
+		t.Fatalf("Get after overwrite = (%q, %v), want (v2, nil)", got, err)
+	}
+
+	// As must deleting it
+	if err := c.Delete(ctx, "k"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := c.Get(ctx, "k"); err == nil {
+		t.Fatalf("Expected Get to miss after Delete")
+	}
+}
+
+func TestGetCacheWipedOnClose(t *testing.T) {
+	ctx := context.Background()
+	c := newCachedInMemoryClient(t, time.Minute)
+
+	if err := c.Store(ctx, "k", "sensitive", options.WithTTL(3600)); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	if _, err := c.Get(ctx, "k"); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	// Keep a handle on the cache: Close drops the client's reference to it
+	cache := c.cache
+	cache.mu.Lock()
+	buf := cache.entries["k"].secret
+	cache.mu.Unlock()
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if c.cache != nil {
+		t.Errorf("Expected Close to drop the cache reference")
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if len(cache.entries) != 0 {
+		t.Errorf("Expected an empty cache after Close, got %d entries", len(cache.entries))
+	}
+	for i, b := range buf {
+		if b != 0 {
+			t.Fatalf("Expected the cached plaintext to be zero-wiped, byte %d is %#x", i, b)
+		}
+	}
+}
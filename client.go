@@ -5,6 +5,7 @@ package burnafter
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"net"
@@ -21,6 +22,20 @@ import (
 
 var ErrServerStartFailed = errors.New("server failed to start (and fallback mode is disabled)")
 
+// ErrNotConnected is returned by server-mode operations attempted before
+// Connect succeeded (or after the connection was torn down), so callers can
+// tell a missing connection apart from a server-side failure with errors.Is.
+var ErrNotConnected = errors.New("not connected to server")
+
+// ErrStartupTimeout marks a server that was spawned but did not answer within
+// StartupTimeout. Connect wraps it in ErrServerStartFailed (when fallback is
+// disabled), so errors.Is distinguishes a slow server from a cancelled wait.
+var ErrStartupTimeout = errors.New("timed out waiting for server startup")
+
+// defaultStartupTimeout preserves the historical total wait for the spawned
+// server when StartupTimeout is unset.
+const defaultStartupTimeout = time.Second
+
 // ServerLauncher starts the burnafter server as a detached subprocess
 // configured with the given options. The embedded-server implementation lives in
 // the github.com/carabiner-dev/burnafter/embedded package (embedded.Launch);
@@ -38,6 +53,19 @@ func WithServerLauncher(l ServerLauncher) Option {
 	return func(c *Client) { c.launcher = l }
 }
 
+// Deps carries the client's environmental dependencies — the clock and the
+// source of randomness — with zero fields meaning the real implementations.
+// Tests inject them (see WithDeps) to drive expiry without sleeping and to
+// make the sealed output deterministic.
+type Deps = pb.Deps
+
+// WithDeps injects the clock and randomness source the client uses for
+// expiry decisions and GCM nonces. Meant for tests; without it the client
+// uses the real time and crypto/rand.
+func WithDeps(d Deps) Option {
+	return func(c *Client) { c.deps = &d }
+}
+
 // Client is the burnafter client.
 //
 // This is the library that applications use to spin up the embedded server
@@ -57,13 +85,42 @@ type Client struct {
 	// secure store (kernel keyring) when available, otherwise an in-process map.
 	// Defaults to the heap store and may be upgraded to the keyring in Connect.
 	mem secretStore
+
+	// cache holds recently fetched plaintext when the GetCacheTTL option is
+	// set; nil (the default) means every Get goes to the backend.
+	cache *secretCache
+
+	// deps holds the injected clock and randomness source (see WithDeps).
+	// nil means the real implementations; tests inject deterministic ones to
+	// pin down the exact fallback file format and to expire secrets without
+	// sleeping.
+	deps *pb.Deps
+}
+
+// now returns the current time according to the client's clock: time.Now
+// unless deterministic deps were injected. Every expiry decision the client
+// makes goes through it.
+func (c *Client) now() time.Time {
+	return c.deps.Now()
+}
+
+// namespaceSuffix renders the Namespace option as a segment for the generated
+// socket and pipe names: a short hash rather than the raw string, so a
+// namespace stays path-safe no matter what characters it contains. The empty
+// namespace yields no suffix, keeping the historical paths byte-for-byte.
+func namespaceSuffix(namespace string) string {
+	if namespace == "" {
+		return ""
+	}
+	nsHash := sha256.Sum256([]byte(namespace))
+	return fmt.Sprintf("-%x", nsHash[:8])
 }
 
 // NewClient creates a new client instance.
 func NewClient(opts *options.Client, clientOpts ...Option) *Client {
 	// If no socket path is specified, generate one based on the client binary hash
 	if opts.SocketPath == "" {
-		opts.SocketPath = generateSocketPath()
+		opts.SocketPath = generateSocketPath(opts.SocketDir, opts.Namespace)
 	}
 
 	c := &Client{
@@ -72,23 +129,33 @@ func NewClient(opts *options.Client, clientOpts ...Option) *Client {
 		// platform supports it.
 		mem: newHeapStore(),
 	}
+	if opts.GetCacheTTL > 0 {
+		c.cache = newSecretCache(opts.GetCacheTTL)
+	}
 	for _, o := range clientOpts {
 		o(c)
 	}
 	return c
 }
 
-// generateSocketPath creates a socket path based on the client binary's SHA256 hash
-func generateSocketPath() string {
-	hash, err := pb.GetCurrentBinaryHash()
-	if err != nil {
-		// Fallback to a default path if we can't compute the hash
-		return "/tmp/burnafter.sock"
+// nonceBytes returns the client nonce as key-derivation input, preferring the
+// wipeable NonceBytes option over the string Nonce when both are set.
+func (c *Client) nonceBytes() []byte {
+	if len(c.options.NonceBytes) > 0 {
+		return c.options.NonceBytes
 	}
+	return []byte(c.options.Nonce)
+}
 
-	// Use first 16 characters of hash for the socket name
-	// This provides uniqueness while keeping the filename reasonable
-	return fmt.Sprintf("/tmp/burnafter-%s.sock", hash[:16])
+// nonce returns the client nonce as a string for the RPC paths, with the same
+// NonceBytes-over-Nonce precedence as nonceBytes. The conversion necessarily
+// copies the bytes into an immutable string, which is why the wipeability of
+// NonceBytes only fully applies to the fallback and in-memory paths.
+func (c *Client) nonce() string {
+	if len(c.options.NonceBytes) > 0 {
+		return string(c.options.NonceBytes)
+	}
+	return c.options.Nonce
 }
 
 // Connect establishes the connection to the server.
@@ -97,13 +164,22 @@ func generateSocketPath() string {
 //
 // If NoServer option is set or server startup fails, fallback mode is used.
 func (c *Client) Connect(ctx context.Context) error {
+	// A caller-supplied base directory must be usable before anything gets
+	// placed in it: failing here beats scattering sockets or secret files
+	// into a directory another user controls.
+	if c.options.SocketDir != "" {
+		if err := validateSocketDir(c.options.SocketDir); err != nil {
+			return err
+		}
+	}
+
 	// In-memory mode keeps secrets ephemeral: no server, no files. Prefer the OS
 	// secure store (kernel keyring) so secret bytes live in kernel memory rather
 	// than the process heap; fall back to the encrypted heap map (set in
 	// NewClient) when the keyring isn't available — e.g. a sandbox without
 	// keyctl, or a non-Linux host where the keyring isn't ephemeral.
 	if c.options.InMemory {
-		if ks, err := isecrets.NewKeyringStorage(ctx); err == nil {
+		if ks, err := isecrets.NewKeyringStorage(ctx, ""); err == nil {
 			c.mem = &keyringStore{storage: ks}
 			clog.FromContext(ctx).Debug("in-memory secrets: using OS kernel keyring")
 		} else {
@@ -142,6 +218,10 @@ func (c *Client) Connect(ctx context.Context) error {
 		return c.dial()
 	}
 
+	// A SIGKILLed server leaves its socket file behind; reap it so the
+	// spawned server's own bind doesn't race the leftover path
+	c.removeStaleSocket(ctx)
+
 	// Server is not running, start it
 	if err := c.startServer(ctx); err != nil {
 		// Starting the embedded server can fail in restricted environments
@@ -157,26 +237,41 @@ func (c *Client) Connect(ctx context.Context) error {
 		return nil
 	}
 
-	// Wait for the server to be ready
-	for range 10 {
-		time.Sleep(100 * time.Millisecond)
+	// Wait for the server to be ready, polling until the configured startup
+	// timeout elapses or the caller's context is cancelled
+	timeout := c.options.StartupTimeout
+	if timeout <= 0 {
+		timeout = defaultStartupTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			// Cancellation is the caller's doing, not evidence the server is
+			// broken: leave serverStartFailed unset so a later Connect with a
+			// live context can still succeed
+			return fmt.Errorf("waiting for server startup: %w", ctx.Err())
+		case <-time.After(100 * time.Millisecond):
+		}
 		if c.IsServerRunning(ctx) {
 			return c.dial()
 		}
 	}
 
-	// Server failed to start, mark as failed and use fallback
+	// Server failed to start in time, mark as failed and use fallback
 	c.serverStartFailed = true
 	if c.options.NoFallbackMode {
-		return ErrServerStartFailed
+		return fmt.Errorf("%w: %w", ErrServerStartFailed, ErrStartupTimeout)
 	}
 	return nil
 }
 
 // isServerRunning checks if the server is responding
 func (c *Client) IsServerRunning(ctx context.Context) bool {
-	d := net.Dialer{Timeout: 1 * time.Second}
-	conn, err := d.DialContext(ctx, "unix", c.options.SocketPath)
+	dialCtx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	conn, err := dialPeer(dialCtx, c.options.SocketPath)
 	if err != nil {
 		return false
 	}
@@ -184,12 +279,20 @@ func (c *Client) IsServerRunning(ctx context.Context) bool {
 	return true
 }
 
-// dial connects to the gRPC server through the unix socket
+// dial connects to the gRPC server through the platform transport (a Unix
+// domain socket, or a named pipe on Windows)
 func (c *Client) dial() error {
-	// Custom dialer for Unix domain sockets
+	// In strict mode, refuse to dial a socket sitting in a directory other
+	// local users could have planted an impostor socket in
+	if c.options.StrictSocketDir {
+		if err := strictSocketPathCheck(c.options.SocketPath); err != nil {
+			return err
+		}
+	}
+
+	// Custom dialer for the platform transport
 	dialer := func(ctx context.Context, addr string) (net.Conn, error) {
-		var d net.Dialer
-		return d.DialContext(ctx, "unix", c.options.SocketPath)
+		return dialPeer(ctx, c.options.SocketPath)
 	}
 
 	// Use "passthrough" as the scheme and a dummy IP address,
@@ -198,6 +301,12 @@ func (c *Client) dial() error {
 		"passthrough:///unix",
 		grpc.WithTransportCredentials(server.NewPeerCredentials()),
 		grpc.WithContextDialer(dialer),
+		// Match the transport limits to the configured secret size so large
+		// stores aren't cut off below the application-level limit
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(pb.MaxMessageSize(c.options.MaxSecretSize)),
+			grpc.MaxCallSendMsgSize(pb.MaxMessageSize(c.options.MaxSecretSize)),
+		),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to dial server: %w", err)
@@ -224,7 +333,7 @@ func (c *Client) Ping(ctx context.Context) error {
 
 	// Server mode
 	if c.client == nil {
-		return fmt.Errorf("not connected to server")
+		return ErrNotConnected
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
@@ -242,8 +351,47 @@ func (c *Client) Ping(ctx context.Context) error {
 	return nil
 }
 
-// Close closes the connection to the server.
+// Reconnect tears down any stale connection state and runs the Connect logic
+// again. It is meant for callers whose server went away — e.g. the idle
+// daemon shut itself down — and who want to re-establish (respawning the
+// server if needed) without constructing a whole new Client. The failed-start
+// marker is cleared so a previous startup failure doesn't short-circuit the
+// retry.
+func (c *Client) Reconnect(ctx context.Context) error {
+	if c.conn != nil {
+		_ = c.conn.Close() //nolint:errcheck // the old connection may already be dead
+		c.conn = nil
+		c.client = nil
+	}
+	c.serverStartFailed = false
+
+	return c.Connect(ctx)
+}
+
+// Close closes the connection to the server and scrubs whatever client-side
+// secret material can be scrubbed: the caller-supplied fallback key, any
+// cached plaintext and the in-memory store's buffers are zeroed and their
+// references dropped, so a long-lived process that closes a client reduces
+// its in-memory secret footprint.
+//
+// The Nonce option is a string and strings are immutable, so it cannot be
+// wiped in place and survives in memory until the garbage collector reuses
+// it. Callers that need the nonce scrubbed too supply it through the
+// NonceBytes option, which Close zeroes along with the rest.
 func (c *Client) Close() error {
+	// Wipe the caller-supplied fallback key and byte nonce; the client is
+	// done with them
+	pb.ZeroBytes(c.options.FallbackKey)
+	c.options.FallbackKey = nil
+	pb.ZeroBytes(c.options.NonceBytes)
+	c.options.NonceBytes = nil
+	if c.cache != nil {
+		c.cache.wipe()
+		c.cache = nil
+	}
+	if c.mem != nil {
+		c.mem.wipe()
+	}
 	if c.conn != nil {
 		return c.conn.Close()
 	}
@@ -11,8 +11,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/chainguard-dev/clog"
 
@@ -21,25 +24,44 @@ import (
 )
 
 func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Server error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the arguments, builds the server and blocks until it stops.
+// The optional --foreground flag is meant for running the binary under a
+// supervisor (systemd Type=exec, a container entrypoint): logs go to stdout
+// instead of being muted, and SIGINT/SIGTERM trigger a graceful shutdown.
+// The client-spawned daemon path does not use the flag and keeps detaching.
+func run(args []string) error {
+	foreground := false
+	if len(args) > 0 && (args[0] == "--foreground" || args[0] == "-foreground") {
+		foreground = true
+		args = args[1:]
+	}
+
 	// Start server with default options
-	serverOpts := options.DefaultServer
+	serverOpts := *options.DefaultServer
 
 	// If JSON options are passed as the first argument, merge them with defaults
-	if len(os.Args) > 1 {
+	if len(args) > 0 {
 		var clientOpts options.Common
-		if err := json.Unmarshal([]byte(os.Args[1]), &clientOpts); err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to parse options: %v\n", err)
-			os.Exit(1)
+		if err := json.Unmarshal([]byte(args[0]), &clientOpts); err != nil {
+			return fmt.Errorf("failed to parse options: %w", err)
 		}
 		serverOpts.Common = clientOpts
 	}
 
-	log := clog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
-		Level: slog.LevelDebug,
-	}))
+	logOut := os.Stderr
+	if foreground {
+		logOut = os.Stdout
+	}
+	log := clog.New(newLogHandler(serverOpts.LogFormat, logOut))
 
-	// Totally mute the log unless debugging
-	if !serverOpts.Debug {
+	// Totally mute the log unless debugging or running for a supervisor
+	if !serverOpts.Debug && !foreground {
 		log = clog.New(&noopHandler{})
 	}
 
@@ -47,18 +69,38 @@ func main() {
 	ctx := clog.WithLogger(context.Background(), log)
 
 	// Create the new server
-	srv, err := server.NewServer(ctx, serverOpts)
+	srv, err := server.NewServer(ctx, &serverOpts)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to create server: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("failed to create server: %w", err)
+	}
+
+	// In foreground mode the supervisor owns the process lifecycle, so honor
+	// its termination signals with a graceful stop instead of dying mid-request
+	if foreground {
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+		defer signal.Stop(sigChan)
+		go func() {
+			sig := <-sigChan
+			clog.FromContext(ctx).Infof("Received %s, shutting down", sig)
+			srv.Stop()
+		}()
 	}
 
 	clog.FromContext(ctx).Info("Starting burnafter server...")
 
-	if err := srv.Run(ctx); err != nil {
-		fmt.Fprintf(os.Stderr, "Server error: %v\n", err)
-		os.Exit(1)
+	return srv.Run(ctx)
+}
+
+// newLogHandler builds the slog handler for the selected log format. The
+// daemon is long-lived, so aggregated setups can ask for "json" to get
+// machine-parseable lines; anything else means the default text handler.
+func newLogHandler(format string, w io.Writer) slog.Handler {
+	opts := &slog.HandlerOptions{Level: slog.LevelDebug}
+	if format == "json" {
+		return slog.NewJSONHandler(w, opts)
 	}
+	return slog.NewTextHandler(w, opts)
 }
 
 type noopHandler struct{}
@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestNewLogHandlerJSON(t *testing.T) {
+	var buf bytes.Buffer
+	log := slog.New(newLogHandler("json", &buf))
+
+	log.InfoContext(context.Background(), "structured message", "key", "value")
+
+	var line map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("Expected a JSON log line, got %q: %v", buf.String(), err)
+	}
+	if line["msg"] != "structured message" {
+		t.Errorf("Expected the message in the msg field, got %v", line["msg"])
+	}
+	if line["key"] != "value" {
+		t.Errorf("Expected attributes to be preserved, got %v", line["key"])
+	}
+}
+
+func TestNewLogHandlerDefaultsToText(t *testing.T) {
+	var buf bytes.Buffer
+	log := slog.New(newLogHandler("", &buf))
+
+	log.InfoContext(context.Background(), "plain message")
+
+	if json.Valid(buf.Bytes()) {
+		t.Errorf("Expected text output by default, got JSON: %q", buf.String())
+	}
+}
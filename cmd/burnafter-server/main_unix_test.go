@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRunForegroundExitsOnSignal(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "burnafter.sock")
+
+	done := make(chan error, 1)
+	go func() {
+		done <- run([]string{"--foreground", fmt.Sprintf(`{"socket_path": %q}`, socketPath)})
+	}()
+
+	// Wait for the server to come up and own the socket
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := os.Stat(socketPath); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Server did not create its socket in time")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// A supervisor stops its service with SIGTERM; the foreground handler
+	// must catch it and let run return cleanly
+	if err := syscall.Kill(os.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatalf("Sending SIGTERM: %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected a clean shutdown, got: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Server did not exit after SIGTERM")
+	}
+}
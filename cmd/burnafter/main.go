@@ -7,9 +7,14 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/carabiner-dev/burnafter"
 	"github.com/carabiner-dev/burnafter/embedded"
@@ -20,11 +25,17 @@ const usage = `burnafter - Ephemeral secret storage with binary verification
 
 Usage:
   burnafter store <name> <secret> [ttl_seconds] [absolute_expiration_secs]  Store a secret
-  burnafter get <name>                                                      Retrieve a secret
+  burnafter store <name> -from-env VAR [ttl_seconds] [absolute_exp_secs]    Store a secret from an env var
+  burnafter get <name> [-meta]                                              Retrieve a secret
+  burnafter locate <name>                                                   Show where a secret is stored
   burnafter ping                                                            Check if server is running
+  burnafter config                                                          Show the running server's configuration
   
 Options:
   -socket string    Socket path (defaults to random tmp path)
+  -no-server        Skip the server and use encrypted file fallback storage
+  -nonce string     Client nonce for key derivation (required with -no-server
+                    to retrieve secrets across invocations)
   -debug            Enable debug output
 
 Secret Expiration:
@@ -45,9 +56,20 @@ Examples:
   # Store with 1 hour inactivity timeout AND 8 hour absolute deadline
   burnafter store api-key "my-secret-key" 3600 28800
 
+  # Store reading the value from an environment variable (avoids argv exposure)
+  burnafter store api-key -from-env API_KEY
+
   # Retrieve a secret (resets inactivity timer)
   burnafter get api-key
 
+  # Retrieve a secret with its expiry metadata printed to stderr
+  burnafter get api-key -meta
+
+  # Store and retrieve without a server (encrypted file fallback). The nonce
+  # is a key-derivation input, so retrieval must use the same one.
+  burnafter -no-server -nonce my-nonce store api-key "my-secret-key"
+  burnafter -no-server -nonce my-nonce get api-key
+
   # Check server status
   burnafter ping
 `
@@ -55,6 +77,8 @@ Examples:
 func main() {
 	// Define flags
 	socketPath := flag.String("socket", "", "Unix custom socket path")
+	noServer := flag.Bool("no-server", false, "Skip the server and use encrypted file fallback storage")
+	nonce := flag.String("nonce", "", "Client nonce for key derivation (required with -no-server)")
 	debug := flag.Bool("debug", false, "Enable debug output")
 	flag.Usage = func() {
 		fmt.Fprint(os.Stderr, usage)
@@ -82,6 +106,10 @@ func main() {
 		clientOpts.SocketPath = *socketPath
 	}
 	clientOpts.Debug = *debug
+	clientOpts.NoServer = *noServer
+	if *nonce != "" {
+		clientOpts.Nonce = *nonce
+	}
 	var err error
 	// Handle commands
 	switch command {
@@ -89,8 +117,12 @@ func main() {
 		err = runStore(context.Background(), clientOpts, args[1:])
 	case "get":
 		err = runGet(context.Background(), clientOpts, args[1:])
+	case "locate":
+		err = runLocate(context.Background(), clientOpts, args[1:])
 	case "ping":
 		err = runPing(context.Background(), clientOpts)
+	case "config":
+		err = runConfig(context.Background(), clientOpts)
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", command)
 		flag.Usage()
@@ -102,27 +134,88 @@ func main() {
 	}
 }
 
+// secretFromEnv reads the secret value from the named environment variable,
+// so it never shows up in the process's argv. Unset and empty variables are
+// rejected (an empty value is almost always a CI misconfiguration) unless the
+// caller explicitly allows empty secrets.
+func secretFromEnv(varName string, allowEmpty bool) (string, error) {
+	value, set := os.LookupEnv(varName)
+	if !set {
+		return "", fmt.Errorf("environment variable %s is not set", varName)
+	}
+	if value == "" && !allowEmpty {
+		return "", fmt.Errorf("environment variable %s is empty (use -allow-empty to store it anyway)", varName)
+	}
+	return value, nil
+}
+
+// isTransientTransportError reports whether an operation failed because the
+// transport to the daemon went away (connection refused, server gone
+// mid-operation) rather than the server answering with an error.
+func isTransientTransportError(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
+
+// retryOnceOnRespawn runs op and, when it fails on a transient transport
+// error, reconnects the client — respawning the daemon if needed — and runs
+// op one more time. burnafter daemons deliberately shut themselves down, so
+// the server disappearing between two CLI invocations is an expected
+// condition, not something the user should see as a raw transport error.
+func retryOnceOnRespawn(ctx context.Context, c *burnafter.Client, op func() error) error {
+	err := op()
+	if err == nil || !isTransientTransportError(err) {
+		return err
+	}
+	if rerr := c.Reconnect(ctx); rerr != nil {
+		return err
+	}
+	return op()
+}
+
 func runStore(ctx context.Context, opts *options.Client, args []string) error {
-	if len(args) < 2 {
-		return fmt.Errorf("usage: burnafter store <name> <secret> [ttl_seconds] [absolute_expiration_seconds]")
+	if len(args) < 1 {
+		return fmt.Errorf("usage: burnafter store <name> [-from-env VAR] [<secret>] [ttl_seconds] [absolute_expiration_seconds]")
 	}
 
 	name := args[0]
-	secret := args[1]
+
+	fs := flag.NewFlagSet("store", flag.ContinueOnError)
+	fromEnv := fs.String("from-env", "", "Read the secret value from this environment variable")
+	allowEmpty := fs.Bool("allow-empty", false, "Allow storing an empty secret read from the environment")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	rest := fs.Args()
+
+	var secret string
+	if *fromEnv != "" {
+		var err error
+		secret, err = secretFromEnv(*fromEnv, *allowEmpty)
+		if err != nil {
+			return err
+		}
+	} else {
+		if len(rest) < 1 {
+			return fmt.Errorf("usage: burnafter store <name> [-from-env VAR] [<secret>] [ttl_seconds] [absolute_expiration_seconds]")
+		}
+		secret = rest[0]
+		rest = rest[1:]
+	}
+
 	ttl := int64(0)                // Use default TTL
 	absoluteExpiration := int64(0) // No absolute expiration by default
 
-	if len(args) >= 3 {
+	if len(rest) >= 1 {
 		var err error
-		ttl, err = strconv.ParseInt(args[2], 10, 64)
+		ttl, err = strconv.ParseInt(rest[0], 10, 64)
 		if err != nil {
 			return fmt.Errorf("invalid TTL: %w", err)
 		}
 	}
 
-	if len(args) >= 4 {
+	if len(rest) >= 2 {
 		var err error
-		absoluteExpiration, err = strconv.ParseInt(args[3], 10, 64)
+		absoluteExpiration, err = strconv.ParseInt(rest[1], 10, 64)
 		if err != nil {
 			return fmt.Errorf("invalid absolute expiration: %w", err)
 		}
@@ -134,12 +227,14 @@ func runStore(ctx context.Context, opts *options.Client, args []string) error {
 	}
 	defer c.Close() //nolint:errcheck
 
-	// Store the secret:
-	if err := c.Store(
-		ctx, name, secret,
-		options.WithTTL(ttl),
-		options.WithAbsoluteExpiration(absoluteExpiration),
-	); err != nil {
+	// Store the secret, retrying once if the daemon went away underneath us
+	if err := retryOnceOnRespawn(ctx, c, func() error {
+		return c.Store(
+			ctx, name, secret,
+			options.WithTTL(ttl),
+			options.WithAbsoluteExpiration(absoluteExpiration),
+		)
+	}); err != nil {
 		return fmt.Errorf("failed to store secret: %w", err)
 	}
 
@@ -149,19 +244,45 @@ func runStore(ctx context.Context, opts *options.Client, args []string) error {
 
 func runGet(ctx context.Context, opts *options.Client, args []string) error {
 	if len(args) < 1 {
-		return fmt.Errorf("usage: burnafter get <name>")
+		return fmt.Errorf("usage: burnafter get <name> [-meta]")
 	}
 
 	name := args[0]
 
+	fs := flag.NewFlagSet("get", flag.ContinueOnError)
+	meta := fs.Bool("meta", false, "Print the secret's expiry metadata to stderr")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
 	c := burnafter.NewClient(opts, burnafter.WithServerLauncher(embedded.Launch))
 	if err := c.Connect(ctx); err != nil {
 		return fmt.Errorf("failed to connect: %w", err)
 	}
 	defer c.Close() //nolint:errcheck
 
-	secret, err := c.Get(ctx, name)
-	if err != nil {
+	if *meta {
+		var secret string
+		var metadata *burnafter.SecretMetadata
+		if err := retryOnceOnRespawn(ctx, c, func() error {
+			var err error
+			secret, metadata, err = c.GetWithMetadata(ctx, name)
+			return err
+		}); err != nil {
+			return fmt.Errorf("failed to get secret: %w", err)
+		}
+		// Metadata goes to stderr so pipelines reading stdout are unaffected
+		printSecretMetadata(os.Stderr, metadata)
+		fmt.Println(secret)
+		return nil
+	}
+
+	var secret string
+	if err := retryOnceOnRespawn(ctx, c, func() error {
+		var err error
+		secret, err = c.Get(ctx, name)
+		return err
+	}); err != nil {
 		return fmt.Errorf("failed to get secret: %w", err)
 	}
 
@@ -170,6 +291,68 @@ func runGet(ctx context.Context, opts *options.Client, args []string) error {
 	return nil
 }
 
+// printSecretMetadata writes a secret's lifecycle metadata to w, keeping the
+// value channel (stdout) clean for piping.
+func printSecretMetadata(w io.Writer, metadata *burnafter.SecretMetadata) {
+	fmt.Fprintf(w, "remaining_ttl: %s\n", metadata.RemainingTTL.Round(time.Second))
+	if metadata.AbsoluteDeadline != nil {
+		fmt.Fprintf(w, "absolute_deadline: %s\n", metadata.AbsoluteDeadline.Format(time.RFC3339))
+	} else {
+		fmt.Fprintf(w, "absolute_deadline: none\n")
+	}
+}
+
+func runLocate(ctx context.Context, opts *options.Client, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: burnafter locate <name>")
+	}
+
+	name := args[0]
+
+	c := burnafter.NewClient(opts, burnafter.WithServerLauncher(embedded.Launch))
+	if err := c.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer c.Close() //nolint:errcheck
+
+	location, err := c.Locate(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to locate secret: %w", err)
+	}
+
+	fmt.Println(location)
+	return nil
+}
+
+func runConfig(ctx context.Context, opts *options.Client) error {
+	// Create the new client, but don't connect
+	c := burnafter.NewClient(opts, burnafter.WithServerLauncher(embedded.Launch))
+
+	// Only query a server that is already running, don't start one just to
+	// read its defaults back.
+	if !c.IsServerRunning(ctx) {
+		return fmt.Errorf("server is not running")
+	}
+
+	if err := c.Connect(ctx); err != nil {
+		return fmt.Errorf("error connecting to server: %w", err)
+	}
+	defer c.Close() //nolint:errcheck
+
+	config, err := c.GetConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get server config: %w", err)
+	}
+
+	fmt.Printf("default_ttl:        %s\n", config.DefaultTTL)
+	fmt.Printf("max_secrets:        %d\n", config.MaxSecrets)
+	fmt.Printf("max_secret_size:    %d\n", config.MaxSecretSize)
+	fmt.Printf("inactivity_timeout: %s\n", config.InactivityTimeout)
+	fmt.Printf("backend:            %s\n", config.Backend)
+	fmt.Printf("cleanup_interval:   %s\n", config.CleanupInterval)
+	return nil
+}
+
 func runPing(ctx context.Context, opts *options.Client) error {
 	// Create the new client, but don't connect
 	c := burnafter.NewClient(opts, burnafter.WithServerLauncher(embedded.Launch))
@@ -0,0 +1,273 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/carabiner-dev/burnafter"
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func TestSecretFromEnv(t *testing.T) {
+	t.Setenv("BURNAFTER_TEST_SECRET", "from-the-environment")
+
+	secret, err := secretFromEnv("BURNAFTER_TEST_SECRET", false)
+	if err != nil {
+		t.Fatalf("secretFromEnv failed: %v", err)
+	}
+	if secret != "from-the-environment" {
+		t.Errorf("Expected from-the-environment, got %q", secret)
+	}
+}
+
+func TestSecretFromEnvUnset(t *testing.T) {
+	if _, err := secretFromEnv("BURNAFTER_TEST_UNSET_VAR", false); err == nil {
+		t.Errorf("Expected an error for an unset variable")
+	}
+}
+
+func TestSecretFromEnvEmpty(t *testing.T) {
+	t.Setenv("BURNAFTER_TEST_EMPTY", "")
+
+	if _, err := secretFromEnv("BURNAFTER_TEST_EMPTY", false); err == nil {
+		t.Errorf("Expected an error for an empty variable")
+	}
+
+	// -allow-empty lets an empty value through
+	secret, err := secretFromEnv("BURNAFTER_TEST_EMPTY", true)
+	if err != nil {
+		t.Fatalf("secretFromEnv failed with allowEmpty: %v", err)
+	}
+	if secret != "" {
+		t.Errorf("Expected an empty secret, got %q", secret)
+	}
+}
+
+// captureOutput runs fn with os.Stdout and os.Stderr redirected to pipes and
+// returns what was written to each.
+func captureOutput(t *testing.T, fn func() error) (stdout, stderr string) {
+	t.Helper()
+
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("creating stdout pipe: %v", err)
+	}
+	errR, errW, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("creating stderr pipe: %v", err)
+	}
+
+	origOut, origErr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = outW, errW
+	fnErr := fn()
+	os.Stdout, os.Stderr = origOut, origErr
+	outW.Close() //nolint:errcheck
+	errW.Close() //nolint:errcheck
+
+	if fnErr != nil {
+		t.Fatalf("captured function failed: %v", fnErr)
+	}
+
+	outData, err := io.ReadAll(outR)
+	if err != nil {
+		t.Fatalf("reading captured stdout: %v", err)
+	}
+	errData, err := io.ReadAll(errR)
+	if err != nil {
+		t.Fatalf("reading captured stderr: %v", err)
+	}
+	return string(outData), string(errData)
+}
+
+func TestRunGetMeta(t *testing.T) {
+	ctx := context.Background()
+
+	opts := *options.DefaultClient
+	opts.NoServer = true
+
+	name := fmt.Sprintf("cli-meta-test-%d", time.Now().UnixNano())
+	if err := runStore(ctx, &opts, []string{name, "the-secret-value", "60"}); err != nil {
+		t.Fatalf("runStore failed: %v", err)
+	}
+	t.Cleanup(func() {
+		c := burnafter.NewClient(&opts)
+		_ = c.Delete(ctx, name) //nolint:errcheck
+	})
+
+	stdout, stderr := captureOutput(t, func() error {
+		return runGet(ctx, &opts, []string{name, "-meta"})
+	})
+
+	// Stdout must carry only the value, so pipelines keep working
+	if stdout != "the-secret-value\n" {
+		t.Errorf("Expected only the secret on stdout, got %q", stdout)
+	}
+	if !strings.Contains(stderr, "remaining_ttl:") {
+		t.Errorf("Expected remaining_ttl on stderr, got %q", stderr)
+	}
+	if !strings.Contains(stderr, "absolute_deadline:") {
+		t.Errorf("Expected absolute_deadline on stderr, got %q", stderr)
+	}
+}
+
+func TestRunGetNoMeta(t *testing.T) {
+	ctx := context.Background()
+
+	opts := *options.DefaultClient
+	opts.NoServer = true
+
+	name := fmt.Sprintf("cli-nometa-test-%d", time.Now().UnixNano())
+	if err := runStore(ctx, &opts, []string{name, "plain-value", "60"}); err != nil {
+		t.Fatalf("runStore failed: %v", err)
+	}
+	t.Cleanup(func() {
+		c := burnafter.NewClient(&opts)
+		_ = c.Delete(ctx, name) //nolint:errcheck
+	})
+
+	stdout, stderr := captureOutput(t, func() error {
+		return runGet(ctx, &opts, []string{name})
+	})
+
+	if stdout != "plain-value\n" {
+		t.Errorf("Expected only the secret on stdout, got %q", stdout)
+	}
+	if stderr != "" {
+		t.Errorf("Expected empty stderr without -meta, got %q", stderr)
+	}
+}
+
+func TestPrintSecretMetadata(t *testing.T) {
+	deadline := time.Now().Add(2 * time.Hour)
+	var buf bytes.Buffer
+	printSecretMetadata(&buf, &burnafter.SecretMetadata{
+		RemainingTTL:     90 * time.Second,
+		AbsoluteDeadline: &deadline,
+	})
+	if !strings.Contains(buf.String(), "remaining_ttl: 1m30s") {
+		t.Errorf("Unexpected metadata output: %q", buf.String())
+	}
+	if !strings.Contains(buf.String(), deadline.Format(time.RFC3339)) {
+		t.Errorf("Expected the deadline in the output: %q", buf.String())
+	}
+
+	buf.Reset()
+	printSecretMetadata(&buf, &burnafter.SecretMetadata{RemainingTTL: time.Minute})
+	if !strings.Contains(buf.String(), "absolute_deadline: none") {
+		t.Errorf("Expected no deadline marker, got %q", buf.String())
+	}
+}
+
+func TestRunStoreGetNoServerNonce(t *testing.T) {
+	ctx := context.Background()
+
+	// Mirror what `-no-server -nonce foo` sets up in main()
+	opts := *options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = "foo"
+
+	name := fmt.Sprintf("cli-noserver-test-%d", time.Now().UnixNano())
+	if err := runStore(ctx, &opts, []string{name, "fallback-value", "60"}); err != nil {
+		t.Fatalf("runStore failed: %v", err)
+	}
+	t.Cleanup(func() {
+		c := burnafter.NewClient(&opts)
+		_ = c.Delete(ctx, name) //nolint:errcheck
+	})
+
+	// A fresh invocation with the same nonce reads the value back from the
+	// encrypted fallback file
+	sameNonce := *options.DefaultClient
+	sameNonce.NoServer = true
+	sameNonce.Nonce = "foo"
+	stdout, _ := captureOutput(t, func() error {
+		return runGet(ctx, &sameNonce, []string{name})
+	})
+	if stdout != "fallback-value\n" {
+		t.Errorf("Expected the secret on stdout, got %q", stdout)
+	}
+
+	// The nonce is a key-derivation input: a different one cannot decrypt
+	otherNonce := *options.DefaultClient
+	otherNonce.NoServer = true
+	otherNonce.Nonce = "bar"
+	if err := runGet(ctx, &otherNonce, []string{name}); err == nil {
+		t.Errorf("Expected runGet with a different nonce to fail")
+	}
+}
+
+func TestRunStoreFromEnvUnset(t *testing.T) {
+	// The error must surface before any connection attempt
+	err := runStore(context.Background(), options.DefaultClient,
+		[]string{"some-secret", "-from-env", "BURNAFTER_TEST_UNSET_VAR"})
+	if err == nil || !strings.Contains(err.Error(), "not set") {
+		t.Errorf("Expected an unset-variable error, got %v", err)
+	}
+}
+
+func TestRetryOnceOnRespawn(t *testing.T) {
+	ctx := context.Background()
+
+	opts := *options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = "test-nonce-retry"
+	c := burnafter.NewClient(&opts)
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	// First attempt hits a dead socket (the ephemeral daemon shut down); the
+	// retry after the reconnect succeeds
+	calls := 0
+	err := retryOnceOnRespawn(ctx, c, func() error {
+		calls++
+		if calls == 1 {
+			return fmt.Errorf("getting secret: %w",
+				status.Error(codes.Unavailable, "connection refused"))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected the retried operation to succeed, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected exactly 2 attempts, got %d", calls)
+	}
+}
+
+func TestRetryOnceOnRespawnNonTransient(t *testing.T) {
+	ctx := context.Background()
+
+	opts := *options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = "test-nonce-retry-nontransient"
+	c := burnafter.NewClient(&opts)
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	// A server-side answer (here: not found) must not trigger a retry
+	calls := 0
+	err := retryOnceOnRespawn(ctx, c, func() error {
+		calls++
+		return fmt.Errorf("server error: secret not found")
+	})
+	if err == nil {
+		t.Fatalf("Expected the error to surface")
+	}
+	if calls != 1 {
+		t.Errorf("Expected a single attempt, got %d", calls)
+	}
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/carabiner-dev/burnafter/internal/common"
+)
+
+// ServerConfig holds the effective non-secret configuration reported by a
+// running server, for troubleshooting mis-parsed or unexpected options.
+type ServerConfig struct {
+	// DefaultTTL applied to secrets stored without an explicit one
+	DefaultTTL time.Duration
+	// MaxSecrets the server will hold
+	MaxSecrets int64
+	// MaxSecretSize of a single secret, in bytes
+	MaxSecretSize int64
+	// InactivityTimeout after which the server shuts itself down (0 = disabled)
+	InactivityTimeout time.Duration
+	// Backend identifier of the storage driver, e.g. "keyring" or "memory"
+	Backend string
+	// CleanupInterval between expired-secret sweeps
+	CleanupInterval time.Duration
+}
+
+// GetConfig fetches the effective configuration from the running server. It
+// only makes sense in server mode; in the fallback and in-memory modes the
+// effective configuration is simply the client's own options.
+func (c *Client) GetConfig(ctx context.Context) (*ServerConfig, error) {
+	if c.useMemory() || c.useFallback() {
+		return nil, fmt.Errorf("no server running, config retrieval requires server mode")
+	}
+
+	if c.client == nil {
+		return nil, ErrNotConnected
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.GetConfig(ctx, &pb.GetConfigRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("getting server config: %w", err)
+	}
+
+	if !resp.Success {
+		return nil, fmt.Errorf("server error: %s", resp.Error)
+	}
+
+	return &ServerConfig{
+		DefaultTTL:        time.Duration(resp.DefaultTtlSeconds) * time.Second,
+		MaxSecrets:        resp.MaxSecrets,
+		MaxSecretSize:     resp.MaxSecretSize,
+		InactivityTimeout: time.Duration(resp.InactivityTimeoutSeconds) * time.Second,
+		Backend:           resp.Backend,
+		CleanupInterval:   time.Duration(resp.CleanupIntervalSeconds) * time.Second,
+	}, nil
+}
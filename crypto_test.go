@@ -5,6 +5,8 @@ package burnafter
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
 	"os"
 	"testing"
 	"time"
@@ -43,6 +45,35 @@ func TestDeriveKey(t *testing.T) {
 	}
 }
 
+func TestDeriveKeyForHashDeterministic(t *testing.T) {
+	opts := options.DefaultClient
+	opts.Nonce = testNonce
+	opts.NoServer = true
+
+	client := NewClient(opts)
+
+	fixedHash := "feedfacefeedfacefeedfacefeedfacefeedfacefeedfacefeedfacefeedface"
+	secretName := "fixed-hash-secret"
+
+	// A fixed binary hash must produce the same key every time, with no
+	// dependency on the live binary.
+	key1 := client.deriveKeyForHash(fixedHash, secretName, client.kdfParams())
+	key2 := client.deriveKeyForHash(fixedHash, secretName, client.kdfParams())
+
+	if len(key1) != 32 {
+		t.Errorf("Expected key length of 32, got %d", len(key1))
+	}
+	if !bytes.Equal(key1, key2) {
+		t.Errorf("Expected deterministic key for fixed hash")
+	}
+
+	// A different hash must produce a different key
+	otherHash := "deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef"
+	if bytes.Equal(key1, client.deriveKeyForHash(otherHash, secretName, client.kdfParams())) {
+		t.Errorf("Expected different keys for different binary hashes")
+	}
+}
+
 func TestDeriveKeyDifferentSecrets(t *testing.T) {
 	opts := options.DefaultClient
 	opts.Nonce = testNonce
@@ -106,19 +137,20 @@ func TestEncryptDecryptSecret(t *testing.T) {
 	opts.NoServer = true
 
 	client := NewClient(opts)
+	ctx := context.Background()
 
 	secretName := "crypto-test"
 	secretValue := []byte("my-secret-value")
 	expiryTime := time.Now().Add(1 * time.Hour)
 
 	// Encrypt
-	err := client.encryptSecret(secretName, secretValue, expiryTime)
+	err := client.encryptSecret(ctx, secretName, secretValue, expiryTime)
 	if err != nil {
 		t.Fatalf("encryptSecret failed: %v", err)
 	}
 
 	// Decrypt
-	decrypted, err := client.decryptSecret(secretName)
+	decrypted, err := client.decryptSecret(ctx, secretName)
 	if err != nil {
 		t.Fatalf("decryptSecret failed: %v", err)
 	}
@@ -137,19 +169,20 @@ func TestEncryptDecryptEmptySecret(t *testing.T) {
 	opts.NoServer = true
 
 	client := NewClient(opts)
+	ctx := context.Background()
 
 	secretName := "empty-secret"
 	secretValue := []byte("")
 	expiryTime := time.Now().Add(1 * time.Hour)
 
 	// Encrypt empty secret
-	err := client.encryptSecret(secretName, secretValue, expiryTime)
+	err := client.encryptSecret(ctx, secretName, secretValue, expiryTime)
 	if err != nil {
 		t.Fatalf("encryptSecret failed: %v", err)
 	}
 
 	// Decrypt
-	decrypted, err := client.decryptSecret(secretName)
+	decrypted, err := client.decryptSecret(ctx, secretName)
 	if err != nil {
 		t.Fatalf("decryptSecret failed: %v", err)
 	}
@@ -168,6 +201,7 @@ func TestEncryptDecryptLargeSecret(t *testing.T) {
 	opts.NoServer = true
 
 	client := NewClient(opts)
+	ctx := context.Background()
 
 	secretName := "large-secret"
 	// Create 10KB secret
@@ -178,13 +212,13 @@ func TestEncryptDecryptLargeSecret(t *testing.T) {
 	expiryTime := time.Now().Add(1 * time.Hour)
 
 	// Encrypt
-	err := client.encryptSecret(secretName, secretValue, expiryTime)
+	err := client.encryptSecret(ctx, secretName, secretValue, expiryTime)
 	if err != nil {
 		t.Fatalf("encryptSecret failed: %v", err)
 	}
 
 	// Decrypt
-	decrypted, err := client.decryptSecret(secretName)
+	decrypted, err := client.decryptSecret(ctx, secretName)
 	if err != nil {
 		t.Fatalf("decryptSecret failed: %v", err)
 	}
@@ -203,6 +237,7 @@ func TestDecryptExpiredSecret(t *testing.T) {
 	opts.NoServer = true
 
 	client := NewClient(opts)
+	ctx := context.Background()
 
 	secretName := "expired-secret"
 	secretValue := []byte("will-expire")
@@ -210,13 +245,13 @@ func TestDecryptExpiredSecret(t *testing.T) {
 	expiryTime := time.Now().Add(-1 * time.Hour)
 
 	// Encrypt
-	err := client.encryptSecret(secretName, secretValue, expiryTime)
+	err := client.encryptSecret(ctx, secretName, secretValue, expiryTime)
 	if err != nil {
 		t.Fatalf("encryptSecret failed: %v", err)
 	}
 
 	// Try to decrypt - should fail
-	_, err = client.decryptSecret(secretName)
+	_, err = client.decryptSecret(ctx, secretName)
 	if err == nil {
 		t.Errorf("Expected error when decrypting expired secret")
 	}
@@ -231,8 +266,9 @@ func TestDecryptNonExistent(t *testing.T) {
 	opts.NoServer = true
 
 	client := NewClient(opts)
+	ctx := context.Background()
 
-	_, err := client.decryptSecret("does-not-exist")
+	_, err := client.decryptSecret(ctx, "does-not-exist")
 	if err == nil {
 		t.Errorf("Expected error when decrypting non-existent secret")
 	}
@@ -244,6 +280,7 @@ func TestEncryptSecretTwiceOverwrites(t *testing.T) {
 	opts.NoServer = true
 
 	client := NewClient(opts)
+	ctx := context.Background()
 
 	secretName := "overwrite-test"
 	value1 := []byte("first-value")
@@ -251,19 +288,19 @@ func TestEncryptSecretTwiceOverwrites(t *testing.T) {
 	expiryTime := time.Now().Add(1 * time.Hour)
 
 	// Encrypt first value
-	err := client.encryptSecret(secretName, value1, expiryTime)
+	err := client.encryptSecret(ctx, secretName, value1, expiryTime)
 	if err != nil {
 		t.Fatalf("encryptSecret failed: %v", err)
 	}
 
 	// Encrypt second value (overwrite)
-	err = client.encryptSecret(secretName, value2, expiryTime)
+	err = client.encryptSecret(ctx, secretName, value2, expiryTime)
 	if err != nil {
 		t.Fatalf("encryptSecret failed on overwrite: %v", err)
 	}
 
 	// Decrypt should get second value
-	decrypted, err := client.decryptSecret(secretName)
+	decrypted, err := client.decryptSecret(ctx, secretName)
 	if err != nil {
 		t.Fatalf("decryptSecret failed: %v", err)
 	}
@@ -282,13 +319,14 @@ func TestEncryptedFileFormat(t *testing.T) {
 	opts.NoServer = true
 
 	client := NewClient(opts)
+	ctx := context.Background()
 
 	secretName := "format-test"
 	secretValue := []byte("test-value")
 	expiryTime := time.Now().Add(1 * time.Hour)
 
 	// Encrypt
-	err := client.encryptSecret(secretName, secretValue, expiryTime)
+	err := client.encryptSecret(ctx, secretName, secretValue, expiryTime)
 	if err != nil {
 		t.Fatalf("encryptSecret failed: %v", err)
 	}
@@ -302,16 +340,23 @@ func TestEncryptedFileFormat(t *testing.T) {
 		t.Fatalf("Failed to read file: %v", err)
 	}
 
-	// Check minimum size: version(1) + nonce(12) + expiry(8) + ciphertext+tag(>=16)
-	minSize := 1 + 12 + 8 + 16
+	// Check minimum size: version(1) + name_hash(32) + nonce(12) + expiry(8) +
+	// iterations(4) + ciphertext+tag(>=16)
+	minSize := 1 + 32 + 12 + 8 + 4 + 16
 	if len(data) < minSize {
 		t.Errorf("Encrypted file too small: %d bytes (expected at least %d)", len(data), minSize)
 	}
 
 	// Check version
 	version := data[0]
-	if version != 1 {
-		t.Errorf("Expected version 1, got %d", version)
+	if version != kdfFallbackFileVersion {
+		t.Errorf("Expected version %d, got %d", kdfFallbackFileVersion, version)
+	}
+
+	// The header records the full hash of the secret name
+	nameHash := sha256.Sum256([]byte(secretName))
+	if !bytes.Equal(data[1:1+32], nameHash[:]) {
+		t.Errorf("Expected header to carry the secret name hash")
 	}
 }
 
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/carabiner-dev/burnafter/internal/common"
+)
+
+// SetDebug toggles the running server's debug logging at runtime, without
+// restarting the daemon (and losing its secrets). The server must have been
+// started with the AllowRuntimeDebug option. Only server mode applies: in the
+// fallback and in-memory modes there is no daemon producing logs.
+func (c *Client) SetDebug(ctx context.Context, enabled bool) error {
+	if c.useMemory() || c.useFallback() {
+		return fmt.Errorf("no server running, debug toggling requires server mode")
+	}
+
+	if c.client == nil {
+		return ErrNotConnected
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.SetDebug(ctx, &pb.SetDebugRequest{Enabled: enabled})
+	if err != nil {
+		return fmt.Errorf("toggling server debug logging: %w", err)
+	}
+
+	if !resp.Success {
+		return fmt.Errorf("server error: %s", resp.Error)
+	}
+
+	return nil
+}
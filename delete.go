@@ -5,12 +5,27 @@ package burnafter
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
+
+	pb "github.com/carabiner-dev/burnafter/internal/common"
 )
 
-// Delete removes a secret from fallback encrypted file storage
-// Note: Delete is only supported in fallback mode currently
+// ErrSecretNotFound is returned by DeleteStrict when the named secret does not
+// exist in the active storage mode.
+var ErrSecretNotFound = errors.New("secret not found")
+
+// Delete removes a secret. It is idempotent: deleting a secret that does not
+// exist (or was already reaped) succeeds, so callers can tear down without
+// first checking what is still there. Use DeleteStrict to be told whether the
+// secret was actually present.
 func (c *Client) Delete(ctx context.Context, name string) error {
+	// A deleted secret must not linger in the read cache
+	if c.cache != nil {
+		c.cache.invalidate(name)
+	}
+
 	// In-memory mode removes from the ephemeral backend.
 	if c.useMemory() {
 		c.deleteFromMemory(ctx, name)
@@ -19,17 +34,107 @@ func (c *Client) Delete(ctx context.Context, name string) error {
 
 	// Use fallback storage if server is not available
 	if c.useFallback() {
-		// Delete from file
+		// Delete from file; a missing file means there is nothing to do
+		if err := c.deleteFallbackSecret(name); err != nil && !errors.Is(err, ErrSecretNotFound) {
+			return err
+		}
+
+		// Cleanup expired files
+		_ = c.cleanupExpiredFallbackFiles(ctx) //nolint:errcheck
+
+		return nil
+	}
+
+	// Server mode: a missing secret is nothing to do, everything else is an
+	// error worth reporting
+	if err := c.deleteFromServer(ctx, name); err != nil && !errors.Is(err, ErrSecretNotFound) {
+		return err
+	}
+	return nil
+}
+
+// DeleteStrict is Delete with strict semantics: it returns ErrSecretNotFound
+// when the named secret does not exist, for callers that need to know the
+// delete actually removed something.
+func (c *Client) DeleteStrict(ctx context.Context, name string) error {
+	// A deleted secret must not linger in the read cache
+	if c.cache != nil {
+		c.cache.invalidate(name)
+	}
+
+	// In-memory mode checks the ephemeral backend before removing.
+	if c.useMemory() {
+		return c.deleteFromMemoryStrict(ctx, name)
+	}
+
+	if c.useFallback() {
 		if err := c.deleteFallbackSecret(name); err != nil {
 			return err
 		}
 
 		// Cleanup expired files
-		_ = c.cleanupExpiredFallbackFiles() //nolint:errcheck
+		_ = c.cleanupExpiredFallbackFiles(ctx) //nolint:errcheck
 
 		return nil
 	}
 
-	// Server mode - Delete not yet implemented in server
-	return fmt.Errorf("delete is only supported in fallback mode")
+	// Server mode
+	return c.deleteFromServer(ctx, name)
+}
+
+// deleteFromServer issues the Delete RPC, mapping the server's error code
+// onto the sentinel errors the same way reads do.
+func (c *Client) deleteFromServer(ctx context.Context, name string) error {
+	if c.client == nil {
+		return ErrNotConnected
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.Delete(ctx, &pb.DeleteRequest{Name: name})
+	if err != nil {
+		return fmt.Errorf("deleting secret: %w", err)
+	}
+
+	if !resp.Success {
+		return serverError(resp.ErrorCode, resp.Error)
+	}
+
+	return nil
+}
+
+// DeleteWhere deletes every secret on the server matching a predicate: all
+// the given labels (set at store time with options.WithLabels) must match
+// and, when olderThan is non-zero, the secret must have been stored at least
+// that long ago. It returns the number of secrets deleted. At least one of
+// the two predicate parts must be specified.
+//
+// Predicate deletes only exist in server mode: the ephemeral and fallback
+// stores do not track labels or creation times.
+func (c *Client) DeleteWhere(ctx context.Context, labels map[string]string, olderThan time.Duration) (int64, error) {
+	if c.useMemory() || c.useFallback() {
+		return 0, fmt.Errorf("DeleteWhere is only supported in server mode")
+	}
+
+	if c.client == nil {
+		return 0, ErrNotConnected
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.DeleteWhere(ctx, &pb.DeleteWhereRequest{
+		Labels:           labels,
+		OlderThanSeconds: int64(olderThan.Seconds()),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("deleting secrets: %w", err)
+	}
+
+	if !resp.Success {
+		return 0, fmt.Errorf("server error: %s", resp.Error)
+	}
+
+	return resp.Deleted, nil
 }
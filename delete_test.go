@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func TestDeleteIdempotentFallback(t *testing.T) {
+	ctx := context.Background()
+	client := newFallbackTestClient(t, "test-nonce-delete-fallback")
+
+	if err := client.Store(ctx, "delete-me", "value", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+
+	// Deleting an existing secret succeeds and removes it
+	if err := client.Delete(ctx, "delete-me"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := client.Get(ctx, "delete-me"); err == nil {
+		t.Errorf("Expected deleted secret to be gone")
+	}
+
+	// Deleting it again (or any missing secret) is a success
+	if err := client.Delete(ctx, "delete-me"); err != nil {
+		t.Errorf("Expected idempotent delete of a missing secret, got: %v", err)
+	}
+	if err := client.Delete(ctx, "never-existed"); err != nil {
+		t.Errorf("Expected idempotent delete of a missing secret, got: %v", err)
+	}
+}
+
+func TestDeleteStrictFallback(t *testing.T) {
+	ctx := context.Background()
+	client := newFallbackTestClient(t, "test-nonce-delete-strict-fallback")
+
+	if err := client.Store(ctx, "strict-me", "value", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+
+	if err := client.DeleteStrict(ctx, "strict-me"); err != nil {
+		t.Fatalf("DeleteStrict of an existing secret failed: %v", err)
+	}
+
+	if err := client.DeleteStrict(ctx, "strict-me"); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("Expected ErrSecretNotFound for a missing secret, got: %v", err)
+	}
+}
+
+func TestDeleteIdempotentInMemory(t *testing.T) {
+	ctx := context.Background()
+	c := newInMemoryClient()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+
+	if err := c.Store(ctx, "delete-mem", "v", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	if err := c.Delete(ctx, "delete-mem"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := c.Delete(ctx, "delete-mem"); err != nil {
+		t.Errorf("Expected idempotent delete of a missing secret, got: %v", err)
+	}
+}
+
+func TestDeleteStrictInMemory(t *testing.T) {
+	ctx := context.Background()
+	c := newInMemoryClient()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+
+	if err := c.Store(ctx, "strict-mem", "v", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	if err := c.DeleteStrict(ctx, "strict-mem"); err != nil {
+		t.Fatalf("DeleteStrict of an existing secret failed: %v", err)
+	}
+	if err := c.DeleteStrict(ctx, "strict-mem"); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("Expected ErrSecretNotFound for a missing secret, got: %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// newDepsClient builds a client around an injected clock (readable and
+// advanceable through the returned pointer) for sleep-free expiry tests.
+func newDepsClient(inMemory bool) (*Client, *time.Time) {
+	now := time.Unix(1700000000, 0)
+	opts := *options.DefaultClient
+	opts.InMemory = inMemory
+	opts.NoServer = !inMemory
+	opts.Nonce = testNonce
+	c := NewClient(&opts, WithDeps(Deps{Clock: func() time.Time { return now }}))
+	return c, &now
+}
+
+func TestDepsClockDrivesMemoryExpiry(t *testing.T) {
+	c, now := newDepsClient(true)
+
+	if err := c.Store(t.Context(), "ticking", "value", options.WithTTL(60)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+
+	// Within the TTL the secret reads back fine
+	if secret, err := c.Get(t.Context(), "ticking"); err != nil || secret != "value" {
+		t.Fatalf("Expected a live secret, got %q, %v", secret, err)
+	}
+
+	// Advance the clock past the TTL: the secret expires without any sleeping
+	*now = now.Add(2 * time.Minute)
+	if _, err := c.Get(t.Context(), "ticking"); !errors.Is(err, ErrSecretExpired) {
+		t.Errorf("Expected ErrSecretExpired after advancing the clock, got %v", err)
+	}
+}
+
+func TestDepsClockDrivesFallbackExpiry(t *testing.T) {
+	c, now := newDepsClient(false)
+
+	if err := c.Store(t.Context(), "ticking-file", "value", options.WithTTL(60)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	t.Cleanup(func() {
+		if path, err := c.getFallbackFilePath("ticking-file"); err == nil {
+			os.Remove(path) //nolint:errcheck,gosec
+		}
+	})
+
+	if secret, err := c.Get(t.Context(), "ticking-file"); err != nil || secret != "value" {
+		t.Fatalf("Expected a live secret, got %q, %v", secret, err)
+	}
+
+	*now = now.Add(2 * time.Minute)
+	if _, err := c.Get(t.Context(), "ticking-file"); !errors.Is(err, ErrSecretExpired) {
+		t.Errorf("Expected ErrSecretExpired after advancing the clock, got %v", err)
+	}
+}
+
+func TestDepsDeterministicFallbackFile(t *testing.T) {
+	// Two independent clients with the same injected clock and randomness
+	// must produce byte-identical fallback files: with both seams pinned,
+	// nothing in the format depends on the environment anymore.
+	write := func() []byte {
+		opts := *options.DefaultClient
+		opts.NoServer = true
+		opts.Nonce = testNonce
+		c := NewClient(&opts, WithDeps(Deps{
+			Clock: func() time.Time { return time.Unix(1700000000, 0) },
+			Rand:  &fixedReader{},
+		}))
+		if err := c.Store(t.Context(), "pinned", "pinned-value", options.WithTTL(3600)); err != nil {
+			t.Fatalf("Store failed: %v", err)
+		}
+		path, err := c.getFallbackFilePath("pinned")
+		if err != nil {
+			t.Fatalf("getFallbackFilePath failed: %v", err)
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Reading fallback file: %v", err)
+		}
+		os.Remove(path) //nolint:errcheck,gosec
+		return contents
+	}
+
+	first := write()
+	second := write()
+	if string(first) != string(second) {
+		t.Errorf("Expected identical fallback files from identical deps")
+	}
+}
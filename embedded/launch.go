@@ -17,26 +17,49 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"syscall"
 	"time"
 
 	iembedded "github.com/carabiner-dev/burnafter/internal/embedded"
 	"github.com/carabiner-dev/burnafter/options"
 )
 
+// extractServerBinary writes the embedded server binary to disk for
+// execution. With the CacheServerBinary option it goes to the stable per-user
+// cache, where later invocations reuse the integrity-checked copy; otherwise
+// it goes to an ephemeral temp file, returned as cleanupPath so the caller
+// removes it once the server is running.
+func extractServerBinary(ctx context.Context, opts *options.Client) (path, cleanupPath string, err error) {
+	if opts.CacheServerBinary {
+		path, err = iembedded.ExtractServerBinaryToCache(ctx, "")
+		return path, "", err
+	}
+	// Extraction is only attempted once in-memory (memfd) execution has
+	// already failed, so a hardened deployment that forbids dropping an
+	// executable on disk fails loudly here instead of falling back.
+	if opts.NoTempBinaryExtraction {
+		return "", "", fmt.Errorf("in-memory (memfd) execution unavailable and temp binary extraction is disabled")
+	}
+	path, err = iembedded.ExtractServerBinaryToTemp(ctx)
+	return path, path, err
+}
+
 // Launch starts the embedded burnafter server as a detached subprocess
 // configured with opts. It executes the embedded binary from memory
 // (memfd_create) when possible, falling back to extracting it to a temp file
-// (e.g. on macOS or when memfd is blocked). It matches burnafter's ServerLauncher
+// (e.g. on macOS or when memfd is blocked) or, with the CacheServerBinary
+// option, to a reusable per-user cache. It matches burnafter's ServerLauncher
 // signature, so pass it via burnafter.WithServerLauncher(embedded.Launch).
 func Launch(ctx context.Context, opts *options.Client) error {
 	var cmd *exec.Cmd
 	var memFile *os.File
 	var tempServerPath string // Track temp file for cleanup
 
-	// Try memfd approach first (better security, no disk writes).
-	memfd, err := iembedded.CreateMemfdServer(ctx)
-	if err == nil {
+	// Try memfd approach first (better security, no disk writes). Any create
+	// error — memfd_create blocked, a short or failed write of the binary —
+	// leaves cmd nil, so every memfd failure mode routes to the temp-file
+	// fallback below instead of aborting the launch.
+	memfd, memfdErr := iembedded.CreateMemfdServer(ctx)
+	if memfdErr == nil {
 		if memfd < 0 {
 			return fmt.Errorf("invalid memfd file descriptor: %d", memfd)
 		}
@@ -58,14 +81,18 @@ func Launch(ctx context.Context, opts *options.Client) error {
 	// or is unsupported (e.g. macOS).
 	if cmd == nil {
 		if opts.Debug {
-			fmt.Fprintf(os.Stderr, "memfd unavailable, falling back to temp file...\n")
+			if memfdErr != nil {
+				fmt.Fprintf(os.Stderr, "memfd unavailable (%v), falling back to temp file...\n", memfdErr)
+			} else {
+				fmt.Fprintf(os.Stderr, "memfd unavailable, falling back to temp file...\n")
+			}
 		}
 
-		serverPath, err := iembedded.ExtractServerBinaryToTemp(ctx)
+		serverPath, cleanupPath, err := extractServerBinary(ctx, opts)
 		if err != nil {
 			return fmt.Errorf("failed to extract server binary: %w", err)
 		}
-		tempServerPath = serverPath
+		tempServerPath = cleanupPath
 
 		cmd = exec.CommandContext(ctx, serverPath) //nolint:gosec // Path is controlled
 	}
@@ -79,9 +106,7 @@ func Launch(ctx context.Context, opts *options.Client) error {
 	cmd.Env = os.Environ()
 
 	// Detach from the parent process.
-	cmd.SysProcAttr = &syscall.SysProcAttr{
-		Setsid: true,
-	}
+	cmd.SysProcAttr = detachSysProcAttr()
 
 	if opts.Debug {
 		cmd.Stdout = os.Stdout
@@ -109,18 +134,16 @@ func Launch(ctx context.Context, opts *options.Client) error {
 
 			memFile.Close() //nolint:errcheck,gosec
 
-			serverPath, extractErr := iembedded.ExtractServerBinaryToTemp(ctx)
+			serverPath, cleanupPath, extractErr := extractServerBinary(ctx, opts)
 			if extractErr != nil {
 				return fmt.Errorf("failed to extract server binary: %w", extractErr)
 			}
-			tempServerPath = serverPath
+			tempServerPath = cleanupPath
 
 			cmd = exec.CommandContext(ctx, serverPath) //nolint:gosec // Path is controlled
 			cmd.Args = append([]string{cmd.Path, string(optionsJSON)}, cmd.Args[1:]...)
 			cmd.Env = os.Environ()
-			cmd.SysProcAttr = &syscall.SysProcAttr{
-				Setsid: true,
-			}
+			cmd.SysProcAttr = detachSysProcAttr()
 
 			if opts.Debug {
 				cmd.Stdout = os.Stdout
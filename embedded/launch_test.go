@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package embedded
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func TestExtractServerBinaryCached(t *testing.T) {
+	if !ServerBinaryAvailable() {
+		t.Skip("no server binary embedded in this build")
+	}
+
+	// Point the user cache dir at a temp dir so the test doesn't touch (or
+	// depend on) the real per-user cache.
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	opts := *options.DefaultClient
+	opts.CacheServerBinary = true
+
+	path1, cleanup1, err := extractServerBinary(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("extractServerBinary failed: %v", err)
+	}
+	if cleanup1 != "" {
+		t.Errorf("Expected no cleanup path for a cached binary, got %q", cleanup1)
+	}
+
+	info1, err := os.Stat(path1)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+
+	// A second extraction must reuse the cached file rather than rewrite it
+	path2, _, err := extractServerBinary(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("extractServerBinary failed on reuse: %v", err)
+	}
+	if path2 != path1 {
+		t.Errorf("Expected the same cached path, got %q and %q", path1, path2)
+	}
+
+	info2, err := os.Stat(path2)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if !info2.ModTime().Equal(info1.ModTime()) {
+		t.Errorf("Expected the cached binary to be reused, but it was rewritten")
+	}
+}
+
+func TestExtractServerBinaryDisabled(t *testing.T) {
+	// Point the temp dir at an empty throwaway so a bug here would leave
+	// visible droppings to assert on
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+
+	opts := *options.DefaultClient
+	opts.NoTempBinaryExtraction = true
+
+	// Extraction only runs after memfd already failed; with the option set it
+	// must refuse instead of writing an executable to disk
+	if _, _, err := extractServerBinary(context.Background(), &opts); err == nil {
+		t.Fatalf("Expected an error with temp extraction disabled")
+	}
+
+	entries, err := os.ReadDir(tmp)
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected the temp dir to stay untouched, found %d entries", len(entries))
+	}
+}
+
+func TestExtractServerBinaryTempCleanup(t *testing.T) {
+	if !ServerBinaryAvailable() {
+		t.Skip("no server binary embedded in this build")
+	}
+
+	opts := *options.DefaultClient
+
+	path, cleanupPath, err := extractServerBinary(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("extractServerBinary failed: %v", err)
+	}
+	defer os.Remove(path) //nolint:errcheck
+
+	// Without the cache option, the caller is expected to delete the file
+	if cleanupPath != path {
+		t.Errorf("Expected cleanup path %q to match the extracted path %q", path, cleanupPath)
+	}
+}
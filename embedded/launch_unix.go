@@ -0,0 +1,15 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package embedded
+
+import "syscall"
+
+// detachSysProcAttr returns the process attributes that detach the spawned
+// server from the parent: a new session, so it survives the client exiting
+// and shares no controlling terminal.
+func detachSysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+
+package embedded
+
+import (
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+// detachSysProcAttr returns the process attributes that detach the spawned
+// server from the parent: its own process group and no inherited console, the
+// Windows equivalent of a new session.
+func detachSysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{
+		CreationFlags: windows.CREATE_NEW_PROCESS_GROUP | windows.DETACHED_PROCESS,
+	}
+}
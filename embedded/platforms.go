@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package embedded
+
+import (
+	iembedded "github.com/carabiner-dev/burnafter/internal/embedded"
+)
+
+// ServerBinaryAvailable reports whether this build embeds a server binary for
+// the current platform. Callers can check it before relying on server mode,
+// e.g. to force the fallback storage instead of letting Launch fail at
+// connect time.
+//
+// This lives here rather than in the root burnafter package on purpose: the
+// answer requires linking the embedded blob, and only programs importing this
+// package carry it.
+func ServerBinaryAvailable() bool {
+	return iembedded.ServerBinaryAvailable()
+}
+
+// EmbeddedPlatforms returns the os/arch pairs this build can launch an
+// embedded server for. Builds embed only their own platform's binary, so the
+// result is either empty or the single platform the program was built for.
+func EmbeddedPlatforms() []string {
+	return iembedded.Platforms()
+}
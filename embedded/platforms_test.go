@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package embedded
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestEmbeddedPlatforms(t *testing.T) {
+	if !ServerBinaryAvailable() {
+		t.Skip("no server binary embedded in this build")
+	}
+
+	platforms := EmbeddedPlatforms()
+	if len(platforms) != 1 {
+		t.Fatalf("Expected exactly one embedded platform, got %v", platforms)
+	}
+
+	// A build with a blob must report its own platform as available
+	current := runtime.GOOS + "/" + runtime.GOARCH
+	if platforms[0] != current {
+		t.Errorf("Expected %s, got %s", current, platforms[0])
+	}
+}
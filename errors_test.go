@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	pb "github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func TestServerErrorCodeMapping(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		code     pb.ErrorCode
+		msg      string
+		sentinel error
+	}{
+		{"not found", pb.ErrorCode_ERROR_NOT_FOUND, "secret not found", ErrSecretNotFound},
+		{"expired", pb.ErrorCode_ERROR_EXPIRED, "secret has expired due to inactivity", ErrSecretExpired},
+		{"binary mismatch", pb.ErrorCode_ERROR_BINARY_MISMATCH, "client binary hash mismatch - unauthorized", ErrBinaryMismatch},
+		{"not yet valid", pb.ErrorCode_ERROR_NOT_YET_VALID, "secret not yet valid (not before 2026-01-01T00:00:00Z)", ErrNotYetValid},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := serverError(tc.code, tc.msg)
+			if !errors.Is(err, tc.sentinel) {
+				t.Errorf("Expected %v, got: %v", tc.sentinel, err)
+			}
+			// The detail past the sentinel text must survive the mapping
+			if suffix := strings.TrimPrefix(tc.msg, tc.sentinel.Error()); suffix != "" &&
+				!strings.Contains(err.Error(), suffix) {
+				t.Errorf("Expected the message detail %q to be preserved, got: %v", suffix, err)
+			}
+		})
+	}
+}
+
+func TestServerErrorWithoutCodeFallsBackToText(t *testing.T) {
+	// Responses from servers built before the code field carry ERROR_UNSPECIFIED
+	// and still map through the message text
+	if err := serverError(pb.ErrorCode_ERROR_UNSPECIFIED, "secret not found"); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("Expected ErrSecretNotFound from the text fallback, got: %v", err)
+	}
+	if err := serverError(pb.ErrorCode_ERROR_UNSPECIFIED, "something else broke"); err == nil ||
+		errors.Is(err, ErrSecretNotFound) || errors.Is(err, ErrSecretExpired) {
+		t.Errorf("Expected an unclassified error, got: %v", err)
+	}
+}
+
+func TestNotConnectedSentinel(t *testing.T) {
+	// A server-mode client that never connected reports ErrNotConnected
+	opts := *options.DefaultClient
+	opts.NoServer = false // other tests flip this on the shared default
+	c := NewClient(&opts)
+
+	if err := c.deleteFromServer(context.Background(), "anything"); !errors.Is(err, ErrNotConnected) {
+		t.Errorf("Expected ErrNotConnected, got: %v", err)
+	}
+}
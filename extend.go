@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/carabiner-dev/burnafter/internal/common"
+)
+
+// ErrLifetimeExceeded is returned by ExtendDeadline when the requested
+// deadline would push the secret past the server's maximum absolute lifetime.
+var ErrLifetimeExceeded = errors.New("extension exceeds the secret's maximum lifetime")
+
+// ExtendDeadline pushes a secret's absolute expiration deadline forward to
+// newAbsolute, for long-running jobs that outlast the deadline set at store
+// time. The server bounds extensions by its maximum-lifetime option, measured
+// from when the secret was originally stored; extensions past that ceiling
+// fail with ErrLifetimeExceeded, so secrets are guaranteed to die eventually
+// no matter how often a job extends them.
+//
+// Deadline extensions only exist in server mode, where the lifecycle metadata
+// lives.
+func (c *Client) ExtendDeadline(ctx context.Context, name string, newAbsolute time.Time) error {
+	if c.useMemory() || c.useFallback() {
+		return fmt.Errorf("ExtendDeadline is only supported in server mode")
+	}
+
+	if c.client == nil {
+		return ErrNotConnected
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.ExtendDeadline(ctx, &pb.ExtendDeadlineRequest{
+		Name:                 name,
+		NewAbsoluteExpiresAt: newAbsolute.Unix(),
+	})
+	if err != nil {
+		return fmt.Errorf("extending deadline: %w", err)
+	}
+
+	if !resp.Success {
+		if resp.LifetimeExceeded {
+			return fmt.Errorf("%w: %s", ErrLifetimeExceeded, resp.Error)
+		}
+		return fmt.Errorf("server error: %s", resp.Error)
+	}
+
+	return nil
+}
@@ -4,11 +4,13 @@
 package burnafter
 
 import (
+	"bytes"
+	"context"
 	"crypto/aes"
 	"crypto/cipher"
-	"crypto/rand"
 	"crypto/sha256"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -16,46 +18,247 @@ import (
 	"path/filepath"
 	"time"
 
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/pbkdf2"
 
 	pb "github.com/carabiner-dev/burnafter/internal/common"
 )
 
 const (
-	fallbackFileVersion = 1
-	pbkdf2Iterations    = 100000
-	aesKeySize          = 32 // AES-256
-	gcmNonceSize        = 12
+	fallbackFileVersion       = 2
+	legacyFallbackFileVersion = 1
+	aesKeySize                = 32 // AES-256
+	gcmNonceSize              = 12
+	nameHashSize              = sha256.Size
+
+	// defaultPBKDF2Iterations is the PBKDF2-SHA256 work factor for newly
+	// written files, per current OWASP guidance. Versions up to 5 predate the
+	// header's iteration field and are implicitly legacyPBKDF2Iterations.
+	defaultPBKDF2Iterations = 600000
+	legacyPBKDF2Iterations  = 100000
+
+	// maxPBKDF2Iterations bounds the count honored from a file header. The
+	// header is authenticated, but only after the key is derived with the
+	// count it claims — without a cap a corrupted header could demand an
+	// arbitrary amount of derivation work before authentication rejects it.
+	maxPBKDF2Iterations = 10_000_000
+
+	// directKeyFallbackFileVersion marks files encrypted with a caller-supplied
+	// key (the FallbackKey option) instead of the PBKDF2 derivation. The layout
+	// is identical to version 2 — there never were salt or iteration fields to
+	// drop — but the version byte records that no KDF was used, so a key-mode
+	// mismatch is reported clearly instead of as a generic decryption failure.
+	directKeyFallbackFileVersion = 3
+
+	// The not-before variants append an 8-byte sealed-until timestamp to the
+	// v2/v3 header, written only for secrets stored with the WithNotBefore
+	// option so everything else keeps the established formats. Version 4 pairs
+	// with the derived-key v2 layout, version 5 with the direct-key v3 one.
+	notBeforeFallbackFileVersion          = 4
+	directKeyNotBeforeFallbackFileVersion = 5
+
+	// The KDF variants append a 4-byte PBKDF2 iteration count to the header,
+	// making the work factor configurable without orphaning files written
+	// under a different setting. Version 6 extends the derived-key v2 layout,
+	// version 7 the not-before v4 one; the direct-key formats never derive a
+	// key, so they have no counterpart here. New derived-key files are always
+	// written in these formats.
+	kdfFallbackFileVersion          = 6
+	kdfNotBeforeFallbackFileVersion = 7
+
+	// The Argon2id variants record that the key was derived with Argon2id
+	// instead of PBKDF2, along with its cost parameters (memory in KiB,
+	// passes, lanes) in place of the iteration count. Version 8 mirrors the
+	// v6 layout, version 9 the not-before v7 one.
+	argon2idFallbackFileVersion          = 8
+	argon2idNotBeforeFallbackFileVersion = 9
+
+	// The read-budget variants (the WithMaxReads store option) append an
+	// 8-byte remaining-reads counter, decremented by an atomic rewrite on
+	// each successful decrypt until the file burns. They always carry the
+	// not-before field (0 = none) so the budgeted formats don't fork into
+	// with/without pairs of their own. Version 10 is the PBKDF2 layout,
+	// version 11 Argon2id, version 12 the direct-key one.
+	maxReadsKDFFallbackFileVersion       = 10
+	maxReadsArgon2idFallbackFileVersion  = 11
+	maxReadsDirectKeyFallbackFileVersion = 12
 )
 
+// Default Argon2id cost parameters for newly written files, per the
+// x/crypto/argon2 recommendation. Like the PBKDF2 count, they are recorded in
+// the header so they can change without orphaning existing files; the caps
+// bound what a corrupted header can demand before authentication rejects it.
+const (
+	defaultArgon2Memory  = 64 * 1024 // KiB
+	defaultArgon2Time    = 1
+	defaultArgon2Threads = 4
+	maxArgon2Memory      = 1 << 20 // 1 GiB in KiB
+	maxArgon2Time        = 64
+)
+
+// ErrNameCollision is returned when a fallback file exists at a secret's path
+// but was written for a different name. File names truncate the name hash to
+// 128 bits, so two names could map to the same file; the full hash recorded in
+// the file detects that instead of failing (or, worse, succeeding) obscurely.
+var ErrNameCollision = errors.New("fallback file was written for a different secret name")
+
+// ErrNotYetValid is returned when reading a secret sealed with a not-before
+// time (the WithNotBefore store option) that has not passed yet.
+var ErrNotYetValid = errors.New("secret not yet valid")
+
 // fallbackSecretFile represents the structure of an encrypted secret file
-// Format: [version:1][nonce:12][expiry:8][ciphertext+tag:variable]
+// Format v2: [version:1][name_hash:32][nonce:12][expiry:8][ciphertext+tag:variable]
+// Version 1 files lack the name hash and are still readable.
 type fallbackSecretFile struct {
 	version    byte
+	nameHash   []byte // Full SHA-256 of the secret name
 	nonce      []byte // GCM nonce
 	expiry     int64  // Unix timestamp when secret expires
-	ciphertext []byte // Encrypted secret + GCM tag
+	notBefore  int64  // Unix timestamp before which the secret cannot be read (0 = none)
+	iterations uint32 // PBKDF2 iteration count (0 = format predates the field)
+	argon2     struct {
+		memory  uint32 // Argon2id memory cost in KiB
+		time    uint32 // Argon2id passes
+		threads uint8  // Argon2id lanes
+	}
+	remainingReads int64  // Successful reads left before the file burns (0 = unlimited)
+	ciphertext     []byte // Encrypted secret + GCM tag
+	header         []byte // Raw header bytes preceding the ciphertext
+}
+
+// kdfParams describes the key derivation function and cost parameters bound
+// to a fallback file (or configured on a client for new files).
+type kdfParams struct {
+	argon2id   bool   // false selects PBKDF2-SHA256
+	iterations int    // PBKDF2 iteration count
+	memory     uint32 // Argon2id memory cost in KiB
+	time       uint32 // Argon2id passes
+	threads    uint8  // Argon2id lanes
+}
+
+// kdfParams returns the derivation parameters the file's key was created
+// with: the recorded values where the format carries them, the historical
+// PBKDF2 default for files that predate configurable KDFs.
+func (f *fallbackSecretFile) kdfParams() kdfParams {
+	if fallbackVersionUsesArgon2(f.version) {
+		return kdfParams{argon2id: true, memory: f.argon2.memory, time: f.argon2.time, threads: f.argon2.threads}
+	}
+	if f.iterations > 0 {
+		return kdfParams{iterations: int(f.iterations)}
+	}
+	return kdfParams{iterations: legacyPBKDF2Iterations}
+}
+
+// aad returns the associated data the file's ciphertext was sealed with.
+// Version 2 files bind the whole header into the GCM tag so tampering with
+// any header byte fails authentication; version 1 files predate that.
+func (f *fallbackSecretFile) aad() []byte {
+	if f.version >= fallbackFileVersion {
+		return f.header
+	}
+	return nil
+}
+
+// usesDirectKey reports whether the client encrypts with a caller-supplied key
+// instead of deriving one.
+func (c *Client) usesDirectKey() bool { return len(c.options.FallbackKey) > 0 }
+
+// kdfIterations returns the PBKDF2 iteration count for newly derived keys:
+// the configured override when set, the library default otherwise. Reads use
+// the count recorded in the file being read, not this one.
+func (c *Client) kdfIterations() int {
+	if c.options.FallbackKDFIterations > 0 {
+		return c.options.FallbackKDFIterations
+	}
+	return defaultPBKDF2Iterations
+}
+
+// kdfParams returns the derivation parameters for newly written files, from
+// the client's configured KDF choice.
+func (c *Client) kdfParams() kdfParams {
+	if c.options.FallbackKDF == "argon2id" {
+		return kdfParams{
+			argon2id: true,
+			memory:   defaultArgon2Memory,
+			time:     defaultArgon2Time,
+			threads:  defaultArgon2Threads,
+		}
+	}
+	return kdfParams{iterations: c.kdfIterations()}
+}
+
+// validateFallbackKDF rejects an unrecognized FallbackKDF setting up front,
+// so a typo fails loudly instead of silently falling back to PBKDF2.
+func (c *Client) validateFallbackKDF() error {
+	switch c.options.FallbackKDF {
+	case "", "pbkdf2", "argon2id":
+		return nil
+	}
+	return fmt.Errorf("unknown fallback KDF %q (supported: pbkdf2, argon2id)", c.options.FallbackKDF)
 }
 
-// deriveKey generates an encryption key from client nonce, binary hash, and secret name
+// deriveKey generates an encryption key from client nonce, binary hash, and
+// secret name, with the client's configured KDF and cost parameters. When the
+// caller supplied a high-entropy key directly via the FallbackKey option, that
+// key is used as-is and key derivation is skipped entirely.
 func (c *Client) deriveKey(secretName string) ([]byte, error) {
+	if err := c.validateFallbackKDF(); err != nil {
+		return nil, err
+	}
+	return c.deriveKeyParams(secretName, c.kdfParams())
+}
+
+// deriveKeyParams is deriveKey with explicit derivation parameters, for
+// decrypting files whose header records a KDF or cost setting other than the
+// configured one.
+func (c *Client) deriveKeyParams(secretName string, params kdfParams) ([]byte, error) {
+	if c.usesDirectKey() {
+		if len(c.options.FallbackKey) != aesKeySize {
+			return nil, fmt.Errorf("fallback key must be %d bytes, got %d", aesKeySize, len(c.options.FallbackKey))
+		}
+		return c.options.FallbackKey, nil
+	}
+
 	// Get binary hash
 	binaryHash, err := pb.GetCurrentBinaryHash()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get binary hash: %w", err)
 	}
 
-	// Create input for key derivation: nonce + binary hash + secret name
-	input := []byte(c.options.Nonce + binaryHash + secretName)
+	return c.deriveKeyForHash(binaryHash, secretName, params), nil
+}
+
+// deriveKeyForHash generates an encryption key for a secret using an explicit
+// binary hash and derivation parameters. Normal operation derives from the
+// live binary hash via deriveKey; migration (MigrateFallback) uses this to
+// re-derive keys for files written by a previous binary.
+func (c *Client) deriveKeyForHash(binaryHash, secretName string, params kdfParams) []byte {
+	// Create input for key derivation: nonce + binary hash + namespace +
+	// secret name. Built with appends rather than string concatenation so a
+	// byte nonce (the NonceBytes option) never passes through an unwipeable
+	// string. The namespace is folded in with a zero separator (absent
+	// entirely when unset, keeping legacy keys identical) so namespaced
+	// clients derive disjoint keys even for identical secret names.
+	nonce := c.nonceBytes()
+	ns := c.options.Namespace
+	input := make([]byte, 0, len(nonce)+len(binaryHash)+len(ns)+1+len(secretName))
+	input = append(input, nonce...)
+	input = append(input, binaryHash...)
+	if ns != "" {
+		input = append(input, ns...)
+		input = append(input, 0)
+	}
+	input = append(input, secretName...)
 
 	// Salt is hash of secret name for deterministic but unique per-secret salt
 	saltInput := []byte(secretName)
 	salt := sha256.Sum256(saltInput)
 
-	// Derive key using PBKDF2
-	key := pbkdf2.Key(input, salt[:], pbkdf2Iterations, aesKeySize, sha256.New)
-
-	return key, nil
+	// Derive the key with the selected KDF
+	if params.argon2id {
+		return argon2.IDKey(input, salt[:], params.time, params.memory, params.threads, aesKeySize)
+	}
+	return pbkdf2.Key(input, salt[:], params.iterations, aesKeySize, sha256.New)
 }
 
 // getFallbackFilePath generates a deterministic file path for a secret
@@ -66,17 +269,36 @@ func (c *Client) getFallbackFilePath(secretName string) (string, error) {
 		return "", fmt.Errorf("failed to get binary hash: %w", err)
 	}
 
-	// Hash the secret name for the filename
-	secretHash := sha256.Sum256([]byte(secretName))
+	return c.fallbackFilePathForHash(binaryHash, secretName), nil
+}
+
+// fallbackFilePathForHash generates the deterministic file path a secret would
+// use under an explicit binary hash. Like deriveKeyForHash, this exists so
+// migration can locate files written by a previous binary.
+func (c *Client) fallbackFilePathForHash(binaryHash, secretName string) string {
+	// Hash the secret name for the filename, prefixed by the namespace (with
+	// a zero separator) when one is set, so namespaced clients get disjoint
+	// paths for the same name while the un-namespaced layout stays untouched
+	nameInput := []byte(secretName)
+	if ns := c.options.Namespace; ns != "" {
+		nameInput = append(append([]byte(ns), 0), secretName...)
+	}
+	secretHash := sha256.Sum256(nameInput)
 
 	// Create filename: burnafter-{binary_hash[:16]}-{secret_hash[:16]}
 	filename := fmt.Sprintf("burnafter-%s-%x", binaryHash[:16], secretHash[:16])
 
-	// Use system temp directory
-	tmpDir := os.TempDir()
-	filePath := filepath.Join(tmpDir, filename)
+	return filepath.Join(c.fallbackDir(), filename)
+}
 
-	return filePath, nil
+// fallbackDir returns the directory fallback secret files live in: the
+// configured SocketDir when set, otherwise the system temp directory, so the
+// files share whatever private home the socket was given.
+func (c *Client) fallbackDir() string {
+	if c.options.SocketDir != "" {
+		return c.options.SocketDir
+	}
+	return os.TempDir()
 }
 
 // newGCM builds an AES-256-GCM AEAD from a derived key.
@@ -92,6 +314,12 @@ func newGCM(key []byte) (cipher.AEAD, error) {
 	return gcm, nil
 }
 
+// randReader returns the client's source of randomness for GCM nonces:
+// crypto/rand.Reader unless deterministic deps were injected (see WithDeps).
+func (c *Client) randReader() io.Reader {
+	return c.deps.Reader()
+}
+
 // seal encrypts secret for secretName, returning a fresh GCM nonce and the
 // ciphertext (with authentication tag). Shared by the file and in-memory stores.
 func (c *Client) seal(secretName string, secret []byte) (nonce, ciphertext []byte, err error) {
@@ -99,39 +327,75 @@ func (c *Client) seal(secretName string, secret []byte) (nonce, ciphertext []byt
 	if err != nil {
 		return nil, nil, err
 	}
+	return c.sealWithKey(key, secret)
+}
+
+// sealWithKey encrypts secret with an already-derived key, returning a fresh
+// GCM nonce and the ciphertext (with authentication tag).
+func (c *Client) sealWithKey(key, secret []byte) (nonce, ciphertext []byte, err error) {
 	gcm, err := newGCM(key)
 	if err != nil {
 		return nil, nil, err
 	}
 	nonce = make([]byte, gcmNonceSize)
-	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+	if _, err := io.ReadFull(c.randReader(), nonce); err != nil {
 		return nil, nil, fmt.Errorf("failed to generate nonce: %w", err)
 	}
 	return nonce, gcm.Seal(nil, nonce, secret, nil), nil
 }
 
-// open decrypts ciphertext for secretName using nonce. Shared by the file and
+// open decrypts ciphertext for secretName using nonce, authenticating aad
+// when the ciphertext was sealed with associated data. Shared by the file and
 // in-memory stores.
-func (c *Client) open(secretName string, nonce, ciphertext []byte) ([]byte, error) {
-	key, err := c.deriveKey(secretName)
+func (c *Client) open(secretName string, nonce, ciphertext, aad []byte) ([]byte, error) {
+	return c.openParams(secretName, c.kdfParams(), nonce, ciphertext, aad)
+}
+
+// openParams is open with explicit derivation parameters, used by the file
+// read path where the KDF and its costs come from the file header rather than
+// the client configuration.
+func (c *Client) openParams(secretName string, params kdfParams, nonce, ciphertext, aad []byte) ([]byte, error) {
+	key, err := c.deriveKeyParams(secretName, params)
 	if err != nil {
 		return nil, err
 	}
+	return c.openWithKey(key, nonce, ciphertext, aad)
+}
+
+// openWithKey decrypts ciphertext with an already-derived key.
+func (c *Client) openWithKey(key, nonce, ciphertext, aad []byte) ([]byte, error) {
 	gcm, err := newGCM(key)
 	if err != nil {
 		return nil, err
 	}
-	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, aad)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decrypt: %w", err)
 	}
 	return plaintext, nil
 }
 
-// encryptSecret encrypts a secret and writes it to a file
-func (c *Client) encryptSecret(secretName string, secret []byte, expiryTime time.Time) error {
-	// Encrypt the secret
-	nonce, ciphertext, err := c.seal(secretName, secret)
+// encryptSecret encrypts a secret and writes it to a file. The context is
+// checked before the expensive key derivation and again before touching the
+// filesystem, so a cancelled store returns promptly without writing anything.
+func (c *Client) encryptSecret(ctx context.Context, secretName string, secret []byte, expiryTime time.Time) error {
+	return c.encryptSecretNotBefore(ctx, secretName, secret, expiryTime, 0, 0)
+}
+
+// encryptSecretNotBefore is encryptSecret with an optional not-before seal
+// (0 = readable immediately) and an optional read budget (0 = unlimited
+// reads), both persisted in the file header.
+func (c *Client) encryptSecretNotBefore(ctx context.Context, secretName string, secret []byte, expiryTime time.Time, notBefore, maxReads int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Derive the key and seal the secret bound to the file header
+	key, err := c.deriveKey(secretName)
+	if err != nil {
+		return err
+	}
+	contents, err := c.sealFallbackFileNotBefore(key, secretName, secret, expiryTime, notBefore, maxReads)
 	if err != nil {
 		return err
 	}
@@ -142,25 +406,113 @@ func (c *Client) encryptSecret(secretName string, secret []byte, expiryTime time
 		return err
 	}
 
-	// Create file structure
-	file := fallbackSecretFile{
-		version:    fallbackFileVersion,
-		nonce:      nonce,
-		expiry:     expiryTime.Unix(),
-		ciphertext: ciphertext,
+	if err := ctx.Err(); err != nil {
+		return err
 	}
 
-	// Serialize to bytes
-	buf := make([]byte, 1+gcmNonceSize+8+len(ciphertext))
-	buf[0] = file.version
-	copy(buf[1:], file.nonce)
+	return c.writeFallbackFile(filePath, contents)
+}
+
+// encodeFallbackHeader serializes the file header: version, full name
+// hash, GCM nonce, expiry timestamp and, depending on the format version, the
+// sealed-until timestamp, the KDF cost parameters and the remaining-reads
+// counter.
+func encodeFallbackHeader(version byte, nameHash, nonce []byte, expiry, notBefore int64, params kdfParams, remainingReads int64) []byte {
+	header := make([]byte, fallbackHeaderSize(version))
+	header[0] = version
+	copy(header[1:], nameHash)
+	copy(header[1+nameHashSize:], nonce)
+	offset := 1 + nameHashSize + gcmNonceSize
+	binary.BigEndian.PutUint64(header[offset:], uint64(expiry)) //nolint:gosec // negative expiry rejected by callers
+	offset += 8
+	if fallbackVersionHasNotBefore(version) {
+		binary.BigEndian.PutUint64(header[offset:], uint64(notBefore)) //nolint:gosec // negative values rejected by callers
+		offset += 8
+	}
+	switch {
+	case fallbackVersionHasIterations(version):
+		binary.BigEndian.PutUint32(header[offset:], uint32(params.iterations)) //nolint:gosec // count bounded well below uint32 range
+		offset += 4
+	case fallbackVersionUsesArgon2(version):
+		binary.BigEndian.PutUint32(header[offset:], params.memory)
+		binary.BigEndian.PutUint32(header[offset+4:], params.time)
+		header[offset+8] = params.threads
+		offset += 9
+	}
+	if fallbackVersionHasMaxReads(version) {
+		binary.BigEndian.PutUint64(header[offset:], uint64(remainingReads)) //nolint:gosec // non-positive budgets rejected by callers
+	}
+	return header
+}
+
+// sealFallbackFile encrypts secret with an already-derived key and returns the
+// serialized file contents. The whole header is bound into the GCM tag as
+// associated data, so flipping any header byte — the version, the name hash or
+// the expiry — fails authentication instead of silently altering how the file
+// is interpreted. The full name hash in the header detects path collisions
+// that the truncated hash in the filename cannot.
+func (c *Client) sealFallbackFile(key []byte, secretName string, secret []byte, expiryTime time.Time) ([]byte, error) {
+	return c.sealFallbackFileNotBefore(key, secretName, secret, expiryTime, 0, 0)
+}
+
+// sealFallbackFileNotBefore is sealFallbackFile with an optional not-before
+// seal and an optional read budget. A non-zero notBefore selects the extended
+// format versions that record the sealed-until timestamp in the
+// (authenticated) header; a non-zero maxReads selects the read-budget
+// versions, which carry both fields.
+func (c *Client) sealFallbackFileNotBefore(key []byte, secretName string, secret []byte, expiryTime time.Time, notBefore, maxReads int64) ([]byte, error) {
 	// Ensure expiry is non-negative before conversion
-	if file.expiry < 0 {
-		return fmt.Errorf("invalid expiry time: %d", file.expiry)
+	expiry := expiryTime.Unix()
+	if expiry < 0 {
+		return nil, fmt.Errorf("invalid expiry time: %d", expiry)
+	}
+	if notBefore < 0 {
+		return nil, fmt.Errorf("invalid not-before time: %d", notBefore)
+	}
+	if maxReads < 0 {
+		return nil, fmt.Errorf("invalid read budget: %d", maxReads)
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcmNonceSize)
+	if _, err := io.ReadFull(c.randReader(), nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
 	}
-	binary.BigEndian.PutUint64(buf[1+gcmNonceSize:], uint64(file.expiry))
-	copy(buf[1+gcmNonceSize+8:], file.ciphertext)
 
+	// Derived-key files record their KDF and cost parameters; direct-key
+	// files have no KDF and keep the parameter-free layouts.
+	params := c.kdfParams()
+	version := byte(kdfFallbackFileVersion)
+	switch {
+	case c.usesDirectKey() && maxReads > 0:
+		version = maxReadsDirectKeyFallbackFileVersion
+	case c.usesDirectKey() && notBefore > 0:
+		version = directKeyNotBeforeFallbackFileVersion
+	case c.usesDirectKey():
+		version = directKeyFallbackFileVersion
+	case params.argon2id && maxReads > 0:
+		version = maxReadsArgon2idFallbackFileVersion
+	case params.argon2id && notBefore > 0:
+		version = argon2idNotBeforeFallbackFileVersion
+	case params.argon2id:
+		version = argon2idFallbackFileVersion
+	case maxReads > 0:
+		version = maxReadsKDFFallbackFileVersion
+	case notBefore > 0:
+		version = kdfNotBeforeFallbackFileVersion
+	}
+	nameHash := sha256.Sum256([]byte(secretName))
+	header := encodeFallbackHeader(version, nameHash[:], nonce, expiry, notBefore, params, maxReads)
+
+	return append(header, gcm.Seal(nil, nonce, secret, header)...), nil
+}
+
+// writeFallbackFile atomically writes serialized secret file contents to
+// filePath with restrictive permissions.
+func (c *Client) writeFallbackFile(filePath string, buf []byte) error {
 	// Write to temp file then rename (atomic)
 	tmpFile, err := os.CreateTemp(filepath.Dir(filePath), ".burnafter-tmp-*")
 	if err != nil {
@@ -174,15 +526,22 @@ func (c *Client) encryptSecret(secretName string, secret []byte, expiryTime time
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 
-	if err := tmpFile.Close(); err != nil {
+	// Make file read/write for owner only, on the descriptor rather than the
+	// path, and verify the result before the rename puts it in place
+	if err := tmpFile.Chmod(0o600); err != nil {
+		tmpFile.Close()    //nolint:errcheck,gosec
 		os.Remove(tmpPath) //nolint:errcheck,gosec
-		return fmt.Errorf("failed to close temp file: %w", err)
+		return fmt.Errorf("failed to set permissions: %w", err)
+	}
+	if err := verifySecureFallbackFile(tmpFile); err != nil {
+		tmpFile.Close()    //nolint:errcheck,gosec
+		os.Remove(tmpPath) //nolint:errcheck,gosec
+		return err
 	}
 
-	// Make file read/write for owner only
-	if err := os.Chmod(tmpPath, 0o600); err != nil {
+	if err := tmpFile.Close(); err != nil {
 		os.Remove(tmpPath) //nolint:errcheck,gosec
-		return fmt.Errorf("failed to set permissions: %w", err)
+		return fmt.Errorf("failed to close temp file: %w", err)
 	}
 
 	// Atomic rename
@@ -194,50 +553,276 @@ func (c *Client) encryptSecret(secretName string, secret []byte, expiryTime time
 	return nil
 }
 
-// decryptSecret reads and decrypts a secret from a file
-func (c *Client) decryptSecret(secretName string) ([]byte, error) {
+// decryptSecret reads and decrypts a secret from a file, honoring context
+// cancellation before the file read and before the key derivation.
+func (c *Client) decryptSecret(ctx context.Context, secretName string) ([]byte, error) {
+	secret, _, err := c.decryptSecretWithExpiry(ctx, secretName)
+	return secret, err
+}
+
+// decryptSecretWithExpiry is decryptSecret, additionally reporting the
+// secret's expiry timestamp for callers interested in lifecycle metadata.
+func (c *Client) decryptSecretWithExpiry(ctx context.Context, secretName string) ([]byte, int64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
 	// Get file path
 	filePath, err := c.getFallbackFilePath(secretName)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+
+	file, err := c.readFallbackFile(filePath)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Check if expired
+	if c.now().Unix() > file.expiry {
+		// Delete expired file
+		os.Remove(filePath) //nolint:errcheck,gosec
+		return nil, 0, ErrSecretExpired
+	}
+
+	// A sealed secret refuses reads until its not-before time passes
+	if file.notBefore > 0 && c.now().Unix() < file.notBefore {
+		return nil, 0, fmt.Errorf("reading secret %q: %w until %s", secretName,
+			ErrNotYetValid, time.Unix(file.notBefore, 0).Format(time.RFC3339))
+	}
+
+	// Verify the file was written for this name. Version 1 files carry no name
+	// hash; for those, decryption below still fails on a collision because the
+	// name is a key-derivation input, just with a generic error.
+	if file.nameHash != nil {
+		wantHash := sha256.Sum256([]byte(secretName))
+		if !bytes.Equal(file.nameHash, wantHash[:]) {
+			// Do not delete the file: it holds another name's live secret.
+			return nil, 0, fmt.Errorf("reading secret %q: %w", secretName, ErrNameCollision)
+		}
+	}
+
+	// Refuse to mix key modes: a direct-key client cannot read PBKDF2-derived
+	// files and vice versa. Without this check the mismatch would only show up
+	// as a generic GCM authentication failure.
+	if fileDirect := fallbackVersionUsesDirectKey(file.version); fileDirect != c.usesDirectKey() {
+		if fileDirect {
+			return nil, 0, fmt.Errorf("reading secret %q: file was encrypted with a caller-supplied key", secretName)
+		}
+		return nil, 0, fmt.Errorf("reading secret %q: file was encrypted with a derived key, not the supplied one", secretName)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	// Decrypt, authenticating the header for formats that bind it. The key is
+	// derived with the KDF and costs the file was written under, which may
+	// differ from this client's configuration.
+	secret, err := c.openParams(secretName, file.kdfParams(), file.nonce, file.ciphertext, file.aad())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Charge the successful read against the file's read budget, burning the
+	// file when this was the last allowed read
+	if file.remainingReads > 0 {
+		if err := c.consumeFallbackRead(secretName, filePath, file, secret); err != nil {
+			return nil, 0, err
+		}
+	}
+	return secret, file.expiry, nil
+}
+
+// consumeFallbackRead decrements a budgeted file's remaining-reads counter
+// after a successful decrypt. The last allowed read deletes the file; earlier
+// reads rewrite it atomically with the decremented counter. The counter lives
+// in the authenticated header, so the rewrite re-seals the plaintext under a
+// fresh nonce rather than patching bytes in place.
+func (c *Client) consumeFallbackRead(secretName, filePath string, file *fallbackSecretFile, secret []byte) error {
+	if file.remainingReads <= 1 {
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("burning read-exhausted secret: %w", err)
+		}
+		return nil
+	}
+
+	// Re-seal under the parameters recorded in the file, which may differ
+	// from this client's configuration
+	key, err := c.deriveKeyParams(secretName, file.kdfParams())
+	if err != nil {
+		return err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
 	}
+	nonce := make([]byte, gcmNonceSize)
+	if _, err := io.ReadFull(c.randReader(), nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	header := encodeFallbackHeader(file.version, file.nameHash, nonce, file.expiry,
+		file.notBefore, file.kdfParams(), file.remainingReads-1)
+
+	return c.writeFallbackFile(filePath, append(header, gcm.Seal(nil, nonce, secret, header)...))
+}
+
+// fallbackHeaderSize returns the number of bytes preceding the ciphertext for
+// a given file format version, or 0 for an unsupported version.
+func fallbackHeaderSize(version byte) int {
+	switch version {
+	case fallbackFileVersion, directKeyFallbackFileVersion:
+		return 1 + nameHashSize + gcmNonceSize + 8
+	case notBeforeFallbackFileVersion, directKeyNotBeforeFallbackFileVersion:
+		return 1 + nameHashSize + gcmNonceSize + 8 + 8
+	case kdfFallbackFileVersion:
+		return 1 + nameHashSize + gcmNonceSize + 8 + 4
+	case kdfNotBeforeFallbackFileVersion:
+		return 1 + nameHashSize + gcmNonceSize + 8 + 8 + 4
+	case argon2idFallbackFileVersion:
+		return 1 + nameHashSize + gcmNonceSize + 8 + 9
+	case argon2idNotBeforeFallbackFileVersion:
+		return 1 + nameHashSize + gcmNonceSize + 8 + 8 + 9
+	case maxReadsKDFFallbackFileVersion:
+		return 1 + nameHashSize + gcmNonceSize + 8 + 8 + 4 + 8
+	case maxReadsArgon2idFallbackFileVersion:
+		return 1 + nameHashSize + gcmNonceSize + 8 + 8 + 9 + 8
+	case maxReadsDirectKeyFallbackFileVersion:
+		return 1 + nameHashSize + gcmNonceSize + 8 + 8 + 8
+	case legacyFallbackFileVersion:
+		return 1 + gcmNonceSize + 8
+	default:
+		return 0
+	}
+}
+
+// fallbackVersionUsesDirectKey reports whether a file format version marks a
+// ciphertext encrypted with a caller-supplied key instead of a derived one.
+func fallbackVersionUsesDirectKey(version byte) bool {
+	return version == directKeyFallbackFileVersion ||
+		version == directKeyNotBeforeFallbackFileVersion ||
+		version == maxReadsDirectKeyFallbackFileVersion
+}
+
+// fallbackVersionHasNotBefore reports whether a file format version carries a
+// sealed-until timestamp in its header.
+func fallbackVersionHasNotBefore(version byte) bool {
+	return version == notBeforeFallbackFileVersion ||
+		version == directKeyNotBeforeFallbackFileVersion ||
+		version == kdfNotBeforeFallbackFileVersion ||
+		version == argon2idNotBeforeFallbackFileVersion ||
+		fallbackVersionHasMaxReads(version)
+}
+
+// fallbackVersionHasIterations reports whether a file format version records
+// its PBKDF2 iteration count in the header.
+func fallbackVersionHasIterations(version byte) bool {
+	return version == kdfFallbackFileVersion ||
+		version == kdfNotBeforeFallbackFileVersion ||
+		version == maxReadsKDFFallbackFileVersion
+}
 
-	// Read file
-	data, err := os.ReadFile(filePath)
+// fallbackVersionUsesArgon2 reports whether a file format version marks a key
+// derived with Argon2id, with the cost parameters in the header.
+func fallbackVersionUsesArgon2(version byte) bool {
+	return version == argon2idFallbackFileVersion ||
+		version == argon2idNotBeforeFallbackFileVersion ||
+		version == maxReadsArgon2idFallbackFileVersion
+}
+
+// fallbackVersionHasMaxReads reports whether a file format version carries a
+// remaining-reads counter in its header.
+func fallbackVersionHasMaxReads(version byte) bool {
+	return version == maxReadsKDFFallbackFileVersion ||
+		version == maxReadsArgon2idFallbackFileVersion ||
+		version == maxReadsDirectKeyFallbackFileVersion
+}
+
+// readFallbackFile reads an encrypted secret file and parses its structure.
+// Version 1 files are parsed with a nil nameHash.
+func (c *Client) readFallbackFile(filePath string) (*fallbackSecretFile, error) {
+	// Open with the on-disk security checks applied and read through the
+	// verified descriptor
+	f, err := openFallbackFileSecurely(filePath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("secret not found")
+			return nil, ErrSecretNotFound
 		}
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
+	defer f.Close() //nolint:errcheck
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
 
 	// Parse file structure
-	if len(data) < 1+gcmNonceSize+8 {
+	if len(data) < 1 {
 		return nil, fmt.Errorf("invalid file format: too small")
 	}
 
-	version := data[0]
-	if version != fallbackFileVersion {
-		return nil, fmt.Errorf("unsupported file version: %d", version)
+	file := &fallbackSecretFile{version: data[0]}
+	headerSize := fallbackHeaderSize(file.version)
+	if headerSize == 0 {
+		return nil, fmt.Errorf("unsupported file version: %d", file.version)
+	}
+	if len(data) < headerSize {
+		return nil, fmt.Errorf("invalid file format: too small")
 	}
 
-	nonce := data[1 : 1+gcmNonceSize]
-	expiryUint := binary.BigEndian.Uint64(data[1+gcmNonceSize : 1+gcmNonceSize+8])
+	offset := 1
+	if file.version >= fallbackFileVersion {
+		file.nameHash = data[offset : offset+nameHashSize]
+		offset += nameHashSize
+	}
+	file.nonce = data[offset : offset+gcmNonceSize]
+	offset += gcmNonceSize
+	expiryUint := binary.BigEndian.Uint64(data[offset : offset+8])
 	if expiryUint > math.MaxInt64 {
 		return nil, fmt.Errorf("invalid expiry time in file")
 	}
-	expiry := int64(expiryUint)
-	ciphertext := data[1+gcmNonceSize+8:]
-
-	// Check if expired
-	if time.Now().Unix() > expiry {
-		// Delete expired file
-		os.Remove(filePath) //nolint:errcheck,gosec
-		return nil, fmt.Errorf("secret expired")
+	file.expiry = int64(expiryUint)
+	offset += 8
+	if fallbackVersionHasNotBefore(file.version) {
+		notBeforeUint := binary.BigEndian.Uint64(data[offset : offset+8])
+		if notBeforeUint > math.MaxInt64 {
+			return nil, fmt.Errorf("invalid not-before time in file")
+		}
+		file.notBefore = int64(notBeforeUint)
+		offset += 8
 	}
+	switch {
+	case fallbackVersionHasIterations(file.version):
+		file.iterations = binary.BigEndian.Uint32(data[offset : offset+4])
+		if file.iterations == 0 || file.iterations > maxPBKDF2Iterations {
+			return nil, fmt.Errorf("invalid iteration count in file: %d", file.iterations)
+		}
+	case fallbackVersionUsesArgon2(file.version):
+		file.argon2.memory = binary.BigEndian.Uint32(data[offset : offset+4])
+		file.argon2.time = binary.BigEndian.Uint32(data[offset+4 : offset+8])
+		file.argon2.threads = data[offset+8]
+		if file.argon2.memory == 0 || file.argon2.memory > maxArgon2Memory ||
+			file.argon2.time == 0 || file.argon2.time > maxArgon2Time ||
+			file.argon2.threads == 0 {
+			return nil, fmt.Errorf("invalid Argon2id parameters in file: m=%d t=%d p=%d",
+				file.argon2.memory, file.argon2.time, file.argon2.threads)
+		}
+	}
+	if fallbackVersionHasMaxReads(file.version) {
+		offset = headerSize - 8
+		readsUint := binary.BigEndian.Uint64(data[offset : offset+8])
+		// A budgeted file always has at least one read left: the last
+		// allowed read deletes it rather than writing a zero
+		if readsUint == 0 || readsUint > math.MaxInt64 {
+			return nil, fmt.Errorf("invalid remaining reads in file: %d", readsUint)
+		}
+		file.remainingReads = int64(readsUint)
+	}
+	file.ciphertext = data[headerSize:]
+	file.header = data[:headerSize]
 
-	// Decrypt
-	return c.open(secretName, nonce, ciphertext)
+	return file, nil
 }
 
 // deleteFallbackSecret removes a secret file
@@ -249,7 +834,7 @@ func (c *Client) deleteFallbackSecret(secretName string) error {
 
 	if err := os.Remove(filePath); err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("secret not found")
+			return ErrSecretNotFound
 		}
 		return fmt.Errorf("failed to delete file: %w", err)
 	}
@@ -257,8 +842,13 @@ func (c *Client) deleteFallbackSecret(secretName string) error {
 	return nil
 }
 
-// cleanupExpiredFallbackFiles removes expired secret files
-func (c *Client) cleanupExpiredFallbackFiles() error {
+// cleanupExpiredFallbackFiles removes expired secret files, stopping early if
+// the context is cancelled.
+func (c *Client) cleanupExpiredFallbackFiles(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	// Get binary hash for filtering our files
 	binaryHash, err := pb.GetCurrentBinaryHash()
 	if err != nil {
@@ -266,17 +856,21 @@ func (c *Client) cleanupExpiredFallbackFiles() error {
 	}
 
 	prefix := fmt.Sprintf("burnafter-%s-", binaryHash[:16])
-	tmpDir := os.TempDir()
+	tmpDir := c.fallbackDir()
 
-	// List files in temp directory
+	// List files in the fallback directory
 	entries, err := os.ReadDir(tmpDir)
 	if err != nil {
-		return fmt.Errorf("failed to read temp directory: %w", err)
+		return fmt.Errorf("failed to read fallback directory: %w", err)
 	}
 
-	now := time.Now().Unix()
+	now := c.now().Unix()
 
 	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		// Skip if not our file
 		if entry.IsDir() || len(entry.Name()) < len(prefix) || entry.Name()[:len(prefix)] != prefix {
 			continue
@@ -284,18 +878,44 @@ func (c *Client) cleanupExpiredFallbackFiles() error {
 
 		filePath := filepath.Join(tmpDir, entry.Name())
 
-		// Read and check expiry
-		data, err := os.ReadFile(filePath)
+		// Age backstop: reap files older than MaxFallbackFileAge based on
+		// their on-disk mtime, regardless of what the embedded expiry claims
+		if c.options.MaxFallbackFileAge > 0 {
+			if info, err := entry.Info(); err == nil &&
+				c.now().Sub(info.ModTime()) > c.options.MaxFallbackFileAge {
+				os.Remove(filePath) //nolint:errcheck,gosec
+				continue
+			}
+		}
+
+		// Read and check expiry, through the same security checks as a real
+		// read: a file we would refuse to decrypt is not ours to reap either
+		file, err := openFallbackFileSecurely(filePath)
+		if err != nil {
+			continue // Skip files we can't open safely
+		}
+		data, err := io.ReadAll(file)
+		file.Close() //nolint:errcheck,gosec
 		if err != nil {
 			continue // Skip files we can't read
 		}
 
-		// Parse expiry time
-		if len(data) < 1+gcmNonceSize+8 {
+		// Parse expiry time at the offset for the file's format version
+		if len(data) < 1 {
+			continue
+		}
+		headerSize := fallbackHeaderSize(data[0])
+		if headerSize == 0 || len(data) < headerSize {
 			continue
 		}
 
-		expiryUint := binary.BigEndian.Uint64(data[1+gcmNonceSize : 1+gcmNonceSize+8])
+		// The expiry sits right after the nonce in every format; only the
+		// version-1 layout lacks the preceding name hash
+		expiryOff := 1 + nameHashSize + gcmNonceSize
+		if data[0] == legacyFallbackFileVersion {
+			expiryOff = 1 + gcmNonceSize
+		}
+		expiryUint := binary.BigEndian.Uint64(data[expiryOff : expiryOff+8])
 		if expiryUint > math.MaxInt64 {
 			continue // Skip invalid files
 		}
@@ -4,9 +4,13 @@
 package burnafter
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -237,6 +241,41 @@ func TestFallbackDeterministicPath(t *testing.T) {
 	}
 }
 
+func TestFallbackFilePathForHash(t *testing.T) {
+	opts := options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = "test-nonce-path-for-hash"
+
+	client := NewClient(opts)
+
+	fixedHash := "feedfacefeedfacefeedfacefeedfacefeedfacefeedfacefeedfacefeedface"
+	secretName := "fixed-hash-path"
+
+	// A fixed hash must produce a deterministic path that embeds the hash
+	// prefix and does not depend on the live binary.
+	path1 := client.fallbackFilePathForHash(fixedHash, secretName)
+	path2 := client.fallbackFilePathForHash(fixedHash, secretName)
+
+	if path1 != path2 {
+		t.Errorf("Expected deterministic path, got %s and %s", path1, path2)
+	}
+
+	filename := filepath.Base(path1)
+	expectedPrefix := "burnafter-" + fixedHash[:16] + "-"
+	if len(filename) < len(expectedPrefix) || filename[:len(expectedPrefix)] != expectedPrefix {
+		t.Errorf("Expected filename prefixed with %s, got %s", expectedPrefix, filename)
+	}
+
+	// getFallbackFilePath is a thin wrapper supplying the live binary hash
+	wrapped, err := client.getFallbackFilePath(secretName)
+	if err != nil {
+		t.Fatalf("getFallbackFilePath failed: %v", err)
+	}
+	if wrapped == path1 {
+		t.Errorf("Expected live-hash path to differ from fixed-hash path")
+	}
+}
+
 func TestFallbackDifferentNonceSamePath(t *testing.T) {
 	// File paths should be the same for same secret name, regardless of nonce
 	// Nonce affects encryption key, not file path
@@ -409,9 +448,14 @@ func TestFallbackDeleteNonExistent(t *testing.T) {
 		t.Fatalf("Connect failed: %v", err)
 	}
 
-	err := client.Delete(ctx, "does-not-exist")
-	if err == nil {
-		t.Errorf("Expected error when deleting non-existent secret")
+	// Delete is idempotent: a missing secret is a success
+	if err := client.Delete(ctx, "does-not-exist"); err != nil {
+		t.Errorf("Expected idempotent delete of a non-existent secret, got: %v", err)
+	}
+
+	// The strict variant reports the miss
+	if err := client.DeleteStrict(ctx, "does-not-exist"); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("Expected ErrSecretNotFound, got: %v", err)
 	}
 }
 
@@ -491,6 +535,228 @@ func TestFallbackFilePermissions(t *testing.T) {
 	}
 }
 
+func TestFallbackCancelledStore(t *testing.T) {
+	opts := options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = "test-nonce-cancelled"
+
+	client := NewClient(opts)
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	secretName := "cancelled-store"
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// A cancelled context must abort the store before anything hits disk
+	if err := client.Store(ctx, secretName, "value", options.WithTTL(300)); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+
+	filePath, err := client.getFallbackFilePath(secretName)
+	if err != nil {
+		t.Fatalf("getFallbackFilePath failed: %v", err)
+	}
+	if _, err := os.Stat(filePath); !os.IsNotExist(err) {
+		t.Errorf("Expected no fallback file after cancelled store")
+		os.Remove(filePath) //nolint:errcheck,gosec
+	}
+
+	// The same name stores fine with a live context
+	if err := client.Store(context.Background(), secretName, "value", options.WithTTL(300)); err != nil {
+		t.Errorf("Store with live context failed: %v", err)
+	}
+	defer os.Remove(filePath) //nolint:errcheck
+}
+
+func TestFallbackCancelledGet(t *testing.T) {
+	opts := options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = "test-nonce-cancelled-get"
+
+	client := NewClient(opts)
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	secretName := "cancelled-get"
+	if err := client.Store(context.Background(), secretName, "value", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	filePath, _ := client.getFallbackFilePath(secretName) //nolint:errcheck
+	defer os.Remove(filePath)                             //nolint:errcheck
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := client.Get(ctx, secretName); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestFallbackNameCollision(t *testing.T) {
+	opts := options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = "test-nonce-collision"
+
+	client := NewClient(opts)
+	ctx := context.Background()
+
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	// Store a secret, then copy its file onto the path another name would use,
+	// simulating two names whose truncated filename hashes collide.
+	victimName := "collision-victim"
+	if err := client.Store(ctx, victimName, "victim-value", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	victimPath, err := client.getFallbackFilePath(victimName)
+	if err != nil {
+		t.Fatalf("getFallbackFilePath failed: %v", err)
+	}
+	defer os.Remove(victimPath) //nolint:errcheck
+
+	otherName := "collision-other"
+	otherPath, err := client.getFallbackFilePath(otherName)
+	if err != nil {
+		t.Fatalf("getFallbackFilePath failed: %v", err)
+	}
+	data, err := os.ReadFile(victimPath)
+	if err != nil {
+		t.Fatalf("reading victim file: %v", err)
+	}
+	if err := os.WriteFile(otherPath, data, 0o600); err != nil {
+		t.Fatalf("writing collided file: %v", err)
+	}
+	defer os.Remove(otherPath) //nolint:errcheck
+
+	// Reading under the colliding name must fail with the dedicated error
+	if _, err := client.Get(ctx, otherName); !errors.Is(err, ErrNameCollision) {
+		t.Errorf("Expected ErrNameCollision, got %v", err)
+	}
+
+	// The collided file must not be deleted: it is another name's live secret
+	if _, err := os.Stat(otherPath); err != nil {
+		t.Errorf("Expected collided file to survive detection: %v", err)
+	}
+
+	// The original name still reads fine
+	if got, err := client.Get(ctx, victimName); err != nil || got != "victim-value" {
+		t.Errorf("Expected victim secret to be unaffected, got %q, %v", got, err)
+	}
+}
+
+func TestFallbackHeaderTampering(t *testing.T) {
+	opts := options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = "test-nonce-tamper"
+
+	client := NewClient(opts)
+	ctx := context.Background()
+
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	secretName := "tamper-test"
+	if err := client.Store(ctx, secretName, "value", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	filePath, err := client.getFallbackFilePath(secretName)
+	if err != nil {
+		t.Fatalf("getFallbackFilePath failed: %v", err)
+	}
+	defer os.Remove(filePath) //nolint:errcheck
+
+	original, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("reading fallback file: %v", err)
+	}
+	headerSize := fallbackHeaderSize(kdfFallbackFileVersion)
+
+	// Flipping any header byte must make retrieval fail: the version byte is
+	// rejected by the parser, a name hash byte trips the collision check, and
+	// everything else fails GCM authentication since the whole header is bound
+	// as associated data.
+	for i := range headerSize {
+		tampered := make([]byte, len(original))
+		copy(tampered, original)
+		tampered[i] ^= 0xff
+
+		if err := os.WriteFile(filePath, tampered, 0o600); err != nil {
+			t.Fatalf("writing tampered file: %v", err)
+		}
+		if _, err := client.Get(ctx, secretName); err == nil {
+			t.Errorf("Expected failure with header byte %d flipped", i)
+		}
+	}
+
+	// The untampered file still reads fine
+	if err := os.WriteFile(filePath, original, 0o600); err != nil {
+		t.Fatalf("restoring file: %v", err)
+	}
+	if got, err := client.Get(ctx, secretName); err != nil || got != "value" {
+		t.Errorf("Expected original file to read back, got %q, %v", got, err)
+	}
+}
+
+func TestFallbackLegacyV1File(t *testing.T) {
+	opts := options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = "test-nonce-legacy-v1"
+
+	client := NewClient(opts)
+	ctx := context.Background()
+
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	secretName := "legacy-v1-secret"
+	secretValue := "legacy-value"
+
+	// Hand-craft a version 1 file: [version:1][nonce:12][expiry:8][ciphertext].
+	// The format predates the header's iteration field, so its key is derived
+	// at the historical count regardless of the client's configuration.
+	key, err := client.deriveKeyParams(secretName, kdfParams{iterations: legacyPBKDF2Iterations})
+	if err != nil {
+		t.Fatalf("deriveKeyParams failed: %v", err)
+	}
+	nonce, ciphertext, err := client.sealWithKey(key, []byte(secretValue))
+	if err != nil {
+		t.Fatalf("sealWithKey failed: %v", err)
+	}
+	buf := make([]byte, 1+gcmNonceSize+8+len(ciphertext))
+	buf[0] = legacyFallbackFileVersion
+	copy(buf[1:], nonce)
+	binary.BigEndian.PutUint64(buf[1+gcmNonceSize:], uint64(time.Now().Add(time.Hour).Unix())) //nolint:gosec
+
+	copy(buf[1+gcmNonceSize+8:], ciphertext)
+
+	filePath, err := client.getFallbackFilePath(secretName)
+	if err != nil {
+		t.Fatalf("getFallbackFilePath failed: %v", err)
+	}
+	if err := os.WriteFile(filePath, buf, 0o600); err != nil {
+		t.Fatalf("writing legacy file: %v", err)
+	}
+	defer os.Remove(filePath) //nolint:errcheck
+
+	// Version 1 files must remain readable
+	retrieved, err := client.Get(ctx, secretName)
+	if err != nil {
+		t.Fatalf("Get of legacy v1 file failed: %v", err)
+	}
+	if retrieved != secretValue {
+		t.Errorf("Expected %q, got %q", secretValue, retrieved)
+	}
+}
+
 func TestFallbackFilePathFormat(t *testing.T) {
 	opts := options.DefaultClient
 	opts.NoServer = true
@@ -523,3 +789,440 @@ func TestFallbackFilePathFormat(t *testing.T) {
 		t.Errorf("Expected no file extension, got %s", ext)
 	}
 }
+
+func TestFallbackMaxFileAge(t *testing.T) {
+	opts := *options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = "test-nonce-file-age"
+	opts.MaxFallbackFileAge = 1 * time.Hour
+
+	client := NewClient(&opts)
+	ctx := context.Background()
+
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	// Store a secret whose embedded expiry is comfortably in the future
+	name := "file-age-backstop"
+	if err := client.Store(ctx, name, "still-valid-on-paper", options.WithTTL(3600)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	filePath, err := client.getFallbackFilePath(name)
+	if err != nil {
+		t.Fatalf("getFallbackFilePath failed: %v", err)
+	}
+	defer os.Remove(filePath) //nolint:errcheck
+
+	// Backdate the file beyond the configured maximum age
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(filePath, old, old); err != nil {
+		t.Fatalf("Chtimes failed: %v", err)
+	}
+
+	if err := client.cleanupExpiredFallbackFiles(ctx); err != nil {
+		t.Fatalf("cleanupExpiredFallbackFiles failed: %v", err)
+	}
+
+	if _, err := os.Stat(filePath); !os.IsNotExist(err) {
+		t.Errorf("Expected the backdated file to be reaped despite its future expiry")
+	}
+
+	// With the backstop disabled the same file must survive
+	if err := client.Store(ctx, name, "still-valid-on-paper", options.WithTTL(3600)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if err := os.Chtimes(filePath, old, old); err != nil {
+		t.Fatalf("Chtimes failed: %v", err)
+	}
+	opts.MaxFallbackFileAge = 0
+	if err := client.cleanupExpiredFallbackFiles(ctx); err != nil {
+		t.Fatalf("cleanupExpiredFallbackFiles failed: %v", err)
+	}
+	if _, err := os.Stat(filePath); err != nil {
+		t.Errorf("Expected the file to survive with the backstop disabled: %v", err)
+	}
+}
+
+func TestFallbackDirectKey(t *testing.T) {
+	ctx := context.Background()
+
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	opts := *options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = "test-nonce-direct-key"
+	opts.FallbackKey = append([]byte{}, key...)
+
+	client := NewClient(&opts)
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	name := "direct-key-secret"
+	if err := client.Store(ctx, name, "kms-managed-value", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	filePath, err := client.getFallbackFilePath(name)
+	if err != nil {
+		t.Fatalf("getFallbackFilePath failed: %v", err)
+	}
+	defer os.Remove(filePath) //nolint:errcheck
+
+	// The file must record the direct-key format version
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("reading fallback file: %v", err)
+	}
+	if data[0] != directKeyFallbackFileVersion {
+		t.Errorf("Expected version %d, got %d", directKeyFallbackFileVersion, data[0])
+	}
+
+	// Round trip with a second client holding the same key
+	opts2 := *options.DefaultClient
+	opts2.NoServer = true
+	opts2.Nonce = "a-different-nonce-is-irrelevant"
+	opts2.FallbackKey = append([]byte{}, key...)
+	client2 := NewClient(&opts2)
+	if err := client2.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	got, err := client2.Get(ctx, name)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got != "kms-managed-value" {
+		t.Errorf("Expected kms-managed-value, got %q", got)
+	}
+
+	// Close must wipe the supplied key
+	if err := client2.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	for _, b := range opts2.FallbackKey {
+		if b != 0 {
+			t.Errorf("Expected the fallback key to be wiped on Close")
+			break
+		}
+	}
+}
+
+func TestFallbackDirectKeyBadSize(t *testing.T) {
+	opts := *options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = "test-nonce-short-key"
+	opts.FallbackKey = []byte("too-short")
+
+	client := NewClient(&opts)
+	ctx := context.Background()
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	err := client.Store(ctx, "short-key-secret", "value", options.WithTTL(300))
+	if err == nil || !strings.Contains(err.Error(), "32 bytes") {
+		t.Errorf("Expected a key size error, got %v", err)
+	}
+}
+
+func TestFallbackDirectKeyModeMismatch(t *testing.T) {
+	ctx := context.Background()
+
+	// Store with the normal derived-key client
+	derivedOpts := *options.DefaultClient
+	derivedOpts.NoServer = true
+	derivedOpts.Nonce = "test-nonce-mode-mismatch"
+	derived := NewClient(&derivedOpts)
+	if err := derived.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	name := "mode-mismatch-secret"
+	if err := derived.Store(ctx, name, "derived-value", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	defer derived.Delete(ctx, name) //nolint:errcheck
+
+	// A direct-key client must refuse to read the derived-key file
+	directOpts := *options.DefaultClient
+	directOpts.NoServer = true
+	directOpts.Nonce = "test-nonce-mode-mismatch"
+	directOpts.FallbackKey = make([]byte, 32)
+	direct := NewClient(&directOpts)
+	if err := direct.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	if _, err := direct.Get(ctx, name); err == nil || !strings.Contains(err.Error(), "derived key") {
+		t.Errorf("Expected a key-mode mismatch error, got %v", err)
+	}
+
+	// And the other way around: a derived-key client can't read direct files
+	name2 := "mode-mismatch-direct"
+	if err := direct.Store(ctx, name2, "direct-value", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	defer direct.Delete(ctx, name2) //nolint:errcheck
+	if _, err := derived.Get(ctx, name2); err == nil || !strings.Contains(err.Error(), "caller-supplied key") {
+		t.Errorf("Expected a key-mode mismatch error, got %v", err)
+	}
+}
+
+// fixedReader yields a deterministic byte sequence, standing in for
+// crypto/rand in exact-format tests.
+type fixedReader struct{ next byte }
+
+func (f *fixedReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = f.next
+		f.next++
+	}
+	return len(p), nil
+}
+
+func TestSealFallbackFileGolden(t *testing.T) {
+	// A caller-supplied key keeps the bytes independent of the test binary's
+	// hash, and the injected reader pins the GCM nonce, so the sealed file is
+	// fully deterministic.
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	opts := *options.DefaultClient
+	opts.NoServer = true
+	opts.FallbackKey = append([]byte{}, key...)
+
+	client := NewClient(&opts, WithDeps(Deps{Rand: &fixedReader{}}))
+
+	contents, err := client.sealFallbackFile(key, "golden-secret", []byte("golden-value"), time.Unix(1700000000, 0))
+	if err != nil {
+		t.Fatalf("sealFallbackFile failed: %v", err)
+	}
+
+	expected, err := os.ReadFile(filepath.Join("testdata", "fallback_v3.golden"))
+	if err != nil {
+		t.Fatalf("Reading golden file: %v", err)
+	}
+	if !bytes.Equal(contents, expected) {
+		t.Errorf("Sealed fallback file does not match the golden bytes:\n got %x\nwant %x", contents, expected)
+	}
+
+	// The deterministic bytes must still decrypt back to the secret
+	nonce := contents[1+nameHashSize : 1+nameHashSize+gcmNonceSize]
+	plaintext, err := client.openWithKey(key, nonce, contents[1+nameHashSize+gcmNonceSize+8:], contents[:1+nameHashSize+gcmNonceSize+8])
+	if err != nil {
+		t.Fatalf("openWithKey failed: %v", err)
+	}
+	if string(plaintext) != "golden-value" {
+		t.Errorf("Expected golden-value, got %q", plaintext)
+	}
+}
+
+func TestFallbackNotBefore(t *testing.T) {
+	ctx := context.Background()
+	client := newFallbackTestClient(t, "test-nonce-not-before")
+
+	// Before the window: a future not-before seals the secret
+	if err := client.Store(ctx, "sealed-early", "v", options.WithTTL(7200),
+		options.WithNotBefore(time.Now().Add(time.Hour))); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	defer client.Delete(ctx, "sealed-early") //nolint:errcheck
+	if _, err := client.Get(ctx, "sealed-early"); !errors.Is(err, ErrNotYetValid) {
+		t.Errorf("Expected ErrNotYetValid before the window, got: %v", err)
+	}
+
+	// Inside the window: a passed not-before reads normally
+	if err := client.Store(ctx, "sealed-open", "open-value", options.WithTTL(300),
+		options.WithNotBefore(time.Now().Add(-time.Minute))); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	defer client.Delete(ctx, "sealed-open") //nolint:errcheck
+	value, err := client.Get(ctx, "sealed-open")
+	if err != nil {
+		t.Fatalf("Get inside the validity window failed: %v", err)
+	}
+	if value != "open-value" {
+		t.Errorf("Expected open-value, got %q", value)
+	}
+
+	// After the window: expiry still wins
+	if err := client.Store(ctx, "sealed-late", "v", options.WithTTL(1),
+		options.WithNotBefore(time.Now().Add(-time.Minute))); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	defer client.Delete(ctx, "sealed-late") //nolint:errcheck
+	time.Sleep(2 * time.Second)
+	if _, err := client.Get(ctx, "sealed-late"); err == nil {
+		t.Errorf("Expected an error after the secret expired")
+	}
+}
+
+func TestFallbackNotBeforeConflictsWithExpiry(t *testing.T) {
+	ctx := context.Background()
+	client := newFallbackTestClient(t, "test-nonce-not-before-conflict")
+
+	// A seal at or past the expiry leaves no readable window
+	err := client.Store(ctx, "never-readable", "v", options.WithTTL(60),
+		options.WithNotBefore(time.Now().Add(time.Hour)))
+	if !errors.Is(err, ErrConflictingExpiry) {
+		t.Errorf("Expected ErrConflictingExpiry, got: %v", err)
+	}
+}
+
+func TestFallbackMaxReads(t *testing.T) {
+	ctx := context.Background()
+	client := newFallbackTestClient(t, "test-nonce-max-reads")
+
+	secretName := "budgeted-secret"
+	if err := client.Store(ctx, secretName, "budgeted-value", options.WithTTL(3600),
+		options.WithMaxReads(2)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	defer client.Delete(ctx, secretName) //nolint:errcheck
+
+	filePath, err := client.getFallbackFilePath(secretName)
+	if err != nil {
+		t.Fatalf("getFallbackFilePath failed: %v", err)
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("Reading fallback file: %v", err)
+	}
+	if data[0] != maxReadsKDFFallbackFileVersion {
+		t.Errorf("Expected version %d, got %d", maxReadsKDFFallbackFileVersion, data[0])
+	}
+
+	// The first read succeeds and rewrites the file with one read left
+	value, err := client.Get(ctx, secretName)
+	if err != nil {
+		t.Fatalf("First Get failed: %v", err)
+	}
+	if value != "budgeted-value" {
+		t.Errorf("Expected budgeted-value, got %q", value)
+	}
+	file, err := client.readFallbackFile(filePath)
+	if err != nil {
+		t.Fatalf("Reading rewritten fallback file: %v", err)
+	}
+	if file.remainingReads != 1 {
+		t.Errorf("Expected 1 remaining read after the first Get, got %d", file.remainingReads)
+	}
+
+	// The second read spends the budget and burns the file
+	value, err = client.Get(ctx, secretName)
+	if err != nil {
+		t.Fatalf("Second Get failed: %v", err)
+	}
+	if value != "budgeted-value" {
+		t.Errorf("Expected budgeted-value, got %q", value)
+	}
+	if _, err := os.Stat(filePath); !os.IsNotExist(err) {
+		t.Errorf("Expected the exhausted file to be removed, stat returned: %v", err)
+	}
+	if _, err := client.Get(ctx, secretName); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("Expected ErrSecretNotFound after the budget is spent, got: %v", err)
+	}
+}
+
+func TestNonceBytesEquivalentToStringNonce(t *testing.T) {
+	ctx := context.Background()
+	nonce := "test-nonce-bytes-equivalence"
+
+	// A string-nonce client stores the secret
+	strOpts := *options.DefaultClient
+	strOpts.NoServer = true
+	strOpts.Nonce = nonce
+
+	strClient := NewClient(&strOpts)
+	if err := strClient.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	if err := strClient.Store(ctx, "nonce-bytes-secret", "shared-value", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	defer strClient.Delete(ctx, "nonce-bytes-secret") //nolint:errcheck
+
+	// A byte-nonce client with the same bytes derives the same key and reads
+	// the value back. The string nonce deliberately conflicts to prove the
+	// byte nonce takes precedence.
+	byteOpts := *options.DefaultClient
+	byteOpts.NoServer = true
+	byteOpts.Nonce = "a-different-string-nonce"
+	byteOpts.NonceBytes = []byte(nonce)
+
+	byteClient := NewClient(&byteOpts)
+	if err := byteClient.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	value, err := byteClient.Get(ctx, "nonce-bytes-secret")
+	if err != nil {
+		t.Fatalf("Get with an equivalent byte nonce failed: %v", err)
+	}
+	if value != "shared-value" {
+		t.Errorf("Expected shared-value, got %q", value)
+	}
+}
+
+func TestNonceBytesWipedOnClose(t *testing.T) {
+	opts := *options.DefaultClient
+	opts.NoServer = true
+	opts.NonceBytes = []byte("test-nonce-bytes-wipe")
+	held := opts.NonceBytes
+
+	client := NewClient(&opts)
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if opts.NonceBytes != nil {
+		t.Errorf("Expected Close to drop the byte nonce reference")
+	}
+	for i, b := range held {
+		if b != 0 {
+			t.Fatalf("Expected the byte nonce to be zero-wiped, byte %d is %#x", i, b)
+		}
+	}
+}
+
+func TestGetRejectsSymlinkedFallbackFile(t *testing.T) {
+	opts := options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = "test-nonce-symlink-reject"
+
+	client := NewClient(opts)
+	ctx := context.Background()
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	if err := client.Store(ctx, "symlinked", "secret-value", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	filePath, err := client.getFallbackFilePath("symlinked")
+	if err != nil {
+		t.Fatalf("getFallbackFilePath: %v", err)
+	}
+	defer os.Remove(filePath) //nolint:errcheck
+
+	// Swap the secret file for a symlink pointing at a copy of itself: the
+	// content is intact, but the read must refuse to follow the link
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	target := filepath.Join(t.TempDir(), "target")
+	if err := os.WriteFile(target, data, 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := os.Remove(filePath); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if err := os.Symlink(target, filePath); err != nil {
+		t.Fatalf("Symlink: %v", err)
+	}
+
+	if _, err := client.Get(ctx, "symlinked"); err == nil {
+		t.Errorf("Expected the symlinked fallback file to be rejected")
+	}
+}
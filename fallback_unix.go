@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package burnafter
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// fallbackCurrentUID reports the UID fallback files must be owned by. It is a
+// variable so tests can simulate a foreign-owned file, which cannot be
+// created without privileges.
+var fallbackCurrentUID = os.Getuid
+
+// verifySecureFallbackFile checks a fallback file's security properties on
+// its open descriptor — fstat, not a path-based stat that could race a swap:
+// it must be a regular file owned by the current user with mode exactly 0600.
+func verifySecureFallbackFile(f *os.File) error {
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat fallback file: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("fallback file is not a regular file")
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		return fmt.Errorf("fallback file has unsafe permissions %04o (want 0600)", perm)
+	}
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok && int(stat.Uid) != fallbackCurrentUID() {
+		return fmt.Errorf("fallback file is owned by uid %d, not the current user", stat.Uid)
+	}
+	return nil
+}
+
+// openFallbackFileSecurely opens an existing fallback secret file for
+// reading, refusing symlinks (O_NOFOLLOW) and anything that fails the
+// descriptor-level checks of verifySecureFallbackFile. The caller owns the
+// returned file.
+func openFallbackFileSecurely(filePath string) (*os.File, error) {
+	f, err := os.OpenFile(filePath, os.O_RDONLY|syscall.O_NOFOLLOW, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySecureFallbackFile(f); err != nil {
+		f.Close() //nolint:errcheck,gosec
+		return nil, err
+	}
+	return f, nil
+}
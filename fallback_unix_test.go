@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package burnafter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// The secure-open checks this test exercises (mode bits, symlink refusal and
+// the fallbackCurrentUID ownership seam) are Unix file semantics; the Windows
+// build guards fallback files through ACLs instead.
+func TestOpenFallbackFileSecurely(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "secret-file")
+	if err := os.WriteFile(path, []byte("payload"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	// A regular 0600 file owned by us opens fine
+	f, err := openFallbackFileSecurely(path)
+	if err != nil {
+		t.Fatalf("Expected the well-formed file to open, got %v", err)
+	}
+	f.Close() //nolint:errcheck,gosec
+
+	// Loose permissions are refused
+	if err := os.Chmod(path, 0o644); err != nil {
+		t.Fatalf("Chmod: %v", err)
+	}
+	if _, err := openFallbackFileSecurely(path); err == nil {
+		t.Errorf("Expected a 0644 file to be rejected")
+	} else if !strings.Contains(err.Error(), "unsafe permissions") {
+		t.Errorf("Expected a permissions error, got %v", err)
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		t.Fatalf("Chmod: %v", err)
+	}
+
+	// Symlinks are refused at open time (O_NOFOLLOW)
+	link := filepath.Join(dir, "secret-link")
+	if err := os.Symlink(path, link); err != nil {
+		t.Fatalf("Symlink: %v", err)
+	}
+	if _, err := openFallbackFileSecurely(link); err == nil {
+		t.Errorf("Expected a symlink to be rejected")
+	}
+
+	// A file owned by someone else is refused. Creating one needs privileges,
+	// so shift the expected UID through the test seam instead.
+	origUID := fallbackCurrentUID
+	fallbackCurrentUID = func() int { return origUID() + 1 }
+	t.Cleanup(func() { fallbackCurrentUID = origUID })
+	if _, err := openFallbackFileSecurely(path); err == nil {
+		t.Errorf("Expected a foreign-owned file to be rejected")
+	} else if !strings.Contains(err.Error(), "owned by uid") {
+		t.Errorf("Expected an ownership error, got %v", err)
+	}
+}
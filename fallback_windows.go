@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+
+package burnafter
+
+import (
+	"fmt"
+	"os"
+)
+
+// verifySecureFallbackFile checks a fallback file's properties on its open
+// descriptor. Windows files carry ACLs rather than a Unix mode and owner, so
+// the checks here are limited to it being a regular file; confidentiality
+// rests on the file living under the user's profile and on the encryption.
+func verifySecureFallbackFile(f *os.File) error {
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat fallback file: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("fallback file is not a regular file")
+	}
+	return nil
+}
+
+// openFallbackFileSecurely opens an existing fallback secret file for
+// reading. There is no O_NOFOLLOW on Windows; os.OpenFile follows a symlink,
+// so reject one with an Lstat before opening (symlinks also require elevated
+// rights to create here, making the window academic).
+func openFallbackFileSecurely(filePath string) (*os.File, error) {
+	if info, err := os.Lstat(filePath); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		return nil, fmt.Errorf("fallback file %s is a symlink", filePath)
+	}
+	f, err := os.OpenFile(filePath, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySecureFallbackFile(f); err != nil {
+		f.Close() //nolint:errcheck,gosec
+		return nil, err
+	}
+	return f, nil
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	pb "github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// StoreFields stores a structured multi-field secret — e.g. a username and
+// password pair, or a set of related tokens — as a single encrypted blob, so
+// callers don't have to invent their own serialization. The fields are JSON
+// encoded (Go sorts map keys, so the same fields always produce the same
+// plaintext) and the intermediate buffer is zero-wiped once encrypted. All the
+// usual store options (TTL, labels, not-before) apply to the bundle as a whole.
+func (c *Client) StoreFields(ctx context.Context, name string, fields map[string]string, funcs ...options.StoreOptsFn) error {
+	if len(fields) == 0 {
+		return fmt.Errorf("no fields to store")
+	}
+
+	buf, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("encoding fields: %w", err)
+	}
+	defer pb.ZeroBytes(buf)
+
+	_, err = c.storeBytes(ctx, name, buf, funcs...)
+	return err
+}
+
+// GetFields retrieves a secret stored with StoreFields and decodes it back
+// into its fields. The serialized buffer is zero-wiped after decoding; the
+// decoded values themselves are Go strings and so — like any secret returned
+// through the string API — cannot be wiped in place.
+func (c *Client) GetFields(ctx context.Context, name string) (map[string]string, error) {
+	buf, err := c.getBytes(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer pb.ZeroBytes(buf)
+
+	fields := map[string]string{}
+	if err := json.Unmarshal(buf, &fields); err != nil {
+		return nil, fmt.Errorf("decoding fields (was %q stored with StoreFields?): %w", name, err)
+	}
+	return fields, nil
+}
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func TestFieldsRoundTripInMemory(t *testing.T) {
+	c := newInMemoryClient()
+
+	fields := map[string]string{
+		"username": "svc-account",
+		"password": "hunter2",
+		"empty":    "",
+		"unicode":  "pāşŝŵōrd-秘密-🔑",
+	}
+	if err := c.StoreFields(t.Context(), "bundle", fields); err != nil {
+		t.Fatalf("StoreFields failed: %v", err)
+	}
+
+	got, err := c.GetFields(t.Context(), "bundle")
+	if err != nil {
+		t.Fatalf("GetFields failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, fields) {
+		t.Errorf("Round-trip mismatch: got %v, want %v", got, fields)
+	}
+}
+
+func TestFieldsRoundTripFallback(t *testing.T) {
+	opts := *options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = testNonce
+	c := NewClient(&opts)
+
+	fields := map[string]string{"token": "tok-1", "refresh": "tok-2"}
+	if err := c.StoreFields(t.Context(), "bundle-file", fields); err != nil {
+		t.Fatalf("StoreFields failed: %v", err)
+	}
+	t.Cleanup(func() {
+		if path, err := c.getFallbackFilePath("bundle-file"); err == nil {
+			os.Remove(path) //nolint:errcheck,gosec
+		}
+	})
+
+	got, err := c.GetFields(t.Context(), "bundle-file")
+	if err != nil {
+		t.Fatalf("GetFields failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, fields) {
+		t.Errorf("Round-trip mismatch: got %v, want %v", got, fields)
+	}
+}
+
+func TestStoreFieldsRejectsEmpty(t *testing.T) {
+	c := newInMemoryClient()
+	if err := c.StoreFields(t.Context(), "nothing", nil); err == nil {
+		t.Errorf("Expected an error storing an empty field set")
+	}
+}
+
+func TestGetFieldsRejectsPlainSecret(t *testing.T) {
+	c := newInMemoryClient()
+	if err := c.Store(t.Context(), "plain", "not json"); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if _, err := c.GetFields(t.Context(), "plain"); err == nil {
+		t.Errorf("Expected an error decoding a secret not stored with StoreFields")
+	}
+}
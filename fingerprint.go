@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	pb "github.com/carabiner-dev/burnafter/internal/common"
+)
+
+// DerivationFingerprint returns a non-secret fingerprint of the inputs this
+// client would feed into key derivation for the named secret: the client's
+// key identity (its binary hash, or the shared-key digest when one is
+// configured, or the caller-supplied key's digest in direct-key fallback
+// mode), the client nonce and the secret name. Two clients whose fingerprints
+// match would derive compatible keys; a mismatch pinpoints which side of a
+// "client B cannot read client A's secret" problem differs, without either
+// side revealing key material. The server session ID and the per-secret salt
+// are deliberately excluded — they are the same for both sides of any
+// cross-client comparison worth making.
+func (c *Client) DerivationFingerprint(secretName string) (string, error) {
+	if secretName == "" {
+		return "", fmt.Errorf("secret name is required")
+	}
+
+	h := sha256.New()
+	h.Write([]byte("burnafter-derivation-fingerprint-v1\x00"))
+
+	switch {
+	case len(c.options.FallbackKey) > 0:
+		// Direct-key fallback skips derivation entirely: compatibility is
+		// purely whether both sides hold the same key. The digest of a digest
+		// keeps the key itself out of the fingerprint preimage.
+		keyDigest := sha256.Sum256(c.options.FallbackKey)
+		h.Write([]byte("direct-key\x00"))
+		h.Write(keyDigest[:])
+	case c.options.SharedKey != "":
+		// Shared-key mode replaces the binary hash with the key's digest,
+		// exactly as the server's key derivation does
+		sum := sha256.Sum256([]byte(c.options.SharedKey))
+		h.Write([]byte("shared-key\x00"))
+		h.Write([]byte(hex.EncodeToString(sum[:])))
+	default:
+		hash, err := pb.GetCurrentBinaryHash()
+		if err != nil {
+			return "", fmt.Errorf("hashing client binary: %w", err)
+		}
+		h.Write([]byte("binary-hash\x00"))
+		h.Write([]byte(hash))
+	}
+
+	h.Write([]byte{0})
+	h.Write(c.nonceBytes())
+	h.Write([]byte{0})
+	h.Write([]byte(secretName))
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"testing"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func fingerprintClient(nonce, sharedKey string, fallbackKey []byte) *Client {
+	opts := *options.DefaultClient
+	opts.Nonce = nonce
+	opts.SharedKey = sharedKey
+	opts.FallbackKey = fallbackKey
+	return NewClient(&opts)
+}
+
+func TestDerivationFingerprintMatching(t *testing.T) {
+	a := fingerprintClient("shared-nonce", "", nil)
+	b := fingerprintClient("shared-nonce", "", nil)
+
+	fpA, err := a.DerivationFingerprint("api-token")
+	if err != nil {
+		t.Fatalf("DerivationFingerprint failed: %v", err)
+	}
+	fpB, err := b.DerivationFingerprint("api-token")
+	if err != nil {
+		t.Fatalf("DerivationFingerprint failed: %v", err)
+	}
+
+	// Same binary (the test binary), same nonce, same name: compatible keys
+	if fpA != fpB {
+		t.Errorf("Expected matching fingerprints, got %s vs %s", fpA, fpB)
+	}
+}
+
+func TestDerivationFingerprintMismatches(t *testing.T) {
+	base := fingerprintClient("nonce-a", "", nil)
+	fpBase, err := base.DerivationFingerprint("api-token")
+	if err != nil {
+		t.Fatalf("DerivationFingerprint failed: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		client *Client
+		secret string
+	}{
+		{"different nonce", fingerprintClient("nonce-b", "", nil), "api-token"},
+		{"different secret name", base, "other-token"},
+		{"shared-key identity", fingerprintClient("nonce-a", "suite-key", nil), "api-token"},
+		{"direct-key identity", fingerprintClient("nonce-a", "", make([]byte, 32)), "api-token"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fp, err := tc.client.DerivationFingerprint(tc.secret)
+			if err != nil {
+				t.Fatalf("DerivationFingerprint failed: %v", err)
+			}
+			if fp == fpBase {
+				t.Errorf("Expected a different fingerprint than the base client's")
+			}
+		})
+	}
+}
+
+func TestDerivationFingerprintSharedAndDirectKeys(t *testing.T) {
+	// Shared-key clients fingerprint by the key digest, not the binary hash,
+	// so two of them match exactly when their keys (and nonces) do
+	sharedA, err := fingerprintClient("n", "suite-key", nil).DerivationFingerprint("s")
+	if err != nil {
+		t.Fatalf("DerivationFingerprint failed: %v", err)
+	}
+	sharedB, err := fingerprintClient("n", "suite-key", nil).DerivationFingerprint("s")
+	if err != nil {
+		t.Fatalf("DerivationFingerprint failed: %v", err)
+	}
+	if sharedA != sharedB {
+		t.Errorf("Expected matching shared-key fingerprints")
+	}
+
+	key := make([]byte, 32)
+	key[0] = 1
+	directA, err := fingerprintClient("n", "", key).DerivationFingerprint("s")
+	if err != nil {
+		t.Fatalf("DerivationFingerprint failed: %v", err)
+	}
+	directB, err := fingerprintClient("n", "", append([]byte{}, key...)).DerivationFingerprint("s")
+	if err != nil {
+		t.Fatalf("DerivationFingerprint failed: %v", err)
+	}
+	if directA != directB {
+		t.Errorf("Expected matching direct-key fingerprints")
+	}
+
+	if _, err := fingerprintClient("n", "", nil).DerivationFingerprint(""); err == nil {
+		t.Errorf("Expected an error for an empty secret name")
+	}
+}
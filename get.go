@@ -5,14 +5,79 @@ package burnafter
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	pb "github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/secrets"
 )
 
+// ErrSecretExpired is returned by the retrieval functions when the named
+// secret existed but its lifetime has run out. Together with ErrSecretNotFound
+// it marks the "the secret is simply gone" outcomes, as opposed to real
+// failures like a refused client verification or a transport error.
+var ErrSecretExpired = errors.New("secret has expired")
+
+// ErrBinaryMismatch is returned in server mode when the server refuses a
+// request because the calling binary's hash does not match the one recorded
+// when the secret was stored. The fallback mode cannot distinguish this case:
+// there the binary hash is a key-derivation input, so a mismatch surfaces as
+// a generic decryption failure.
+var ErrBinaryMismatch = errors.New("client binary hash mismatch")
+
+// serverError converts a failure reported by the server into the client's
+// error form, mapping the machine-readable error code onto the sentinel
+// errors so callers can test them with errors.Is. The server's message text
+// is preserved. Responses from servers predating the code field carry
+// ERROR_UNSPECIFIED and fall back to the message matching in serverGetError.
+func serverError(code pb.ErrorCode, msg string) error {
+	switch code {
+	case pb.ErrorCode_ERROR_NOT_FOUND:
+		return fmt.Errorf("server error: %w", ErrSecretNotFound)
+	case pb.ErrorCode_ERROR_EXPIRED:
+		return fmt.Errorf("server error: %w%s", ErrSecretExpired, strings.TrimPrefix(msg, "secret has expired"))
+	case pb.ErrorCode_ERROR_BINARY_MISMATCH:
+		return fmt.Errorf("server error: %w%s", ErrBinaryMismatch, strings.TrimPrefix(msg, "client binary hash mismatch"))
+	case pb.ErrorCode_ERROR_NOT_YET_VALID:
+		return fmt.Errorf("server error: %w%s", ErrNotYetValid, strings.TrimPrefix(msg, "secret not yet valid"))
+	}
+	return serverGetError(msg)
+}
+
+// serverGetError converts a failure reported without an error code into the
+// client's error form by matching the message text, covering the responses
+// (Stat, Rotate) that do not carry a code and servers built before the code
+// field existed. The server's message text is preserved.
+func serverGetError(msg string) error {
+	switch {
+	case msg == "secret not found":
+		return fmt.Errorf("server error: %w", ErrSecretNotFound)
+	case strings.HasPrefix(msg, "secret has expired"):
+		return fmt.Errorf("server error: %w%s", ErrSecretExpired, strings.TrimPrefix(msg, "secret has expired"))
+	}
+	return fmt.Errorf("server error: %s", msg)
+}
+
 // Get retrieves a secret from the server or fallback encrypted file storage
 func (c *Client) Get(ctx context.Context, name string) (string, error) {
+	// With the opt-in cache enabled, serve repeated reads from memory. Misses
+	// go through GetWithMetadata so the entry can be capped at the secret's
+	// own remaining lifetime.
+	if c.cache != nil {
+		if secret, ok := c.cache.get(name, c.now()); ok {
+			return secret, nil
+		}
+		secret, meta, err := c.GetWithMetadata(ctx, name)
+		if err != nil {
+			return "", err
+		}
+		c.cache.put(name, secret, c.now(), meta)
+		return secret, nil
+	}
+
 	// In-memory mode reads from the ephemeral backend.
 	if c.useMemory() {
 		secret, err := c.getFromMemory(ctx, name)
@@ -25,20 +90,20 @@ func (c *Client) Get(ctx context.Context, name string) (string, error) {
 	// Use fallback storage if server is not available
 	if c.useFallback() {
 		// Decrypt from file
-		secret, err := c.decryptSecret(name)
+		secret, err := c.decryptSecret(ctx, name)
 		if err != nil {
 			return "", err
 		}
 
 		// Cleanup expired files
-		_ = c.cleanupExpiredFallbackFiles() //nolint:errcheck
+		_ = c.cleanupExpiredFallbackFiles(ctx) //nolint:errcheck
 
 		return string(secret), nil
 	}
 
 	// Server mode
 	if c.client == nil {
-		return "", fmt.Errorf("not connected to server")
+		return "", ErrNotConnected
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
@@ -46,15 +111,269 @@ func (c *Client) Get(ctx context.Context, name string) (string, error) {
 
 	resp, err := c.client.Get(ctx, &pb.GetRequest{
 		Name:        name,
-		ClientNonce: c.options.Nonce,
+		ClientNonce: c.nonce(),
 	})
 	if err != nil {
 		return "", fmt.Errorf("getting secret: %w", err)
 	}
 
 	if !resp.Success {
-		return "", fmt.Errorf("server error: %s", resp.Error)
+		return "", serverError(resp.ErrorCode, resp.Error)
+	}
+
+	// The string conversion copies, so the unmarshalled buffer can be wiped
+	secret := string(resp.Secret)
+	pb.ZeroBytes(resp.Secret)
+	return secret, nil
+}
+
+// GetOr retrieves a secret like Get, but returns def instead of an error when
+// the secret does not exist or has already expired. Real failures — a refused
+// client verification, a corrupt file, a transport error — still surface, so
+// a missing optional secret reads differently from a broken setup.
+func (c *Client) GetOr(ctx context.Context, name, def string) (string, error) {
+	secret, err := c.Get(ctx, name)
+	if errors.Is(err, ErrSecretNotFound) || errors.Is(err, ErrSecretExpired) {
+		return def, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+// SecretMetadata describes a secret's lifecycle as known at retrieval time.
+type SecretMetadata struct {
+	// RemainingTTL is the inactivity window left after this read (server mode
+	// resets it on every access). The fallback and in-memory modes have a
+	// single expiry timestamp, so there it is the time left until that.
+	RemainingTTL time.Duration
+	// AbsoluteDeadline is the fixed time the secret dies regardless of
+	// access, when one was set.
+	AbsoluteDeadline *time.Time
+}
+
+// GetWithMetadata retrieves a secret like Get and also reports its lifecycle
+// metadata, so callers can tell how much longer the value remains usable.
+func (c *Client) GetWithMetadata(ctx context.Context, name string) (string, *SecretMetadata, error) {
+	// The fallback and in-memory modes record a single expiry timestamp
+	if c.useMemory() || c.useFallback() {
+		var secret []byte
+		var expiry int64
+		var err error
+		if c.useMemory() {
+			secret, expiry, err = c.getFromMemoryWithExpiry(ctx, name)
+		} else {
+			secret, expiry, err = c.decryptSecretWithExpiry(ctx, name)
+			if err == nil {
+				_ = c.cleanupExpiredFallbackFiles(ctx) //nolint:errcheck
+			}
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		deadline := time.Unix(expiry, 0)
+		return string(secret), &SecretMetadata{
+			RemainingTTL:     deadline.Sub(c.now()),
+			AbsoluteDeadline: &deadline,
+		}, nil
+	}
+
+	// Server mode
+	if c.client == nil {
+		return "", nil, ErrNotConnected
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.Get(ctx, &pb.GetRequest{
+		Name:        name,
+		ClientNonce: c.nonce(),
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("getting secret: %w", err)
+	}
+
+	if !resp.Success {
+		return "", nil, serverError(resp.ErrorCode, resp.Error)
+	}
+
+	meta := &SecretMetadata{
+		RemainingTTL: time.Duration(resp.TtlSeconds) * time.Second,
+	}
+	if resp.AbsoluteExpiresAt > 0 {
+		deadline := time.Unix(resp.AbsoluteExpiresAt, 0)
+		meta.AbsoluteDeadline = &deadline
+	}
+
+	return string(resp.Secret), meta, nil
+}
+
+// GetToFile retrieves a secret and writes it directly to a caller-provided
+// file, for tools that expect credentials on a specific fd. The plaintext
+// never becomes a Go string in this process: the intermediate buffer is
+// zero-wiped once written. The file is the caller's responsibility and is
+// ideally a pipe or memfd rather than something that persists on disk. In
+// server mode the secret still crosses the socket, so prefer the fallback or
+// in-memory modes when the transport copy matters.
+func (c *Client) GetToFile(ctx context.Context, name string, f *os.File) error {
+	if f == nil {
+		return fmt.Errorf("no file provided")
+	}
+
+	secret, err := c.getBytes(ctx, name)
+	if err != nil {
+		return err
+	}
+	defer pb.ZeroBytes(secret)
+
+	// Handle partial writes: keep writing until the whole secret is out
+	for written := 0; written < len(secret); {
+		n, err := f.Write(secret[written:])
+		if err != nil {
+			return fmt.Errorf("writing secret to file: %w", err)
+		}
+		written += n
+	}
+
+	return nil
+}
+
+// getBytes retrieves a secret as a byte slice the caller owns (and should
+// wipe). Every mode keeps the plaintext in wipeable buffers: the fallback and
+// in-memory modes decrypt straight into the slice, and server mode returns
+// the unmarshalled wire buffer itself rather than routing through Get, whose
+// string form cannot be wiped. For the same reason this path deliberately
+// skips the read cache, which holds strings.
+func (c *Client) getBytes(ctx context.Context, name string) ([]byte, error) {
+	if c.useMemory() {
+		return c.getFromMemory(ctx, name)
+	}
+
+	if c.useFallback() {
+		secret, err := c.decryptSecret(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		_ = c.cleanupExpiredFallbackFiles(ctx) //nolint:errcheck
+		return secret, nil
+	}
+
+	// Server mode
+	if c.client == nil {
+		return nil, ErrNotConnected
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.Get(ctx, &pb.GetRequest{
+		Name:        name,
+		ClientNonce: c.nonce(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting secret: %w", err)
+	}
+
+	if !resp.Success {
+		return nil, serverError(resp.ErrorCode, resp.Error)
 	}
 
 	return resp.Secret, nil
 }
+
+// GetBytes retrieves a secret as raw bytes, the counterpart of StoreBytes for
+// values that are not valid UTF-8. The returned slice is the caller's to wipe
+// once the secret has been used.
+func (c *Client) GetBytes(ctx context.Context, name string) ([]byte, error) {
+	return c.getBytes(ctx, name)
+}
+
+// GetInto retrieves a secret into dst, a buffer the caller owns, and returns
+// the number of bytes written. This gives the caller full control over the
+// plaintext's lifetime: dst can be wiped (or live in locked memory) on the
+// caller's schedule, and the intermediate copy the transport produced is
+// zero-wiped before returning. A dst too small for the secret is an error and
+// nothing is written.
+func (c *Client) GetInto(ctx context.Context, name string, dst []byte) (int, error) {
+	secret, err := c.getBytes(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+	defer pb.ZeroBytes(secret)
+
+	if len(dst) < len(secret) {
+		return 0, fmt.Errorf("destination buffer too small: secret is %d bytes, buffer holds %d", len(secret), len(dst))
+	}
+	return copy(dst, secret), nil
+}
+
+// GetEncrypted fetches a secret's raw encrypted payload without decrypting
+// it, for relaying to another burnafter instance. The server must have been
+// started with the AllowEncryptedExport option; only server mode supports
+// this, since the other modes hold nothing worth proxying.
+func (c *Client) GetEncrypted(ctx context.Context, name string) (*secrets.Payload, error) {
+	if c.useMemory() || c.useFallback() {
+		return nil, fmt.Errorf("encrypted export is only supported in server mode")
+	}
+
+	if c.client == nil {
+		return nil, ErrNotConnected
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.GetEncrypted(ctx, &pb.GetRequest{
+		Name:        name,
+		ClientNonce: c.nonce(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting encrypted secret: %w", err)
+	}
+
+	if !resp.Success {
+		return nil, fmt.Errorf("server error: %s", resp.Error)
+	}
+
+	return &secrets.Payload{
+		EncryptedData:    resp.EncryptedData,
+		Salt:             resp.Salt,
+		ClientBinaryHash: resp.ClientBinaryHash,
+		Chunked:          resp.Chunked,
+	}, nil
+}
+
+// GetByHandle redeems a single-use retrieval handle issued by StoreWithHandle.
+// The handle is invalidated by the redemption attempt, so a second call with
+// the same handle fails. The usual client binary verification still applies.
+func (c *Client) GetByHandle(ctx context.Context, handle string) (string, error) {
+	if c.useMemory() || c.useFallback() {
+		return "", fmt.Errorf("retrieval handles are only supported in server mode")
+	}
+
+	if c.client == nil {
+		return "", ErrNotConnected
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.GetByHandle(ctx, &pb.GetByHandleRequest{
+		Handle:      handle,
+		ClientNonce: c.nonce(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("getting secret by handle: %w", err)
+	}
+
+	if !resp.Success {
+		return "", serverError(resp.ErrorCode, resp.Error)
+	}
+
+	// The string conversion copies, so the unmarshalled buffer can be wiped
+	secret := string(resp.Secret)
+	pb.ZeroBytes(resp.Secret)
+	return secret, nil
+}
@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func TestGetToFilePipe(t *testing.T) {
+	opts := options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = "test-nonce-get-to-file"
+
+	client := NewClient(opts)
+	ctx := context.Background()
+
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	secretName := "get-to-file-test"
+	secretValue := "piped-secret-value"
+
+	if err := client.Store(ctx, secretName, secretValue, options.WithTTL(300)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	filePath, _ := client.getFallbackFilePath(secretName) //nolint:errcheck
+	defer os.Remove(filePath)                             //nolint:errcheck
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Pipe failed: %v", err)
+	}
+	defer r.Close() //nolint:errcheck
+
+	// Write the secret into the pipe and read it back out
+	done := make(chan error, 1)
+	go func() {
+		defer w.Close() //nolint:errcheck
+		done <- client.GetToFile(ctx, secretName, w)
+	}()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Reading from pipe failed: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("GetToFile failed: %v", err)
+	}
+
+	if string(data) != secretValue {
+		t.Errorf("Expected %q, got %q", secretValue, data)
+	}
+}
+
+func TestGetToFileInMemory(t *testing.T) {
+	opts := *options.DefaultClient
+	opts.InMemory = true
+	opts.Nonce = "test-nonce-get-to-file-mem"
+
+	client := NewClient(&opts)
+	ctx := context.Background()
+
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	secretName := "get-to-file-mem"
+	secretValue := "memory-piped-value"
+
+	if err := client.Store(ctx, secretName, secretValue, options.WithTTL(300)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Pipe failed: %v", err)
+	}
+	defer r.Close() //nolint:errcheck
+
+	done := make(chan error, 1)
+	go func() {
+		defer w.Close() //nolint:errcheck
+		done <- client.GetToFile(ctx, secretName, w)
+	}()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Reading from pipe failed: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("GetToFile failed: %v", err)
+	}
+
+	if string(data) != secretValue {
+		t.Errorf("Expected %q, got %q", secretValue, data)
+	}
+}
+
+func TestGetToFileNilFile(t *testing.T) {
+	opts := options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = "test-nonce-get-to-file-nil"
+
+	client := NewClient(opts)
+
+	if err := client.GetToFile(context.Background(), "anything", nil); err == nil {
+		t.Errorf("Expected error for nil file")
+	}
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func TestGetOrMissingReturnsDefault(t *testing.T) {
+	ctx := context.Background()
+	c := newInMemoryClient()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+
+	// Plain Get stays strict and reports the typed error
+	if _, err := c.Get(ctx, "absent"); !errors.Is(err, ErrSecretNotFound) {
+		t.Fatalf("Expected ErrSecretNotFound from Get, got %v", err)
+	}
+
+	got, err := c.GetOr(ctx, "absent", "fallback-value")
+	if err != nil {
+		t.Fatalf("GetOr of a missing secret failed: %v", err)
+	}
+	if got != "fallback-value" {
+		t.Errorf("Expected the default for a missing secret, got %q", got)
+	}
+}
+
+func TestGetOrExpiredReturnsDefault(t *testing.T) {
+	ctx := context.Background()
+	c := newInMemoryClient()
+
+	// Store rejects past deadlines up front, so plant the expired secret
+	// directly in the ephemeral backend.
+	past := time.Now().Add(-time.Minute)
+	if err := c.storeInMemory(ctx, "stale", []byte("v"), past); err != nil {
+		t.Fatalf("storeInMemory: %v", err)
+	}
+
+	if _, err := c.Get(ctx, "stale"); !errors.Is(err, ErrSecretExpired) {
+		t.Fatalf("Expected ErrSecretExpired from Get, got %v", err)
+	}
+
+	got, err := c.GetOr(ctx, "stale", "fresh-default")
+	if err != nil {
+		t.Fatalf("GetOr of an expired secret failed: %v", err)
+	}
+	if got != "fresh-default" {
+		t.Errorf("Expected the default for an expired secret, got %q", got)
+	}
+}
+
+func TestGetOrPresentReturnsSecret(t *testing.T) {
+	ctx := context.Background()
+	c := newInMemoryClient()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+
+	if err := c.Store(ctx, "present", "real-value", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	got, err := c.GetOr(ctx, "present", "unused-default")
+	if err != nil {
+		t.Fatalf("GetOr of a present secret failed: %v", err)
+	}
+	if got != "real-value" {
+		t.Errorf("Expected the stored value, got %q", got)
+	}
+}
+
+// TestGetOrUnauthorizedStillErrors proves GetOr only swallows the "secret is
+// gone" outcomes: a secret that exists but cannot be decrypted (here, a
+// client with the wrong nonce) must surface as an error, never as the
+// default.
+func TestGetOrUnauthorizedStillErrors(t *testing.T) {
+	ctx := context.Background()
+
+	owner := *options.DefaultClient
+	owner.NoServer = true
+	owner.Nonce = "test-nonce-getor-owner"
+	ownerClient := NewClient(&owner)
+	if err := ownerClient.Connect(ctx); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+
+	if err := ownerClient.Store(ctx, "guarded", "owner-only", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	filePath, _ := ownerClient.getFallbackFilePath("guarded") //nolint:errcheck
+	defer os.Remove(filePath)                                 //nolint:errcheck
+
+	intruder := *options.DefaultClient
+	intruder.NoServer = true
+	intruder.Nonce = "test-nonce-getor-intruder"
+	intruderClient := NewClient(&intruder)
+	if err := intruderClient.Connect(ctx); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+
+	if _, err := intruderClient.GetOr(ctx, "guarded", "should-not-appear"); err == nil {
+		t.Fatalf("Expected a decryption error, not the default")
+	} else if errors.Is(err, ErrSecretNotFound) || errors.Is(err, ErrSecretExpired) {
+		t.Errorf("Expected a non-sentinel error for a wrong-nonce read, got %v", err)
+	}
+}
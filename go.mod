@@ -5,6 +5,7 @@ go 1.25.8
 require (
 	github.com/chainguard-dev/clog v1.8.1
 	github.com/keybase/go-keychain v0.0.1
+	github.com/zeebo/blake3 v0.2.4
 	golang.org/x/crypto v0.54.0
 	golang.org/x/sys v0.47.0
 	google.golang.org/grpc v1.82.1
@@ -12,6 +13,7 @@ require (
 )
 
 require (
+	github.com/klauspost/cpuid/v2 v2.0.12 // indirect
 	golang.org/x/net v0.56.0 // indirect
 	golang.org/x/text v0.40.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20260414002931-afd174a4e478 // indirect
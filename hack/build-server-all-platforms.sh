@@ -44,6 +44,10 @@ for platform in "${PLATFORMS[@]}"; do
         -ldflags="-s -w" \
         "$PROJECT_DIR/cmd/burnafter-server"
 
+    # Record the SHA-256 of the uncompressed binary next to the embed; the
+    # client verifies the decompressed bytes against it before executing them.
+    sha256sum "$output_path" | cut -d' ' -f1 > "${output_path}.sha256"
+
     # Compress with Go's compress/gzip (via hack/gzip) rather than the system
     # gzip: its DEFLATE output is identical on every host for a given Go version,
     # whereas the system gzip differs (e.g. BSD/Apple vs GNU), which broke the
@@ -1,61 +1,2257 @@
 // SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
 // SPDX-License-Identifier: Apache-2.0
 
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
 // 	protoc-gen-go v1.36.11
 // 	protoc        v4.24.4
 // source: proto/burnafter.proto
 
-package common
+package common
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ErrorCode classifies a failed request so clients can map the failure onto
+// a typed sentinel error with errors.Is instead of matching on the message
+// text. The error string stays the human-readable detail; the code is the
+// machine-readable class. Failures without a dedicated class report
+// ERROR_UNSPECIFIED.
+type ErrorCode int32
+
+const (
+	// The request succeeded, or the failure has no dedicated class
+	ErrorCode_ERROR_UNSPECIFIED ErrorCode = 0
+	// The named secret (or handle) does not exist
+	ErrorCode_ERROR_NOT_FOUND ErrorCode = 1
+	// The secret existed but its lifetime has run out
+	ErrorCode_ERROR_EXPIRED ErrorCode = 2
+	// The caller's binary hash does not match the one recorded at store time
+	ErrorCode_ERROR_BINARY_MISMATCH ErrorCode = 3
+	// The secret is sealed until its not-before time passes
+	ErrorCode_ERROR_NOT_YET_VALID ErrorCode = 4
+)
+
+// Enum value maps for ErrorCode.
+var (
+	ErrorCode_name = map[int32]string{
+		0: "ERROR_UNSPECIFIED",
+		1: "ERROR_NOT_FOUND",
+		2: "ERROR_EXPIRED",
+		3: "ERROR_BINARY_MISMATCH",
+		4: "ERROR_NOT_YET_VALID",
+	}
+	ErrorCode_value = map[string]int32{
+		"ERROR_UNSPECIFIED":     0,
+		"ERROR_NOT_FOUND":       1,
+		"ERROR_EXPIRED":         2,
+		"ERROR_BINARY_MISMATCH": 3,
+		"ERROR_NOT_YET_VALID":   4,
+	}
+)
+
+func (x ErrorCode) Enum() *ErrorCode {
+	p := new(ErrorCode)
+	*p = x
+	return p
+}
+
+func (x ErrorCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_burnafter_proto_enumTypes[0].Descriptor()
+}
+
+func (ErrorCode) Type() protoreflect.EnumType {
+	return &file_proto_burnafter_proto_enumTypes[0]
+}
+
+func (x ErrorCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorCode.Descriptor instead.
+func (ErrorCode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{0}
+}
+
+// StatRequest asks for a secret's lifecycle metadata by name
+type StatRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Secret name to describe
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatRequest) Reset() {
+	*x = StatRequest{}
+	mi := &file_proto_burnafter_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatRequest) ProtoMessage() {}
+
+func (x *StatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatRequest.ProtoReflect.Descriptor instead.
+func (*StatRequest) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StatRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// StatResponse reports a secret's lifecycle metadata without its value.
+// Answering it reads no payload, decrypts nothing and does not count as an
+// access — neither the secret's inactivity timer nor the server's idle
+// shutdown clock moves — so a dashboard can watch a secret's expiry without
+// extending its life.
+type StatResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error   string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// Inactivity TTL in seconds (negative = expires only at the absolute deadline)
+	TtlSeconds int64 `protobuf:"varint,3,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	// Unix timestamp of the absolute expiration deadline (0 = none)
+	AbsoluteExpiresAt int64 `protobuf:"varint,4,opt,name=absolute_expires_at,json=absoluteExpiresAt,proto3" json:"absolute_expires_at,omitempty"`
+	// Unix timestamp of the last access
+	LastAccessed int64 `protobuf:"varint,5,opt,name=last_accessed,json=lastAccessed,proto3" json:"last_accessed,omitempty"`
+	// Unix timestamp the secret was stored
+	CreatedAt int64 `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Lifecycle state at the time of the request (see LocateResponse.state)
+	State         string `protobuf:"bytes,7,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatResponse) Reset() {
+	*x = StatResponse{}
+	mi := &file_proto_burnafter_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatResponse) ProtoMessage() {}
+
+func (x *StatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatResponse.ProtoReflect.Descriptor instead.
+func (*StatResponse) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StatResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StatResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *StatResponse) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+func (x *StatResponse) GetAbsoluteExpiresAt() int64 {
+	if x != nil {
+		return x.AbsoluteExpiresAt
+	}
+	return 0
+}
+
+func (x *StatResponse) GetLastAccessed() int64 {
+	if x != nil {
+		return x.LastAccessed
+	}
+	return 0
+}
+
+func (x *StatResponse) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *StatResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+// RotateRequest atomically replaces an existing secret's value, for
+// credential rotation. The swap happens in place under the server's metadata
+// lock, so there is no delete-then-create window in which readers see the
+// secret missing. The inactivity timer restarts (the rotation counts as an
+// access); the absolute deadline is preserved unless the request sets a new
+// one.
+type RotateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the secret to rotate
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Replacement secret value (see StoreRequest.secret)
+	Secret []byte `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	// Compile-time nonce from client
+	ClientNonce string `protobuf:"bytes,3,opt,name=client_nonce,json=clientNonce,proto3" json:"client_nonce,omitempty"`
+	// Optional: new inactivity TTL in seconds (0 = keep the current one)
+	TtlSeconds int64 `protobuf:"varint,4,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	// Optional: new absolute expiration in seconds from now (0 = preserve the
+	// original deadline)
+	AbsoluteExpirationSeconds int64 `protobuf:"varint,5,opt,name=absolute_expiration_seconds,json=absoluteExpirationSeconds,proto3" json:"absolute_expiration_seconds,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *RotateRequest) Reset() {
+	*x = RotateRequest{}
+	mi := &file_proto_burnafter_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateRequest) ProtoMessage() {}
+
+func (x *RotateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateRequest.ProtoReflect.Descriptor instead.
+func (*RotateRequest) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RotateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RotateRequest) GetSecret() []byte {
+	if x != nil {
+		return x.Secret
+	}
+	return nil
+}
+
+func (x *RotateRequest) GetClientNonce() string {
+	if x != nil {
+		return x.ClientNonce
+	}
+	return ""
+}
+
+func (x *RotateRequest) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+func (x *RotateRequest) GetAbsoluteExpirationSeconds() int64 {
+	if x != nil {
+		return x.AbsoluteExpirationSeconds
+	}
+	return 0
+}
+
+// RotateResponse returns the result of rotating a secret
+type RotateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateResponse) Reset() {
+	*x = RotateResponse{}
+	mi := &file_proto_burnafter_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateResponse) ProtoMessage() {}
+
+func (x *RotateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateResponse.ProtoReflect.Descriptor instead.
+func (*RotateResponse) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RotateResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RotateResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// ListRequest asks the server for an inventory of the secrets it is guarding
+type ListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_proto_burnafter_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{4}
+}
+
+// SecretListEntry describes one guarded secret: its name, lifecycle state and
+// the storage backend holding its payload. The backend is uniform across the
+// server today, but it is reported per secret so that backends can differ
+// later (e.g. keyring secrets spilling to memory on quota).
+type SecretListEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	State         string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Backend       string                 `protobuf:"bytes,3,opt,name=backend,proto3" json:"backend,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretListEntry) Reset() {
+	*x = SecretListEntry{}
+	mi := &file_proto_burnafter_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretListEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretListEntry) ProtoMessage() {}
+
+func (x *SecretListEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretListEntry.ProtoReflect.Descriptor instead.
+func (*SecretListEntry) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SecretListEntry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SecretListEntry) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *SecretListEntry) GetBackend() string {
+	if x != nil {
+		return x.Backend
+	}
+	return ""
+}
+
+// ListResponse returns the server's secret inventory
+type ListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Secrets       []*SecretListEntry     `protobuf:"bytes,3,rep,name=secrets,proto3" json:"secrets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	mi := &file_proto_burnafter_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResponse) ProtoMessage() {}
+
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListResponse) GetSecrets() []*SecretListEntry {
+	if x != nil {
+		return x.Secrets
+	}
+	return nil
+}
+
+// StoreRequest represents a request from the client to store a new secret
+type StoreRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Secret name (id to refetence it)
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Secret value to store. A bytes field (not string) so secrets containing
+	// arbitrary binary data survive proto3's UTF-8 enforcement on the wire
+	Secret []byte `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	// Inactivity TTL in seconds (secret expires if not accessed for this duration)
+	TtlSeconds int64 `protobuf:"varint,3,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	// Compile-time nonce from client
+	ClientNonce string `protobuf:"bytes,4,opt,name=client_nonce,json=clientNonce,proto3" json:"client_nonce,omitempty"`
+	// Optional: absolute expiration time in seconds from now (0 = no absolute expiration)
+	AbsoluteExpirationSeconds int64 `protobuf:"varint,5,opt,name=absolute_expiration_seconds,json=absoluteExpirationSeconds,proto3" json:"absolute_expiration_seconds,omitempty"`
+	// Optional: labels to tag the secret with, usable to burn whole cohorts
+	// of secrets at once with DeleteWhere
+	Labels map[string]string `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Optional: when true, the server returns a single-use retrieval handle
+	// for the secret in the store response
+	ReturnHandle bool `protobuf:"varint,7,opt,name=return_handle,json=returnHandle,proto3" json:"return_handle,omitempty"`
+	// Optional: unix timestamp before which the secret cannot be read
+	// (0 = readable immediately)
+	NotBefore int64 `protobuf:"varint,8,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	// Optional: when true, the store response echoes the non-sensitive
+	// derivation parameters the server used (see DerivationInfo)
+	EchoDerivation bool `protobuf:"varint,9,opt,name=echo_derivation,json=echoDerivation,proto3" json:"echo_derivation,omitempty"`
+	// Optional: maximum number of successful reads before the secret is
+	// destroyed (0 = unlimited; 1 = classic one-time secret)
+	MaxReads      int64 `protobuf:"varint,10,opt,name=max_reads,json=maxReads,proto3" json:"max_reads,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreRequest) Reset() {
+	*x = StoreRequest{}
+	mi := &file_proto_burnafter_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreRequest) ProtoMessage() {}
+
+func (x *StoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreRequest.ProtoReflect.Descriptor instead.
+func (*StoreRequest) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StoreRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StoreRequest) GetSecret() []byte {
+	if x != nil {
+		return x.Secret
+	}
+	return nil
+}
+
+func (x *StoreRequest) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+func (x *StoreRequest) GetClientNonce() string {
+	if x != nil {
+		return x.ClientNonce
+	}
+	return ""
+}
+
+func (x *StoreRequest) GetAbsoluteExpirationSeconds() int64 {
+	if x != nil {
+		return x.AbsoluteExpirationSeconds
+	}
+	return 0
+}
+
+func (x *StoreRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *StoreRequest) GetReturnHandle() bool {
+	if x != nil {
+		return x.ReturnHandle
+	}
+	return false
+}
+
+func (x *StoreRequest) GetNotBefore() int64 {
+	if x != nil {
+		return x.NotBefore
+	}
+	return 0
+}
+
+func (x *StoreRequest) GetEchoDerivation() bool {
+	if x != nil {
+		return x.EchoDerivation
+	}
+	return false
+}
+
+func (x *StoreRequest) GetMaxReads() int64 {
+	if x != nil {
+		return x.MaxReads
+	}
+	return 0
+}
+
+// DerivationInfo echoes the non-sensitive parameters a secret's key was
+// derived with: the KDF and cipher names, the salt length and the client
+// binary hash the server computed for the caller. No key material travels;
+// clients can compare client_binary_hash against their own fingerprint to
+// catch a mismatch at store time instead of at the next Get.
+type DerivationInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Key derivation function, e.g. "hkdf-sha256"
+	Kdf string `protobuf:"bytes,1,opt,name=kdf,proto3" json:"kdf,omitempty"`
+	// Cipher the secret is sealed with, e.g. "aes-256-gcm"
+	Cipher string `protobuf:"bytes,2,opt,name=cipher,proto3" json:"cipher,omitempty"`
+	// Length in bytes of the random salt mixed into the derivation
+	SaltLength int64 `protobuf:"varint,3,opt,name=salt_length,json=saltLength,proto3" json:"salt_length,omitempty"`
+	// The client binary hash the server bound into the key
+	ClientBinaryHash string `protobuf:"bytes,4,opt,name=client_binary_hash,json=clientBinaryHash,proto3" json:"client_binary_hash,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DerivationInfo) Reset() {
+	*x = DerivationInfo{}
+	mi := &file_proto_burnafter_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DerivationInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DerivationInfo) ProtoMessage() {}
+
+func (x *DerivationInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DerivationInfo.ProtoReflect.Descriptor instead.
+func (*DerivationInfo) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DerivationInfo) GetKdf() string {
+	if x != nil {
+		return x.Kdf
+	}
+	return ""
+}
+
+func (x *DerivationInfo) GetCipher() string {
+	if x != nil {
+		return x.Cipher
+	}
+	return ""
+}
+
+func (x *DerivationInfo) GetSaltLength() int64 {
+	if x != nil {
+		return x.SaltLength
+	}
+	return 0
+}
+
+func (x *DerivationInfo) GetClientBinaryHash() string {
+	if x != nil {
+		return x.ClientBinaryHash
+	}
+	return ""
+}
+
+// DeleteRequest asks the server to remove a single secret by name. The server
+// verifies the caller's binary hash against the one recorded at store time, so
+// a foreign binary cannot evict secrets it didn't create.
+type DeleteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Secret name to delete
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	mi := &file_proto_burnafter_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// DeleteResponse returns the result of deleting a secret
+type DeleteResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error   string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// Machine-readable failure class, set alongside error on failure
+	ErrorCode     ErrorCode `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3,enum=burnafter.ErrorCode" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteResponse) Reset() {
+	*x = DeleteResponse{}
+	mi := &file_proto_burnafter_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteResponse) ProtoMessage() {}
+
+func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DeleteResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_ERROR_UNSPECIFIED
+}
+
+// DeleteWhereRequest asks the server to delete every secret matching a
+// predicate: all the given labels must match and, when older_than_seconds is
+// set, the secret must have been stored at least that long ago. At least one
+// of the two must be specified.
+type DeleteWhereRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Labels that must all be present (with equal values) on a secret
+	Labels map[string]string `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Minimum age, in seconds since the secret was stored (0 = any age)
+	OlderThanSeconds int64 `protobuf:"varint,2,opt,name=older_than_seconds,json=olderThanSeconds,proto3" json:"older_than_seconds,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DeleteWhereRequest) Reset() {
+	*x = DeleteWhereRequest{}
+	mi := &file_proto_burnafter_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWhereRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWhereRequest) ProtoMessage() {}
+
+func (x *DeleteWhereRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWhereRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWhereRequest) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteWhereRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *DeleteWhereRequest) GetOlderThanSeconds() int64 {
+	if x != nil {
+		return x.OlderThanSeconds
+	}
+	return 0
+}
+
+// DeleteWhereResponse returns the results of a predicate delete
+type DeleteWhereResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error   string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// Number of secrets deleted
+	Deleted       int64 `protobuf:"varint,3,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWhereResponse) Reset() {
+	*x = DeleteWhereResponse{}
+	mi := &file_proto_burnafter_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWhereResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWhereResponse) ProtoMessage() {}
+
+func (x *DeleteWhereResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWhereResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWhereResponse) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteWhereResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteWhereResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DeleteWhereResponse) GetDeleted() int64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+// StoreResponse returns the results of storing a secret
+type StoreResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error   string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// Single-use retrieval handle, set only when the request asked for one
+	Handle string `protobuf:"bytes,3,opt,name=handle,proto3" json:"handle,omitempty"`
+	// Derivation parameters, set only when the request asked for them
+	Derivation *DerivationInfo `protobuf:"bytes,4,opt,name=derivation,proto3" json:"derivation,omitempty"`
+	// The inactivity TTL actually applied, in seconds, after server-side
+	// defaulting (negative = the secret only expires at its absolute deadline).
+	// Reported so schedulers can plan refreshes without knowing the server's
+	// DefaultTTL.
+	EffectiveTtlSeconds int64 `protobuf:"varint,5,opt,name=effective_ttl_seconds,json=effectiveTtlSeconds,proto3" json:"effective_ttl_seconds,omitempty"`
+	// Unix timestamp of the resolved absolute expiration deadline (0 = none)
+	AbsoluteExpiresAt int64 `protobuf:"varint,6,opt,name=absolute_expires_at,json=absoluteExpiresAt,proto3" json:"absolute_expires_at,omitempty"`
+	// Machine-readable failure class, set alongside error on failure
+	ErrorCode     ErrorCode `protobuf:"varint,7,opt,name=error_code,json=errorCode,proto3,enum=burnafter.ErrorCode" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreResponse) Reset() {
+	*x = StoreResponse{}
+	mi := &file_proto_burnafter_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreResponse) ProtoMessage() {}
+
+func (x *StoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreResponse.ProtoReflect.Descriptor instead.
+func (*StoreResponse) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *StoreResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StoreResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *StoreResponse) GetHandle() string {
+	if x != nil {
+		return x.Handle
+	}
+	return ""
+}
+
+func (x *StoreResponse) GetDerivation() *DerivationInfo {
+	if x != nil {
+		return x.Derivation
+	}
+	return nil
+}
+
+func (x *StoreResponse) GetEffectiveTtlSeconds() int64 {
+	if x != nil {
+		return x.EffectiveTtlSeconds
+	}
+	return 0
+}
+
+func (x *StoreResponse) GetAbsoluteExpiresAt() int64 {
+	if x != nil {
+		return x.AbsoluteExpiresAt
+	}
+	return 0
+}
+
+func (x *StoreResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_ERROR_UNSPECIFIED
+}
+
+// GetByHandleRequest redeems a single-use retrieval handle issued at store
+// time. The handle is invalidated by the redemption attempt.
+type GetByHandleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The opaque handle returned by Store
+	Handle string `protobuf:"bytes,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	// Client nonce, a baked value in the client somewhat hidden
+	ClientNonce   string `protobuf:"bytes,2,opt,name=client_nonce,json=clientNonce,proto3" json:"client_nonce,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetByHandleRequest) Reset() {
+	*x = GetByHandleRequest{}
+	mi := &file_proto_burnafter_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetByHandleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetByHandleRequest) ProtoMessage() {}
+
+func (x *GetByHandleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetByHandleRequest.ProtoReflect.Descriptor instead.
+func (*GetByHandleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetByHandleRequest) GetHandle() string {
+	if x != nil {
+		return x.Handle
+	}
+	return ""
+}
+
+func (x *GetByHandleRequest) GetClientNonce() string {
+	if x != nil {
+		return x.ClientNonce
+	}
+	return ""
+}
+
+// Get request is a request from the client to retrieve a secre
+type GetRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Secret name to retrieve
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Client nonce, a baked value in the client somewhat hidden
+	ClientNonce   string `protobuf:"bytes,2,opt,name=client_nonce,json=clientNonce,proto3" json:"client_nonce,omitempty"` // Compile-time nonce from client
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRequest) Reset() {
+	*x = GetRequest{}
+	mi := &file_proto_burnafter_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRequest) ProtoMessage() {}
+
+func (x *GetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetRequest) GetClientNonce() string {
+	if x != nil {
+		return x.ClientNonce
+	}
+	return ""
+}
+
+// GetResponse returns the results when retrieving the secret.
+type GetResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// Raw secret bytes (see StoreRequest.secret)
+	Secret []byte `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	Error  string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// Inactivity TTL in seconds, as reset by this access
+	TtlSeconds int64 `protobuf:"varint,4,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	// Unix timestamp of the absolute expiration deadline (0 = none)
+	AbsoluteExpiresAt int64 `protobuf:"varint,5,opt,name=absolute_expires_at,json=absoluteExpiresAt,proto3" json:"absolute_expires_at,omitempty"`
+	// Machine-readable failure class, set alongside error on failure
+	ErrorCode     ErrorCode `protobuf:"varint,6,opt,name=error_code,json=errorCode,proto3,enum=burnafter.ErrorCode" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetResponse) Reset() {
+	*x = GetResponse{}
+	mi := &file_proto_burnafter_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResponse) ProtoMessage() {}
+
+func (x *GetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResponse.ProtoReflect.Descriptor instead.
+func (*GetResponse) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetResponse) GetSecret() []byte {
+	if x != nil {
+		return x.Secret
+	}
+	return nil
+}
+
+func (x *GetResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetResponse) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+func (x *GetResponse) GetAbsoluteExpiresAt() int64 {
+	if x != nil {
+		return x.AbsoluteExpiresAt
+	}
+	return 0
+}
+
+func (x *GetResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_ERROR_UNSPECIFIED
+}
+
+// StoreStreamRequest is one frame of a client-streaming store for secrets too
+// large to buffer whole. The first frame carries the store parameters (a
+// StoreRequest whose secret field stays empty); every following frame carries
+// one plaintext chunk, in order.
+type StoreStreamRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Frame:
+	//
+	//	*StoreStreamRequest_Info
+	//	*StoreStreamRequest_Chunk
+	Frame         isStoreStreamRequest_Frame `protobuf_oneof:"frame"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreStreamRequest) Reset() {
+	*x = StoreStreamRequest{}
+	mi := &file_proto_burnafter_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreStreamRequest) ProtoMessage() {}
+
+func (x *StoreStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreStreamRequest.ProtoReflect.Descriptor instead.
+func (*StoreStreamRequest) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *StoreStreamRequest) GetFrame() isStoreStreamRequest_Frame {
+	if x != nil {
+		return x.Frame
+	}
+	return nil
+}
+
+func (x *StoreStreamRequest) GetInfo() *StoreRequest {
+	if x != nil {
+		if x, ok := x.Frame.(*StoreStreamRequest_Info); ok {
+			return x.Info
+		}
+	}
+	return nil
+}
+
+func (x *StoreStreamRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Frame.(*StoreStreamRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isStoreStreamRequest_Frame interface {
+	isStoreStreamRequest_Frame()
+}
+
+type StoreStreamRequest_Info struct {
+	Info *StoreRequest `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
+}
+
+type StoreStreamRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*StoreStreamRequest_Info) isStoreStreamRequest_Frame() {}
+
+func (*StoreStreamRequest_Chunk) isStoreStreamRequest_Frame() {}
+
+// GetStreamResponse is one frame of a server-streaming get. The first frame
+// reports the outcome and the lifecycle metadata with no chunk; on success
+// the following frames each carry one plaintext chunk, in order.
+type GetStreamResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error   string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// Machine-readable failure class, set alongside error on failure
+	ErrorCode ErrorCode `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3,enum=burnafter.ErrorCode" json:"error_code,omitempty"`
+	// Inactivity TTL in seconds, as reset by this access
+	TtlSeconds int64 `protobuf:"varint,4,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	// Unix timestamp of the absolute expiration deadline (0 = none)
+	AbsoluteExpiresAt int64 `protobuf:"varint,5,opt,name=absolute_expires_at,json=absoluteExpiresAt,proto3" json:"absolute_expires_at,omitempty"`
+	// One plaintext chunk (empty in the first frame)
+	Chunk         []byte `protobuf:"bytes,6,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStreamResponse) Reset() {
+	*x = GetStreamResponse{}
+	mi := &file_proto_burnafter_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStreamResponse) ProtoMessage() {}
+
+func (x *GetStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStreamResponse.ProtoReflect.Descriptor instead.
+func (*GetStreamResponse) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetStreamResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetStreamResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetStreamResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_ERROR_UNSPECIFIED
+}
+
+func (x *GetStreamResponse) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+func (x *GetStreamResponse) GetAbsoluteExpiresAt() int64 {
+	if x != nil {
+		return x.AbsoluteExpiresAt
+	}
+	return 0
+}
+
+func (x *GetStreamResponse) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+// LocateRequest asks the server which storage backend holds a secret
+type LocateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Secret name to locate
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LocateRequest) Reset() {
+	*x = LocateRequest{}
+	mi := &file_proto_burnafter_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LocateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocateRequest) ProtoMessage() {}
+
+func (x *LocateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocateRequest.ProtoReflect.Descriptor instead.
+func (*LocateRequest) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *LocateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// LocateResponse reports the storage backend guarding the secret
+type LocateResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error   string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// Backend identifier, e.g. "keyring" or "memory"
+	Location string `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	// Lifecycle state of the secret at the time of the request: "live",
+	// "expired-inactivity" or "expired-absolute". Expired states are reported
+	// for secrets the cleanup sweep has not reaped yet, exposing the sweep lag.
+	State         string `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LocateResponse) Reset() {
+	*x = LocateResponse{}
+	mi := &file_proto_burnafter_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LocateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocateResponse) ProtoMessage() {}
+
+func (x *LocateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocateResponse.ProtoReflect.Descriptor instead.
+func (*LocateResponse) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *LocateResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *LocateResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *LocateResponse) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *LocateResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+// GetEncryptedResponse returns a secret's raw encrypted payload for relaying
+// to another burnafter instance. Only served when the server was started with
+// the encrypted-export option enabled.
+type GetEncryptedResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error   string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// The AES-GCM encrypted secret, exactly as held by the storage backend
+	EncryptedData []byte `protobuf:"bytes,3,opt,name=encrypted_data,json=encryptedData,proto3" json:"encrypted_data,omitempty"`
+	// Salt used when deriving the secret's key
+	Salt []byte `protobuf:"bytes,4,opt,name=salt,proto3" json:"salt,omitempty"`
+	// Hash of the client binary that stored the secret
+	ClientBinaryHash string `protobuf:"bytes,5,opt,name=client_binary_hash,json=clientBinaryHash,proto3" json:"client_binary_hash,omitempty"`
+	// True when the payload was stored through the streaming RPC and is framed
+	// as per-chunk AES-GCM records instead of one sealed blob
+	Chunked       bool `protobuf:"varint,6,opt,name=chunked,proto3" json:"chunked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEncryptedResponse) Reset() {
+	*x = GetEncryptedResponse{}
+	mi := &file_proto_burnafter_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEncryptedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEncryptedResponse) ProtoMessage() {}
+
+func (x *GetEncryptedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEncryptedResponse.ProtoReflect.Descriptor instead.
+func (*GetEncryptedResponse) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetEncryptedResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetEncryptedResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetEncryptedResponse) GetEncryptedData() []byte {
+	if x != nil {
+		return x.EncryptedData
+	}
+	return nil
+}
+
+func (x *GetEncryptedResponse) GetSalt() []byte {
+	if x != nil {
+		return x.Salt
+	}
+	return nil
+}
+
+func (x *GetEncryptedResponse) GetClientBinaryHash() string {
+	if x != nil {
+		return x.ClientBinaryHash
+	}
+	return ""
+}
+
+func (x *GetEncryptedResponse) GetChunked() bool {
+	if x != nil {
+		return x.Chunked
+	}
+	return false
+}
+
+// GetConfigRequest asks the server for its effective configuration
+type GetConfigRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional: when true, the response also carries aggregate secret-size
+	// statistics for capacity planning
+	IncludeStats  bool `protobuf:"varint,1,opt,name=include_stats,json=includeStats,proto3" json:"include_stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigRequest) Reset() {
+	*x = GetConfigRequest{}
+	mi := &file_proto_burnafter_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigRequest) ProtoMessage() {}
+
+func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigRequest) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetConfigRequest) GetIncludeStats() bool {
+	if x != nil {
+		return x.IncludeStats
+	}
+	return false
+}
+
+// SecretStats aggregates the sizes of the stored secrets, derived from the
+// plaintext lengths recorded at store time. Only the distribution is
+// reported; no secret names or values are exposed.
+type SecretStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of secrets counted (aliases hold no payload and are excluded)
+	Count int64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// Sum of the recorded plaintext sizes, in bytes
+	TotalBytes int64 `protobuf:"varint,2,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	// Smallest recorded plaintext size, in bytes
+	MinBytes int64 `protobuf:"varint,3,opt,name=min_bytes,json=minBytes,proto3" json:"min_bytes,omitempty"`
+	// Largest recorded plaintext size, in bytes
+	MaxBytes int64 `protobuf:"varint,4,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	// Mean recorded plaintext size, in bytes
+	MeanBytes     float64 `protobuf:"fixed64,5,opt,name=mean_bytes,json=meanBytes,proto3" json:"mean_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretStats) Reset() {
+	*x = SecretStats{}
+	mi := &file_proto_burnafter_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretStats) ProtoMessage() {}
+
+func (x *SecretStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretStats.ProtoReflect.Descriptor instead.
+func (*SecretStats) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SecretStats) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *SecretStats) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+func (x *SecretStats) GetMinBytes() int64 {
+	if x != nil {
+		return x.MinBytes
+	}
+	return 0
+}
+
+func (x *SecretStats) GetMaxBytes() int64 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+func (x *SecretStats) GetMeanBytes() float64 {
+	if x != nil {
+		return x.MeanBytes
+	}
+	return 0
+}
+
+// GetConfigResponse reports the server's effective non-secret configuration,
+// so clients can confirm the daemon runs with the options they expect. No
+// secret names or values are ever included.
+type GetConfigResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error   string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// Default inactivity TTL applied to secrets stored without one, in seconds
+	DefaultTtlSeconds int64 `protobuf:"varint,3,opt,name=default_ttl_seconds,json=defaultTtlSeconds,proto3" json:"default_ttl_seconds,omitempty"`
+	// Maximum number of secrets the server will hold
+	MaxSecrets int64 `protobuf:"varint,4,opt,name=max_secrets,json=maxSecrets,proto3" json:"max_secrets,omitempty"`
+	// Maximum size of a single secret, in bytes
+	MaxSecretSize int64 `protobuf:"varint,5,opt,name=max_secret_size,json=maxSecretSize,proto3" json:"max_secret_size,omitempty"`
+	// Server inactivity shutdown timeout, in seconds (0 = disabled)
+	InactivityTimeoutSeconds int64 `protobuf:"varint,6,opt,name=inactivity_timeout_seconds,json=inactivityTimeoutSeconds,proto3" json:"inactivity_timeout_seconds,omitempty"`
+	// Storage backend identifier, e.g. "keyring" or "memory"
+	Backend string `protobuf:"bytes,7,opt,name=backend,proto3" json:"backend,omitempty"`
+	// Interval between expired-secret cleanup sweeps, in seconds
+	CleanupIntervalSeconds int64 `protobuf:"varint,8,opt,name=cleanup_interval_seconds,json=cleanupIntervalSeconds,proto3" json:"cleanup_interval_seconds,omitempty"`
+	// Aggregate secret-size statistics, present only when the request set
+	// include_stats
+	Stats         *SecretStats `protobuf:"bytes,9,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigResponse) Reset() {
+	*x = GetConfigResponse{}
+	mi := &file_proto_burnafter_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigResponse) ProtoMessage() {}
+
+func (x *GetConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetConfigResponse) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetConfigResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetConfigResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetConfigResponse) GetDefaultTtlSeconds() int64 {
+	if x != nil {
+		return x.DefaultTtlSeconds
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetMaxSecrets() int64 {
+	if x != nil {
+		return x.MaxSecrets
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetMaxSecretSize() int64 {
+	if x != nil {
+		return x.MaxSecretSize
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetInactivityTimeoutSeconds() int64 {
+	if x != nil {
+		return x.InactivityTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetBackend() string {
+	if x != nil {
+		return x.Backend
+	}
+	return ""
+}
+
+func (x *GetConfigResponse) GetCleanupIntervalSeconds() int64 {
+	if x != nil {
+		return x.CleanupIntervalSeconds
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetStats() *SecretStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+// SetDebugRequest toggles the server's debug logging at runtime
+type SetDebugRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether debug logging should be enabled
+	Enabled       bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDebugRequest) Reset() {
+	*x = SetDebugRequest{}
+	mi := &file_proto_burnafter_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDebugRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDebugRequest) ProtoMessage() {}
+
+func (x *SetDebugRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDebugRequest.ProtoReflect.Descriptor instead.
+func (*SetDebugRequest) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SetDebugRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+// SetDebugResponse reports the result of a debug toggle request
+type SetDebugResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDebugResponse) Reset() {
+	*x = SetDebugResponse{}
+	mi := &file_proto_burnafter_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDebugResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDebugResponse) ProtoMessage() {}
+
+func (x *SetDebugResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDebugResponse.ProtoReflect.Descriptor instead.
+func (*SetDebugResponse) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SetDebugResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetDebugResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// ShutdownRequest asks the server to wipe every stored secret and exit now,
+// instead of lingering until the inactivity timeout or the cleanup sweep
+type ShutdownRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShutdownRequest) Reset() {
+	*x = ShutdownRequest{}
+	mi := &file_proto_burnafter_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShutdownRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShutdownRequest) ProtoMessage() {}
+
+func (x *ShutdownRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShutdownRequest.ProtoReflect.Descriptor instead.
+func (*ShutdownRequest) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{27}
+}
+
+// ShutdownResponse acknowledges the shutdown before the server actually
+// stops, so the caller knows the wipe happened
+type ShutdownResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShutdownResponse) Reset() {
+	*x = ShutdownResponse{}
+	mi := &file_proto_burnafter_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShutdownResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShutdownResponse) ProtoMessage() {}
+
+func (x *ShutdownResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShutdownResponse.ProtoReflect.Descriptor instead.
+func (*ShutdownResponse) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ShutdownResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ShutdownResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// StoreAliasRequest creates a metadata-only alias that resolves to another
+// secret. The alias shares the target's encrypted payload and its expiry;
+// deleting the target invalidates the alias.
+type StoreAliasRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the alias to create
+	Alias string `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
+	// Name of the existing secret the alias resolves to
+	Target        string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreAliasRequest) Reset() {
+	*x = StoreAliasRequest{}
+	mi := &file_proto_burnafter_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreAliasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreAliasRequest) ProtoMessage() {}
+
+func (x *StoreAliasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreAliasRequest.ProtoReflect.Descriptor instead.
+func (*StoreAliasRequest) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *StoreAliasRequest) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *StoreAliasRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+// StoreAliasResponse returns the result of creating an alias
+type StoreAliasResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreAliasResponse) Reset() {
+	*x = StoreAliasResponse{}
+	mi := &file_proto_burnafter_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-import (
-	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
-	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	reflect "reflect"
-	sync "sync"
-	unsafe "unsafe"
-)
+func (x *StoreAliasResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-const (
-	// Verify that this generated code is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
-	// Verify that runtime/protoimpl is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
-)
+func (*StoreAliasResponse) ProtoMessage() {}
 
-// StoreRequest represents a request from the client to store a new secret
-type StoreRequest struct {
+func (x *StoreAliasResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreAliasResponse.ProtoReflect.Descriptor instead.
+func (*StoreAliasResponse) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *StoreAliasResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StoreAliasResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// ExtendDeadlineRequest pushes a secret's absolute expiration deadline
+// forward. The server caps extensions at its maximum absolute lifetime,
+// measured from the secret's original store time, so secrets still die
+// eventually.
+type ExtendDeadlineRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Secret name (id to refetence it)
+	// Name of the secret whose deadline to extend
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// Secret value to store
-	Secret string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
-	// Inactivity TTL in seconds (secret expires if not accessed for this duration)
-	TtlSeconds int64 `protobuf:"varint,3,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
-	// Compile-time nonce from client
-	ClientNonce string `protobuf:"bytes,4,opt,name=client_nonce,json=clientNonce,proto3" json:"client_nonce,omitempty"`
-	// Optional: absolute expiration time in seconds from now (0 = no absolute expiration)
-	AbsoluteExpirationSeconds int64 `protobuf:"varint,5,opt,name=absolute_expiration_seconds,json=absoluteExpirationSeconds,proto3" json:"absolute_expiration_seconds,omitempty"`
-	unknownFields             protoimpl.UnknownFields
-	sizeCache                 protoimpl.SizeCache
+	// Unix timestamp of the requested new absolute expiration deadline
+	NewAbsoluteExpiresAt int64 `protobuf:"varint,2,opt,name=new_absolute_expires_at,json=newAbsoluteExpiresAt,proto3" json:"new_absolute_expires_at,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
-func (x *StoreRequest) Reset() {
-	*x = StoreRequest{}
-	mi := &file_proto_burnafter_proto_msgTypes[0]
+func (x *ExtendDeadlineRequest) Reset() {
+	*x = ExtendDeadlineRequest{}
+	mi := &file_proto_burnafter_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StoreRequest) String() string {
+func (x *ExtendDeadlineRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StoreRequest) ProtoMessage() {}
+func (*ExtendDeadlineRequest) ProtoMessage() {}
 
-func (x *StoreRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_burnafter_proto_msgTypes[0]
+func (x *ExtendDeadlineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -66,48 +2262,167 @@ func (x *StoreRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StoreRequest.ProtoReflect.Descriptor instead.
-func (*StoreRequest) Descriptor() ([]byte, []int) {
-	return file_proto_burnafter_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use ExtendDeadlineRequest.ProtoReflect.Descriptor instead.
+func (*ExtendDeadlineRequest) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *StoreRequest) GetName() string {
+func (x *ExtendDeadlineRequest) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *StoreRequest) GetSecret() string {
+func (x *ExtendDeadlineRequest) GetNewAbsoluteExpiresAt() int64 {
 	if x != nil {
-		return x.Secret
+		return x.NewAbsoluteExpiresAt
+	}
+	return 0
+}
+
+// ExtendDeadlineResponse returns the result of a deadline extension
+type ExtendDeadlineResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error   string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// True when the extension was rejected because it would push the secret
+	// past the server's maximum absolute lifetime
+	LifetimeExceeded bool `protobuf:"varint,3,opt,name=lifetime_exceeded,json=lifetimeExceeded,proto3" json:"lifetime_exceeded,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ExtendDeadlineResponse) Reset() {
+	*x = ExtendDeadlineResponse{}
+	mi := &file_proto_burnafter_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtendDeadlineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendDeadlineResponse) ProtoMessage() {}
+
+func (x *ExtendDeadlineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendDeadlineResponse.ProtoReflect.Descriptor instead.
+func (*ExtendDeadlineResponse) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ExtendDeadlineResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ExtendDeadlineResponse) GetError() string {
+	if x != nil {
+		return x.Error
 	}
 	return ""
 }
 
-func (x *StoreRequest) GetTtlSeconds() int64 {
+func (x *ExtendDeadlineResponse) GetLifetimeExceeded() bool {
 	if x != nil {
-		return x.TtlSeconds
+		return x.LifetimeExceeded
 	}
-	return 0
+	return false
 }
 
-func (x *StoreRequest) GetClientNonce() string {
+// AppendRequest atomically appends data to an existing secret's plaintext on
+// the server, so callers building a secret from several sources never see the
+// accumulated value client-side.
+type AppendRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the secret to append to
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Data to append to the secret's plaintext. Bytes, not string, so appends
+	// to binary secrets survive proto3's UTF-8 validation.
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// Compile-time nonce from client
+	ClientNonce string `protobuf:"bytes,3,opt,name=client_nonce,json=clientNonce,proto3" json:"client_nonce,omitempty"`
+	// When true, a missing secret is created (with the default TTL) instead of
+	// failing the append
+	CreateIfMissing bool `protobuf:"varint,4,opt,name=create_if_missing,json=createIfMissing,proto3" json:"create_if_missing,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AppendRequest) Reset() {
+	*x = AppendRequest{}
+	mi := &file_proto_burnafter_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppendRequest) ProtoMessage() {}
+
+func (x *AppendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppendRequest.ProtoReflect.Descriptor instead.
+func (*AppendRequest) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AppendRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AppendRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *AppendRequest) GetClientNonce() string {
 	if x != nil {
 		return x.ClientNonce
 	}
 	return ""
 }
 
-func (x *StoreRequest) GetAbsoluteExpirationSeconds() int64 {
+func (x *AppendRequest) GetCreateIfMissing() bool {
 	if x != nil {
-		return x.AbsoluteExpirationSeconds
+		return x.CreateIfMissing
 	}
-	return 0
+	return false
 }
 
-// StoreResponse returns the results of storing a secret
-type StoreResponse struct {
+// AppendResponse returns the result of an append
+type AppendResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
@@ -115,21 +2430,21 @@ type StoreResponse struct {
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StoreResponse) Reset() {
-	*x = StoreResponse{}
-	mi := &file_proto_burnafter_proto_msgTypes[1]
+func (x *AppendResponse) Reset() {
+	*x = AppendResponse{}
+	mi := &file_proto_burnafter_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StoreResponse) String() string {
+func (x *AppendResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StoreResponse) ProtoMessage() {}
+func (*AppendResponse) ProtoMessage() {}
 
-func (x *StoreResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_burnafter_proto_msgTypes[1]
+func (x *AppendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -140,51 +2455,53 @@ func (x *StoreResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StoreResponse.ProtoReflect.Descriptor instead.
-func (*StoreResponse) Descriptor() ([]byte, []int) {
-	return file_proto_burnafter_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use AppendResponse.ProtoReflect.Descriptor instead.
+func (*AppendResponse) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *StoreResponse) GetSuccess() bool {
+func (x *AppendResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *StoreResponse) GetError() string {
+func (x *AppendResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
 	return ""
 }
 
-// Get request is a request from the client to retrieve a secre
-type GetRequest struct {
+// ReclaimRequest re-binds a secret to the calling binary's current hash after
+// a legitimate upgrade changed it. Only served when the server allows
+// reclaims, and only to a peer whose UID matches the original storer's.
+type ReclaimRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Secret name to retrieve
+	// Name of the secret to reclaim
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// Client nonce, a baked value in the client somewhat hidden
-	ClientNonce   string `protobuf:"bytes,2,opt,name=client_nonce,json=clientNonce,proto3" json:"client_nonce,omitempty"` // Compile-time nonce from client
+	// Compile-time nonce from client
+	ClientNonce   string `protobuf:"bytes,2,opt,name=client_nonce,json=clientNonce,proto3" json:"client_nonce,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetRequest) Reset() {
-	*x = GetRequest{}
-	mi := &file_proto_burnafter_proto_msgTypes[2]
+func (x *ReclaimRequest) Reset() {
+	*x = ReclaimRequest{}
+	mi := &file_proto_burnafter_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetRequest) String() string {
+func (x *ReclaimRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRequest) ProtoMessage() {}
+func (*ReclaimRequest) ProtoMessage() {}
 
-func (x *GetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_burnafter_proto_msgTypes[2]
+func (x *ReclaimRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -195,50 +2512,49 @@ func (x *GetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
-func (*GetRequest) Descriptor() ([]byte, []int) {
-	return file_proto_burnafter_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ReclaimRequest.ProtoReflect.Descriptor instead.
+func (*ReclaimRequest) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *GetRequest) GetName() string {
+func (x *ReclaimRequest) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *GetRequest) GetClientNonce() string {
+func (x *ReclaimRequest) GetClientNonce() string {
 	if x != nil {
 		return x.ClientNonce
 	}
 	return ""
 }
 
-// GetResponse returns the results when retrieving the secret.
-type GetResponse struct {
+// ReclaimResponse returns the result of a reclaim
+type ReclaimResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
-	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetResponse) Reset() {
-	*x = GetResponse{}
-	mi := &file_proto_burnafter_proto_msgTypes[3]
+func (x *ReclaimResponse) Reset() {
+	*x = ReclaimResponse{}
+	mi := &file_proto_burnafter_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetResponse) String() string {
+func (x *ReclaimResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetResponse) ProtoMessage() {}
+func (*ReclaimResponse) ProtoMessage() {}
 
-func (x *GetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_burnafter_proto_msgTypes[3]
+func (x *ReclaimResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_burnafter_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -249,26 +2565,19 @@ func (x *GetResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetResponse.ProtoReflect.Descriptor instead.
-func (*GetResponse) Descriptor() ([]byte, []int) {
-	return file_proto_burnafter_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ReclaimResponse.ProtoReflect.Descriptor instead.
+func (*ReclaimResponse) Descriptor() ([]byte, []int) {
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *GetResponse) GetSuccess() bool {
+func (x *ReclaimResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *GetResponse) GetSecret() string {
-	if x != nil {
-		return x.Secret
-	}
-	return ""
-}
-
-func (x *GetResponse) GetError() string {
+func (x *ReclaimResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
@@ -277,14 +2586,19 @@ func (x *GetResponse) GetError() string {
 
 // PingRequest an emptyu request to check if the3 server is alive
 type PingRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional: when true, the server also round-trips a sentinel payload
+	// through its storage backend and reports the result, so monitoring can
+	// tell a wedged backend (e.g. a stuck keyring worker) apart from process
+	// liveness
+	CheckStorage  bool `protobuf:"varint,1,opt,name=check_storage,json=checkStorage,proto3" json:"check_storage,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PingRequest) Reset() {
 	*x = PingRequest{}
-	mi := &file_proto_burnafter_proto_msgTypes[4]
+	mi := &file_proto_burnafter_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -296,7 +2610,7 @@ func (x *PingRequest) String() string {
 func (*PingRequest) ProtoMessage() {}
 
 func (x *PingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_burnafter_proto_msgTypes[4]
+	mi := &file_proto_burnafter_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -309,20 +2623,32 @@ func (x *PingRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PingRequest.ProtoReflect.Descriptor instead.
 func (*PingRequest) Descriptor() ([]byte, []int) {
-	return file_proto_burnafter_proto_rawDescGZIP(), []int{4}
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *PingRequest) GetCheckStorage() bool {
+	if x != nil {
+		return x.CheckStorage
+	}
+	return false
 }
 
 // Response when the server is running
 type PingResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Alive         bool                   `protobuf:"varint,1,opt,name=alive,proto3" json:"alive,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Alive bool                   `protobuf:"varint,1,opt,name=alive,proto3" json:"alive,omitempty"`
+	// Whether the storage self-test passed (only meaningful when the request
+	// set check_storage)
+	StorageHealthy bool `protobuf:"varint,2,opt,name=storage_healthy,json=storageHealthy,proto3" json:"storage_healthy,omitempty"`
+	// Error from the storage self-test, empty when healthy
+	StorageError  string `protobuf:"bytes,3,opt,name=storage_error,json=storageError,proto3" json:"storage_error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PingResponse) Reset() {
 	*x = PingResponse{}
-	mi := &file_proto_burnafter_proto_msgTypes[5]
+	mi := &file_proto_burnafter_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -334,7 +2660,7 @@ func (x *PingResponse) String() string {
 func (*PingResponse) ProtoMessage() {}
 
 func (x *PingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_burnafter_proto_msgTypes[5]
+	mi := &file_proto_burnafter_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -347,7 +2673,7 @@ func (x *PingResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PingResponse.ProtoReflect.Descriptor instead.
 func (*PingResponse) Descriptor() ([]byte, []int) {
-	return file_proto_burnafter_proto_rawDescGZIP(), []int{5}
+	return file_proto_burnafter_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *PingResponse) GetAlive() bool {
@@ -357,36 +2683,242 @@ func (x *PingResponse) GetAlive() bool {
 	return false
 }
 
+func (x *PingResponse) GetStorageHealthy() bool {
+	if x != nil {
+		return x.StorageHealthy
+	}
+	return false
+}
+
+func (x *PingResponse) GetStorageError() string {
+	if x != nil {
+		return x.StorageError
+	}
+	return ""
+}
+
 var File_proto_burnafter_proto protoreflect.FileDescriptor
 
 const file_proto_burnafter_proto_rawDesc = "" +
 	"\n" +
-	"\x15proto/burnafter.proto\x12\tburnafter\"\xbe\x01\n" +
+	"\x15proto/burnafter.proto\x12\tburnafter\"!\n" +
+	"\vStatRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\xe9\x01\n" +
+	"\fStatResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1f\n" +
+	"\vttl_seconds\x18\x03 \x01(\x03R\n" +
+	"ttlSeconds\x12.\n" +
+	"\x13absolute_expires_at\x18\x04 \x01(\x03R\x11absoluteExpiresAt\x12#\n" +
+	"\rlast_accessed\x18\x05 \x01(\x03R\flastAccessed\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x14\n" +
+	"\x05state\x18\a \x01(\tR\x05state\"\xbf\x01\n" +
+	"\rRotateRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\fR\x06secret\x12!\n" +
+	"\fclient_nonce\x18\x03 \x01(\tR\vclientNonce\x12\x1f\n" +
+	"\vttl_seconds\x18\x04 \x01(\x03R\n" +
+	"ttlSeconds\x12>\n" +
+	"\x1babsolute_expiration_seconds\x18\x05 \x01(\x03R\x19absoluteExpirationSeconds\"@\n" +
+	"\x0eRotateResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\r\n" +
+	"\vListRequest\"U\n" +
+	"\x0fSecretListEntry\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12\x18\n" +
+	"\abackend\x18\x03 \x01(\tR\abackend\"t\n" +
+	"\fListResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x124\n" +
+	"\asecrets\x18\x03 \x03(\v2\x1a.burnafter.SecretListEntryR\asecrets\"\xc0\x03\n" +
 	"\fStoreRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
-	"\x06secret\x18\x02 \x01(\tR\x06secret\x12\x1f\n" +
+	"\x06secret\x18\x02 \x01(\fR\x06secret\x12\x1f\n" +
 	"\vttl_seconds\x18\x03 \x01(\x03R\n" +
 	"ttlSeconds\x12!\n" +
 	"\fclient_nonce\x18\x04 \x01(\tR\vclientNonce\x12>\n" +
-	"\x1babsolute_expiration_seconds\x18\x05 \x01(\x03R\x19absoluteExpirationSeconds\"?\n" +
+	"\x1babsolute_expiration_seconds\x18\x05 \x01(\x03R\x19absoluteExpirationSeconds\x12;\n" +
+	"\x06labels\x18\x06 \x03(\v2#.burnafter.StoreRequest.LabelsEntryR\x06labels\x12#\n" +
+	"\rreturn_handle\x18\a \x01(\bR\freturnHandle\x12\x1d\n" +
+	"\n" +
+	"not_before\x18\b \x01(\x03R\tnotBefore\x12'\n" +
+	"\x0fecho_derivation\x18\t \x01(\bR\x0eechoDerivation\x12\x1b\n" +
+	"\tmax_reads\x18\n" +
+	" \x01(\x03R\bmaxReads\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x89\x01\n" +
+	"\x0eDerivationInfo\x12\x10\n" +
+	"\x03kdf\x18\x01 \x01(\tR\x03kdf\x12\x16\n" +
+	"\x06cipher\x18\x02 \x01(\tR\x06cipher\x12\x1f\n" +
+	"\vsalt_length\x18\x03 \x01(\x03R\n" +
+	"saltLength\x12,\n" +
+	"\x12client_binary_hash\x18\x04 \x01(\tR\x10clientBinaryHash\"#\n" +
+	"\rDeleteRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"u\n" +
+	"\x0eDeleteResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x123\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\x0e2\x14.burnafter.ErrorCodeR\terrorCode\"\xc0\x01\n" +
+	"\x12DeleteWhereRequest\x12A\n" +
+	"\x06labels\x18\x01 \x03(\v2).burnafter.DeleteWhereRequest.LabelsEntryR\x06labels\x12,\n" +
+	"\x12older_than_seconds\x18\x02 \x01(\x03R\x10olderThanSeconds\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"_\n" +
+	"\x13DeleteWhereResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x18\n" +
+	"\adeleted\x18\x03 \x01(\x03R\adeleted\"\xab\x02\n" +
 	"\rStoreResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"C\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x16\n" +
+	"\x06handle\x18\x03 \x01(\tR\x06handle\x129\n" +
+	"\n" +
+	"derivation\x18\x04 \x01(\v2\x19.burnafter.DerivationInfoR\n" +
+	"derivation\x122\n" +
+	"\x15effective_ttl_seconds\x18\x05 \x01(\x03R\x13effectiveTtlSeconds\x12.\n" +
+	"\x13absolute_expires_at\x18\x06 \x01(\x03R\x11absoluteExpiresAt\x123\n" +
+	"\n" +
+	"error_code\x18\a \x01(\x0e2\x14.burnafter.ErrorCodeR\terrorCode\"O\n" +
+	"\x12GetByHandleRequest\x12\x16\n" +
+	"\x06handle\x18\x01 \x01(\tR\x06handle\x12!\n" +
+	"\fclient_nonce\x18\x02 \x01(\tR\vclientNonce\"C\n" +
 	"\n" +
 	"GetRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
-	"\fclient_nonce\x18\x02 \x01(\tR\vclientNonce\"U\n" +
+	"\fclient_nonce\x18\x02 \x01(\tR\vclientNonce\"\xdb\x01\n" +
 	"\vGetResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x16\n" +
-	"\x06secret\x18\x02 \x01(\tR\x06secret\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error\"\r\n" +
-	"\vPingRequest\"$\n" +
+	"\x06secret\x18\x02 \x01(\fR\x06secret\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1f\n" +
+	"\vttl_seconds\x18\x04 \x01(\x03R\n" +
+	"ttlSeconds\x12.\n" +
+	"\x13absolute_expires_at\x18\x05 \x01(\x03R\x11absoluteExpiresAt\x123\n" +
+	"\n" +
+	"error_code\x18\x06 \x01(\x0e2\x14.burnafter.ErrorCodeR\terrorCode\"d\n" +
+	"\x12StoreStreamRequest\x12-\n" +
+	"\x04info\x18\x01 \x01(\v2\x17.burnafter.StoreRequestH\x00R\x04info\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\a\n" +
+	"\x05frame\"\xdf\x01\n" +
+	"\x11GetStreamResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x123\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\x0e2\x14.burnafter.ErrorCodeR\terrorCode\x12\x1f\n" +
+	"\vttl_seconds\x18\x04 \x01(\x03R\n" +
+	"ttlSeconds\x12.\n" +
+	"\x13absolute_expires_at\x18\x05 \x01(\x03R\x11absoluteExpiresAt\x12\x14\n" +
+	"\x05chunk\x18\x06 \x01(\fR\x05chunk\"#\n" +
+	"\rLocateRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"r\n" +
+	"\x0eLocateResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1a\n" +
+	"\blocation\x18\x03 \x01(\tR\blocation\x12\x14\n" +
+	"\x05state\x18\x04 \x01(\tR\x05state\"\xc9\x01\n" +
+	"\x14GetEncryptedResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12%\n" +
+	"\x0eencrypted_data\x18\x03 \x01(\fR\rencryptedData\x12\x12\n" +
+	"\x04salt\x18\x04 \x01(\fR\x04salt\x12,\n" +
+	"\x12client_binary_hash\x18\x05 \x01(\tR\x10clientBinaryHash\x12\x18\n" +
+	"\achunked\x18\x06 \x01(\bR\achunked\"7\n" +
+	"\x10GetConfigRequest\x12#\n" +
+	"\rinclude_stats\x18\x01 \x01(\bR\fincludeStats\"\x9d\x01\n" +
+	"\vSecretStats\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x03R\x05count\x12\x1f\n" +
+	"\vtotal_bytes\x18\x02 \x01(\x03R\n" +
+	"totalBytes\x12\x1b\n" +
+	"\tmin_bytes\x18\x03 \x01(\x03R\bminBytes\x12\x1b\n" +
+	"\tmax_bytes\x18\x04 \x01(\x03R\bmaxBytes\x12\x1d\n" +
+	"\n" +
+	"mean_bytes\x18\x05 \x01(\x01R\tmeanBytes\"\xfc\x02\n" +
+	"\x11GetConfigResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12.\n" +
+	"\x13default_ttl_seconds\x18\x03 \x01(\x03R\x11defaultTtlSeconds\x12\x1f\n" +
+	"\vmax_secrets\x18\x04 \x01(\x03R\n" +
+	"maxSecrets\x12&\n" +
+	"\x0fmax_secret_size\x18\x05 \x01(\x03R\rmaxSecretSize\x12<\n" +
+	"\x1ainactivity_timeout_seconds\x18\x06 \x01(\x03R\x18inactivityTimeoutSeconds\x12\x18\n" +
+	"\abackend\x18\a \x01(\tR\abackend\x128\n" +
+	"\x18cleanup_interval_seconds\x18\b \x01(\x03R\x16cleanupIntervalSeconds\x12,\n" +
+	"\x05stats\x18\t \x01(\v2\x16.burnafter.SecretStatsR\x05stats\"+\n" +
+	"\x0fSetDebugRequest\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\"B\n" +
+	"\x10SetDebugResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\x11\n" +
+	"\x0fShutdownRequest\"B\n" +
+	"\x10ShutdownResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"A\n" +
+	"\x11StoreAliasRequest\x12\x14\n" +
+	"\x05alias\x18\x01 \x01(\tR\x05alias\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\"D\n" +
+	"\x12StoreAliasResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"b\n" +
+	"\x15ExtendDeadlineRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x125\n" +
+	"\x17new_absolute_expires_at\x18\x02 \x01(\x03R\x14newAbsoluteExpiresAt\"u\n" +
+	"\x16ExtendDeadlineResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12+\n" +
+	"\x11lifetime_exceeded\x18\x03 \x01(\bR\x10lifetimeExceeded\"\x86\x01\n" +
+	"\rAppendRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12!\n" +
+	"\fclient_nonce\x18\x03 \x01(\tR\vclientNonce\x12*\n" +
+	"\x11create_if_missing\x18\x04 \x01(\bR\x0fcreateIfMissing\"@\n" +
+	"\x0eAppendResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"G\n" +
+	"\x0eReclaimRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fclient_nonce\x18\x02 \x01(\tR\vclientNonce\"A\n" +
+	"\x0fReclaimResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"2\n" +
+	"\vPingRequest\x12#\n" +
+	"\rcheck_storage\x18\x01 \x01(\bR\fcheckStorage\"r\n" +
 	"\fPingResponse\x12\x14\n" +
-	"\x05alive\x18\x01 \x01(\bR\x05alive2\xb6\x01\n" +
+	"\x05alive\x18\x01 \x01(\bR\x05alive\x12'\n" +
+	"\x0fstorage_healthy\x18\x02 \x01(\bR\x0estorageHealthy\x12#\n" +
+	"\rstorage_error\x18\x03 \x01(\tR\fstorageError*~\n" +
+	"\tErrorCode\x12\x15\n" +
+	"\x11ERROR_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fERROR_NOT_FOUND\x10\x01\x12\x11\n" +
+	"\rERROR_EXPIRED\x10\x02\x12\x19\n" +
+	"\x15ERROR_BINARY_MISMATCH\x10\x03\x12\x17\n" +
+	"\x13ERROR_NOT_YET_VALID\x10\x042\xc4\n" +
+	"\n" +
 	"\tBurnAfter\x12:\n" +
 	"\x05Store\x12\x17.burnafter.StoreRequest\x1a\x18.burnafter.StoreResponse\x124\n" +
 	"\x03Get\x12\x15.burnafter.GetRequest\x1a\x16.burnafter.GetResponse\x127\n" +
-	"\x04Ping\x12\x16.burnafter.PingRequest\x1a\x17.burnafter.PingResponseB4Z2github.com/carabiner-dev/burnafter/internal/commonb\x06proto3"
+	"\x04Ping\x12\x16.burnafter.PingRequest\x1a\x17.burnafter.PingResponse\x12=\n" +
+	"\x06Delete\x12\x18.burnafter.DeleteRequest\x1a\x19.burnafter.DeleteResponse\x12L\n" +
+	"\vDeleteWhere\x12\x1d.burnafter.DeleteWhereRequest\x1a\x1e.burnafter.DeleteWhereResponse\x12D\n" +
+	"\vGetByHandle\x12\x1d.burnafter.GetByHandleRequest\x1a\x16.burnafter.GetResponse\x12=\n" +
+	"\x06Locate\x12\x18.burnafter.LocateRequest\x1a\x19.burnafter.LocateResponse\x12F\n" +
+	"\fGetEncrypted\x12\x15.burnafter.GetRequest\x1a\x1f.burnafter.GetEncryptedResponse\x12F\n" +
+	"\tGetConfig\x12\x1b.burnafter.GetConfigRequest\x1a\x1c.burnafter.GetConfigResponse\x12C\n" +
+	"\bSetDebug\x12\x1a.burnafter.SetDebugRequest\x1a\x1b.burnafter.SetDebugResponse\x12I\n" +
+	"\n" +
+	"StoreAlias\x12\x1c.burnafter.StoreAliasRequest\x1a\x1d.burnafter.StoreAliasResponse\x12U\n" +
+	"\x0eExtendDeadline\x12 .burnafter.ExtendDeadlineRequest\x1a!.burnafter.ExtendDeadlineResponse\x12=\n" +
+	"\x06Append\x12\x18.burnafter.AppendRequest\x1a\x19.burnafter.AppendResponse\x12@\n" +
+	"\aReclaim\x12\x19.burnafter.ReclaimRequest\x1a\x1a.burnafter.ReclaimResponse\x127\n" +
+	"\x04List\x12\x16.burnafter.ListRequest\x1a\x17.burnafter.ListResponse\x12=\n" +
+	"\x06Rotate\x12\x18.burnafter.RotateRequest\x1a\x19.burnafter.RotateResponse\x127\n" +
+	"\x04Stat\x12\x16.burnafter.StatRequest\x1a\x17.burnafter.StatResponse\x12C\n" +
+	"\bShutdown\x12\x1a.burnafter.ShutdownRequest\x1a\x1b.burnafter.ShutdownResponse\x12H\n" +
+	"\vStoreStream\x12\x1d.burnafter.StoreStreamRequest\x1a\x18.burnafter.StoreResponse(\x01\x12B\n" +
+	"\tGetStream\x12\x15.burnafter.GetRequest\x1a\x1c.burnafter.GetStreamResponse0\x01B4Z2github.com/carabiner-dev/burnafter/internal/commonb\x06proto3"
 
 var (
 	file_proto_burnafter_proto_rawDescOnce sync.Once
@@ -400,27 +2932,108 @@ func file_proto_burnafter_proto_rawDescGZIP() []byte {
 	return file_proto_burnafter_proto_rawDescData
 }
 
-var file_proto_burnafter_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_burnafter_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_burnafter_proto_msgTypes = make([]protoimpl.MessageInfo, 41)
 var file_proto_burnafter_proto_goTypes = []any{
-	(*StoreRequest)(nil),  // 0: burnafter.StoreRequest
-	(*StoreResponse)(nil), // 1: burnafter.StoreResponse
-	(*GetRequest)(nil),    // 2: burnafter.GetRequest
-	(*GetResponse)(nil),   // 3: burnafter.GetResponse
-	(*PingRequest)(nil),   // 4: burnafter.PingRequest
-	(*PingResponse)(nil),  // 5: burnafter.PingResponse
+	(ErrorCode)(0),                 // 0: burnafter.ErrorCode
+	(*StatRequest)(nil),            // 1: burnafter.StatRequest
+	(*StatResponse)(nil),           // 2: burnafter.StatResponse
+	(*RotateRequest)(nil),          // 3: burnafter.RotateRequest
+	(*RotateResponse)(nil),         // 4: burnafter.RotateResponse
+	(*ListRequest)(nil),            // 5: burnafter.ListRequest
+	(*SecretListEntry)(nil),        // 6: burnafter.SecretListEntry
+	(*ListResponse)(nil),           // 7: burnafter.ListResponse
+	(*StoreRequest)(nil),           // 8: burnafter.StoreRequest
+	(*DerivationInfo)(nil),         // 9: burnafter.DerivationInfo
+	(*DeleteRequest)(nil),          // 10: burnafter.DeleteRequest
+	(*DeleteResponse)(nil),         // 11: burnafter.DeleteResponse
+	(*DeleteWhereRequest)(nil),     // 12: burnafter.DeleteWhereRequest
+	(*DeleteWhereResponse)(nil),    // 13: burnafter.DeleteWhereResponse
+	(*StoreResponse)(nil),          // 14: burnafter.StoreResponse
+	(*GetByHandleRequest)(nil),     // 15: burnafter.GetByHandleRequest
+	(*GetRequest)(nil),             // 16: burnafter.GetRequest
+	(*GetResponse)(nil),            // 17: burnafter.GetResponse
+	(*StoreStreamRequest)(nil),     // 18: burnafter.StoreStreamRequest
+	(*GetStreamResponse)(nil),      // 19: burnafter.GetStreamResponse
+	(*LocateRequest)(nil),          // 20: burnafter.LocateRequest
+	(*LocateResponse)(nil),         // 21: burnafter.LocateResponse
+	(*GetEncryptedResponse)(nil),   // 22: burnafter.GetEncryptedResponse
+	(*GetConfigRequest)(nil),       // 23: burnafter.GetConfigRequest
+	(*SecretStats)(nil),            // 24: burnafter.SecretStats
+	(*GetConfigResponse)(nil),      // 25: burnafter.GetConfigResponse
+	(*SetDebugRequest)(nil),        // 26: burnafter.SetDebugRequest
+	(*SetDebugResponse)(nil),       // 27: burnafter.SetDebugResponse
+	(*ShutdownRequest)(nil),        // 28: burnafter.ShutdownRequest
+	(*ShutdownResponse)(nil),       // 29: burnafter.ShutdownResponse
+	(*StoreAliasRequest)(nil),      // 30: burnafter.StoreAliasRequest
+	(*StoreAliasResponse)(nil),     // 31: burnafter.StoreAliasResponse
+	(*ExtendDeadlineRequest)(nil),  // 32: burnafter.ExtendDeadlineRequest
+	(*ExtendDeadlineResponse)(nil), // 33: burnafter.ExtendDeadlineResponse
+	(*AppendRequest)(nil),          // 34: burnafter.AppendRequest
+	(*AppendResponse)(nil),         // 35: burnafter.AppendResponse
+	(*ReclaimRequest)(nil),         // 36: burnafter.ReclaimRequest
+	(*ReclaimResponse)(nil),        // 37: burnafter.ReclaimResponse
+	(*PingRequest)(nil),            // 38: burnafter.PingRequest
+	(*PingResponse)(nil),           // 39: burnafter.PingResponse
+	nil,                            // 40: burnafter.StoreRequest.LabelsEntry
+	nil,                            // 41: burnafter.DeleteWhereRequest.LabelsEntry
 }
 var file_proto_burnafter_proto_depIdxs = []int32{
-	0, // 0: burnafter.BurnAfter.Store:input_type -> burnafter.StoreRequest
-	2, // 1: burnafter.BurnAfter.Get:input_type -> burnafter.GetRequest
-	4, // 2: burnafter.BurnAfter.Ping:input_type -> burnafter.PingRequest
-	1, // 3: burnafter.BurnAfter.Store:output_type -> burnafter.StoreResponse
-	3, // 4: burnafter.BurnAfter.Get:output_type -> burnafter.GetResponse
-	5, // 5: burnafter.BurnAfter.Ping:output_type -> burnafter.PingResponse
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	6,  // 0: burnafter.ListResponse.secrets:type_name -> burnafter.SecretListEntry
+	40, // 1: burnafter.StoreRequest.labels:type_name -> burnafter.StoreRequest.LabelsEntry
+	0,  // 2: burnafter.DeleteResponse.error_code:type_name -> burnafter.ErrorCode
+	41, // 3: burnafter.DeleteWhereRequest.labels:type_name -> burnafter.DeleteWhereRequest.LabelsEntry
+	9,  // 4: burnafter.StoreResponse.derivation:type_name -> burnafter.DerivationInfo
+	0,  // 5: burnafter.StoreResponse.error_code:type_name -> burnafter.ErrorCode
+	0,  // 6: burnafter.GetResponse.error_code:type_name -> burnafter.ErrorCode
+	8,  // 7: burnafter.StoreStreamRequest.info:type_name -> burnafter.StoreRequest
+	0,  // 8: burnafter.GetStreamResponse.error_code:type_name -> burnafter.ErrorCode
+	24, // 9: burnafter.GetConfigResponse.stats:type_name -> burnafter.SecretStats
+	8,  // 10: burnafter.BurnAfter.Store:input_type -> burnafter.StoreRequest
+	16, // 11: burnafter.BurnAfter.Get:input_type -> burnafter.GetRequest
+	38, // 12: burnafter.BurnAfter.Ping:input_type -> burnafter.PingRequest
+	10, // 13: burnafter.BurnAfter.Delete:input_type -> burnafter.DeleteRequest
+	12, // 14: burnafter.BurnAfter.DeleteWhere:input_type -> burnafter.DeleteWhereRequest
+	15, // 15: burnafter.BurnAfter.GetByHandle:input_type -> burnafter.GetByHandleRequest
+	20, // 16: burnafter.BurnAfter.Locate:input_type -> burnafter.LocateRequest
+	16, // 17: burnafter.BurnAfter.GetEncrypted:input_type -> burnafter.GetRequest
+	23, // 18: burnafter.BurnAfter.GetConfig:input_type -> burnafter.GetConfigRequest
+	26, // 19: burnafter.BurnAfter.SetDebug:input_type -> burnafter.SetDebugRequest
+	30, // 20: burnafter.BurnAfter.StoreAlias:input_type -> burnafter.StoreAliasRequest
+	32, // 21: burnafter.BurnAfter.ExtendDeadline:input_type -> burnafter.ExtendDeadlineRequest
+	34, // 22: burnafter.BurnAfter.Append:input_type -> burnafter.AppendRequest
+	36, // 23: burnafter.BurnAfter.Reclaim:input_type -> burnafter.ReclaimRequest
+	5,  // 24: burnafter.BurnAfter.List:input_type -> burnafter.ListRequest
+	3,  // 25: burnafter.BurnAfter.Rotate:input_type -> burnafter.RotateRequest
+	1,  // 26: burnafter.BurnAfter.Stat:input_type -> burnafter.StatRequest
+	28, // 27: burnafter.BurnAfter.Shutdown:input_type -> burnafter.ShutdownRequest
+	18, // 28: burnafter.BurnAfter.StoreStream:input_type -> burnafter.StoreStreamRequest
+	16, // 29: burnafter.BurnAfter.GetStream:input_type -> burnafter.GetRequest
+	14, // 30: burnafter.BurnAfter.Store:output_type -> burnafter.StoreResponse
+	17, // 31: burnafter.BurnAfter.Get:output_type -> burnafter.GetResponse
+	39, // 32: burnafter.BurnAfter.Ping:output_type -> burnafter.PingResponse
+	11, // 33: burnafter.BurnAfter.Delete:output_type -> burnafter.DeleteResponse
+	13, // 34: burnafter.BurnAfter.DeleteWhere:output_type -> burnafter.DeleteWhereResponse
+	17, // 35: burnafter.BurnAfter.GetByHandle:output_type -> burnafter.GetResponse
+	21, // 36: burnafter.BurnAfter.Locate:output_type -> burnafter.LocateResponse
+	22, // 37: burnafter.BurnAfter.GetEncrypted:output_type -> burnafter.GetEncryptedResponse
+	25, // 38: burnafter.BurnAfter.GetConfig:output_type -> burnafter.GetConfigResponse
+	27, // 39: burnafter.BurnAfter.SetDebug:output_type -> burnafter.SetDebugResponse
+	31, // 40: burnafter.BurnAfter.StoreAlias:output_type -> burnafter.StoreAliasResponse
+	33, // 41: burnafter.BurnAfter.ExtendDeadline:output_type -> burnafter.ExtendDeadlineResponse
+	35, // 42: burnafter.BurnAfter.Append:output_type -> burnafter.AppendResponse
+	37, // 43: burnafter.BurnAfter.Reclaim:output_type -> burnafter.ReclaimResponse
+	7,  // 44: burnafter.BurnAfter.List:output_type -> burnafter.ListResponse
+	4,  // 45: burnafter.BurnAfter.Rotate:output_type -> burnafter.RotateResponse
+	2,  // 46: burnafter.BurnAfter.Stat:output_type -> burnafter.StatResponse
+	29, // 47: burnafter.BurnAfter.Shutdown:output_type -> burnafter.ShutdownResponse
+	14, // 48: burnafter.BurnAfter.StoreStream:output_type -> burnafter.StoreResponse
+	19, // 49: burnafter.BurnAfter.GetStream:output_type -> burnafter.GetStreamResponse
+	30, // [30:50] is the sub-list for method output_type
+	10, // [10:30] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_proto_burnafter_proto_init() }
@@ -428,18 +3041,23 @@ func file_proto_burnafter_proto_init() {
 	if File_proto_burnafter_proto != nil {
 		return
 	}
+	file_proto_burnafter_proto_msgTypes[17].OneofWrappers = []any{
+		(*StoreStreamRequest_Info)(nil),
+		(*StoreStreamRequest_Chunk)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_burnafter_proto_rawDesc), len(file_proto_burnafter_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      1,
+			NumMessages:   41,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_burnafter_proto_goTypes,
 		DependencyIndexes: file_proto_burnafter_proto_depIdxs,
+		EnumInfos:         file_proto_burnafter_proto_enumTypes,
 		MessageInfos:      file_proto_burnafter_proto_msgTypes,
 	}.Build()
 	File_proto_burnafter_proto = out.File
@@ -1,6 +1,9 @@
 // SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
 // SPDX-License-Identifier: Apache-2.0
 
+// SPDX-FileCopyrightText: Copyright 2025 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.5.1
@@ -22,9 +25,26 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	BurnAfter_Store_FullMethodName = "/burnafter.BurnAfter/Store"
-	BurnAfter_Get_FullMethodName   = "/burnafter.BurnAfter/Get"
-	BurnAfter_Ping_FullMethodName  = "/burnafter.BurnAfter/Ping"
+	BurnAfter_Store_FullMethodName          = "/burnafter.BurnAfter/Store"
+	BurnAfter_Get_FullMethodName            = "/burnafter.BurnAfter/Get"
+	BurnAfter_Ping_FullMethodName           = "/burnafter.BurnAfter/Ping"
+	BurnAfter_Delete_FullMethodName         = "/burnafter.BurnAfter/Delete"
+	BurnAfter_DeleteWhere_FullMethodName    = "/burnafter.BurnAfter/DeleteWhere"
+	BurnAfter_GetByHandle_FullMethodName    = "/burnafter.BurnAfter/GetByHandle"
+	BurnAfter_Locate_FullMethodName         = "/burnafter.BurnAfter/Locate"
+	BurnAfter_GetEncrypted_FullMethodName   = "/burnafter.BurnAfter/GetEncrypted"
+	BurnAfter_GetConfig_FullMethodName      = "/burnafter.BurnAfter/GetConfig"
+	BurnAfter_SetDebug_FullMethodName       = "/burnafter.BurnAfter/SetDebug"
+	BurnAfter_StoreAlias_FullMethodName     = "/burnafter.BurnAfter/StoreAlias"
+	BurnAfter_ExtendDeadline_FullMethodName = "/burnafter.BurnAfter/ExtendDeadline"
+	BurnAfter_Append_FullMethodName         = "/burnafter.BurnAfter/Append"
+	BurnAfter_Reclaim_FullMethodName        = "/burnafter.BurnAfter/Reclaim"
+	BurnAfter_List_FullMethodName           = "/burnafter.BurnAfter/List"
+	BurnAfter_Rotate_FullMethodName         = "/burnafter.BurnAfter/Rotate"
+	BurnAfter_Stat_FullMethodName           = "/burnafter.BurnAfter/Stat"
+	BurnAfter_Shutdown_FullMethodName       = "/burnafter.BurnAfter/Shutdown"
+	BurnAfter_StoreStream_FullMethodName    = "/burnafter.BurnAfter/StoreStream"
+	BurnAfter_GetStream_FullMethodName      = "/burnafter.BurnAfter/GetStream"
 )
 
 // BurnAfterClient is the client API for BurnAfter service.
@@ -34,6 +54,23 @@ type BurnAfterClient interface {
 	Store(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*StoreResponse, error)
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
 	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	DeleteWhere(ctx context.Context, in *DeleteWhereRequest, opts ...grpc.CallOption) (*DeleteWhereResponse, error)
+	GetByHandle(ctx context.Context, in *GetByHandleRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	Locate(ctx context.Context, in *LocateRequest, opts ...grpc.CallOption) (*LocateResponse, error)
+	GetEncrypted(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetEncryptedResponse, error)
+	GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error)
+	SetDebug(ctx context.Context, in *SetDebugRequest, opts ...grpc.CallOption) (*SetDebugResponse, error)
+	StoreAlias(ctx context.Context, in *StoreAliasRequest, opts ...grpc.CallOption) (*StoreAliasResponse, error)
+	ExtendDeadline(ctx context.Context, in *ExtendDeadlineRequest, opts ...grpc.CallOption) (*ExtendDeadlineResponse, error)
+	Append(ctx context.Context, in *AppendRequest, opts ...grpc.CallOption) (*AppendResponse, error)
+	Reclaim(ctx context.Context, in *ReclaimRequest, opts ...grpc.CallOption) (*ReclaimResponse, error)
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	Rotate(ctx context.Context, in *RotateRequest, opts ...grpc.CallOption) (*RotateResponse, error)
+	Stat(ctx context.Context, in *StatRequest, opts ...grpc.CallOption) (*StatResponse, error)
+	Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error)
+	StoreStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[StoreStreamRequest, StoreResponse], error)
+	GetStream(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetStreamResponse], error)
 }
 
 type burnAfterClient struct {
@@ -74,6 +111,188 @@ func (c *burnAfterClient) Ping(ctx context.Context, in *PingRequest, opts ...grp
 	return out, nil
 }
 
+func (c *burnAfterClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, BurnAfter_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnAfterClient) DeleteWhere(ctx context.Context, in *DeleteWhereRequest, opts ...grpc.CallOption) (*DeleteWhereResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteWhereResponse)
+	err := c.cc.Invoke(ctx, BurnAfter_DeleteWhere_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnAfterClient) GetByHandle(ctx context.Context, in *GetByHandleRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetResponse)
+	err := c.cc.Invoke(ctx, BurnAfter_GetByHandle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnAfterClient) Locate(ctx context.Context, in *LocateRequest, opts ...grpc.CallOption) (*LocateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LocateResponse)
+	err := c.cc.Invoke(ctx, BurnAfter_Locate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnAfterClient) GetEncrypted(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetEncryptedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEncryptedResponse)
+	err := c.cc.Invoke(ctx, BurnAfter_GetEncrypted_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnAfterClient) GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConfigResponse)
+	err := c.cc.Invoke(ctx, BurnAfter_GetConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnAfterClient) SetDebug(ctx context.Context, in *SetDebugRequest, opts ...grpc.CallOption) (*SetDebugResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetDebugResponse)
+	err := c.cc.Invoke(ctx, BurnAfter_SetDebug_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnAfterClient) StoreAlias(ctx context.Context, in *StoreAliasRequest, opts ...grpc.CallOption) (*StoreAliasResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StoreAliasResponse)
+	err := c.cc.Invoke(ctx, BurnAfter_StoreAlias_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnAfterClient) ExtendDeadline(ctx context.Context, in *ExtendDeadlineRequest, opts ...grpc.CallOption) (*ExtendDeadlineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExtendDeadlineResponse)
+	err := c.cc.Invoke(ctx, BurnAfter_ExtendDeadline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnAfterClient) Append(ctx context.Context, in *AppendRequest, opts ...grpc.CallOption) (*AppendResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AppendResponse)
+	err := c.cc.Invoke(ctx, BurnAfter_Append_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnAfterClient) Reclaim(ctx context.Context, in *ReclaimRequest, opts ...grpc.CallOption) (*ReclaimResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReclaimResponse)
+	err := c.cc.Invoke(ctx, BurnAfter_Reclaim_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnAfterClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, BurnAfter_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnAfterClient) Rotate(ctx context.Context, in *RotateRequest, opts ...grpc.CallOption) (*RotateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RotateResponse)
+	err := c.cc.Invoke(ctx, BurnAfter_Rotate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnAfterClient) Stat(ctx context.Context, in *StatRequest, opts ...grpc.CallOption) (*StatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatResponse)
+	err := c.cc.Invoke(ctx, BurnAfter_Stat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnAfterClient) Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShutdownResponse)
+	err := c.cc.Invoke(ctx, BurnAfter_Shutdown_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnAfterClient) StoreStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[StoreStreamRequest, StoreResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BurnAfter_ServiceDesc.Streams[0], BurnAfter_StoreStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StoreStreamRequest, StoreResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BurnAfter_StoreStreamClient = grpc.ClientStreamingClient[StoreStreamRequest, StoreResponse]
+
+func (c *burnAfterClient) GetStream(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BurnAfter_ServiceDesc.Streams[1], BurnAfter_GetStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetRequest, GetStreamResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BurnAfter_GetStreamClient = grpc.ServerStreamingClient[GetStreamResponse]
+
 // BurnAfterServer is the server API for BurnAfter service.
 // All implementations must embed UnimplementedBurnAfterServer
 // for forward compatibility.
@@ -81,6 +300,23 @@ type BurnAfterServer interface {
 	Store(context.Context, *StoreRequest) (*StoreResponse, error)
 	Get(context.Context, *GetRequest) (*GetResponse, error)
 	Ping(context.Context, *PingRequest) (*PingResponse, error)
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	DeleteWhere(context.Context, *DeleteWhereRequest) (*DeleteWhereResponse, error)
+	GetByHandle(context.Context, *GetByHandleRequest) (*GetResponse, error)
+	Locate(context.Context, *LocateRequest) (*LocateResponse, error)
+	GetEncrypted(context.Context, *GetRequest) (*GetEncryptedResponse, error)
+	GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error)
+	SetDebug(context.Context, *SetDebugRequest) (*SetDebugResponse, error)
+	StoreAlias(context.Context, *StoreAliasRequest) (*StoreAliasResponse, error)
+	ExtendDeadline(context.Context, *ExtendDeadlineRequest) (*ExtendDeadlineResponse, error)
+	Append(context.Context, *AppendRequest) (*AppendResponse, error)
+	Reclaim(context.Context, *ReclaimRequest) (*ReclaimResponse, error)
+	List(context.Context, *ListRequest) (*ListResponse, error)
+	Rotate(context.Context, *RotateRequest) (*RotateResponse, error)
+	Stat(context.Context, *StatRequest) (*StatResponse, error)
+	Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error)
+	StoreStream(grpc.ClientStreamingServer[StoreStreamRequest, StoreResponse]) error
+	GetStream(*GetRequest, grpc.ServerStreamingServer[GetStreamResponse]) error
 	mustEmbedUnimplementedBurnAfterServer()
 }
 
@@ -100,6 +336,57 @@ func (UnimplementedBurnAfterServer) Get(context.Context, *GetRequest) (*GetRespo
 func (UnimplementedBurnAfterServer) Ping(context.Context, *PingRequest) (*PingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
 }
+func (UnimplementedBurnAfterServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedBurnAfterServer) DeleteWhere(context.Context, *DeleteWhereRequest) (*DeleteWhereResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWhere not implemented")
+}
+func (UnimplementedBurnAfterServer) GetByHandle(context.Context, *GetByHandleRequest) (*GetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetByHandle not implemented")
+}
+func (UnimplementedBurnAfterServer) Locate(context.Context, *LocateRequest) (*LocateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Locate not implemented")
+}
+func (UnimplementedBurnAfterServer) GetEncrypted(context.Context, *GetRequest) (*GetEncryptedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEncrypted not implemented")
+}
+func (UnimplementedBurnAfterServer) GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
+}
+func (UnimplementedBurnAfterServer) SetDebug(context.Context, *SetDebugRequest) (*SetDebugResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDebug not implemented")
+}
+func (UnimplementedBurnAfterServer) StoreAlias(context.Context, *StoreAliasRequest) (*StoreAliasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StoreAlias not implemented")
+}
+func (UnimplementedBurnAfterServer) ExtendDeadline(context.Context, *ExtendDeadlineRequest) (*ExtendDeadlineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExtendDeadline not implemented")
+}
+func (UnimplementedBurnAfterServer) Append(context.Context, *AppendRequest) (*AppendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Append not implemented")
+}
+func (UnimplementedBurnAfterServer) Reclaim(context.Context, *ReclaimRequest) (*ReclaimResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reclaim not implemented")
+}
+func (UnimplementedBurnAfterServer) List(context.Context, *ListRequest) (*ListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedBurnAfterServer) Rotate(context.Context, *RotateRequest) (*RotateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rotate not implemented")
+}
+func (UnimplementedBurnAfterServer) Stat(context.Context, *StatRequest) (*StatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stat not implemented")
+}
+func (UnimplementedBurnAfterServer) Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Shutdown not implemented")
+}
+func (UnimplementedBurnAfterServer) StoreStream(grpc.ClientStreamingServer[StoreStreamRequest, StoreResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StoreStream not implemented")
+}
+func (UnimplementedBurnAfterServer) GetStream(*GetRequest, grpc.ServerStreamingServer[GetStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method GetStream not implemented")
+}
 func (UnimplementedBurnAfterServer) mustEmbedUnimplementedBurnAfterServer() {}
 func (UnimplementedBurnAfterServer) testEmbeddedByValue()                   {}
 
@@ -175,6 +462,294 @@ func _BurnAfter_Ping_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BurnAfter_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnAfterServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnAfter_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnAfterServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnAfter_DeleteWhere_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWhereRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnAfterServer).DeleteWhere(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnAfter_DeleteWhere_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnAfterServer).DeleteWhere(ctx, req.(*DeleteWhereRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnAfter_GetByHandle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByHandleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnAfterServer).GetByHandle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnAfter_GetByHandle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnAfterServer).GetByHandle(ctx, req.(*GetByHandleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnAfter_Locate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LocateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnAfterServer).Locate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnAfter_Locate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnAfterServer).Locate(ctx, req.(*LocateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnAfter_GetEncrypted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnAfterServer).GetEncrypted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnAfter_GetEncrypted_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnAfterServer).GetEncrypted(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnAfter_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnAfterServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnAfter_GetConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnAfterServer).GetConfig(ctx, req.(*GetConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnAfter_SetDebug_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDebugRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnAfterServer).SetDebug(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnAfter_SetDebug_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnAfterServer).SetDebug(ctx, req.(*SetDebugRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnAfter_StoreAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreAliasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnAfterServer).StoreAlias(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnAfter_StoreAlias_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnAfterServer).StoreAlias(ctx, req.(*StoreAliasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnAfter_ExtendDeadline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtendDeadlineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnAfterServer).ExtendDeadline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnAfter_ExtendDeadline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnAfterServer).ExtendDeadline(ctx, req.(*ExtendDeadlineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnAfter_Append_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnAfterServer).Append(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnAfter_Append_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnAfterServer).Append(ctx, req.(*AppendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnAfter_Reclaim_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReclaimRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnAfterServer).Reclaim(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnAfter_Reclaim_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnAfterServer).Reclaim(ctx, req.(*ReclaimRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnAfter_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnAfterServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnAfter_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnAfterServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnAfter_Rotate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnAfterServer).Rotate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnAfter_Rotate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnAfterServer).Rotate(ctx, req.(*RotateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnAfter_Stat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnAfterServer).Stat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnAfter_Stat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnAfterServer).Stat(ctx, req.(*StatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnAfter_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShutdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnAfterServer).Shutdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnAfter_Shutdown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnAfterServer).Shutdown(ctx, req.(*ShutdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnAfter_StoreStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BurnAfterServer).StoreStream(&grpc.GenericServerStream[StoreStreamRequest, StoreResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BurnAfter_StoreStreamServer = grpc.ClientStreamingServer[StoreStreamRequest, StoreResponse]
+
+func _BurnAfter_GetStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BurnAfterServer).GetStream(m, &grpc.GenericServerStream[GetRequest, GetStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BurnAfter_GetStreamServer = grpc.ServerStreamingServer[GetStreamResponse]
+
 // BurnAfter_ServiceDesc is the grpc.ServiceDesc for BurnAfter service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -194,7 +769,78 @@ var BurnAfter_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Ping",
 			Handler:    _BurnAfter_Ping_Handler,
 		},
+		{
+			MethodName: "Delete",
+			Handler:    _BurnAfter_Delete_Handler,
+		},
+		{
+			MethodName: "DeleteWhere",
+			Handler:    _BurnAfter_DeleteWhere_Handler,
+		},
+		{
+			MethodName: "GetByHandle",
+			Handler:    _BurnAfter_GetByHandle_Handler,
+		},
+		{
+			MethodName: "Locate",
+			Handler:    _BurnAfter_Locate_Handler,
+		},
+		{
+			MethodName: "GetEncrypted",
+			Handler:    _BurnAfter_GetEncrypted_Handler,
+		},
+		{
+			MethodName: "GetConfig",
+			Handler:    _BurnAfter_GetConfig_Handler,
+		},
+		{
+			MethodName: "SetDebug",
+			Handler:    _BurnAfter_SetDebug_Handler,
+		},
+		{
+			MethodName: "StoreAlias",
+			Handler:    _BurnAfter_StoreAlias_Handler,
+		},
+		{
+			MethodName: "ExtendDeadline",
+			Handler:    _BurnAfter_ExtendDeadline_Handler,
+		},
+		{
+			MethodName: "Append",
+			Handler:    _BurnAfter_Append_Handler,
+		},
+		{
+			MethodName: "Reclaim",
+			Handler:    _BurnAfter_Reclaim_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _BurnAfter_List_Handler,
+		},
+		{
+			MethodName: "Rotate",
+			Handler:    _BurnAfter_Rotate_Handler,
+		},
+		{
+			MethodName: "Stat",
+			Handler:    _BurnAfter_Stat_Handler,
+		},
+		{
+			MethodName: "Shutdown",
+			Handler:    _BurnAfter_Shutdown_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StoreStream",
+			Handler:       _BurnAfter_StoreStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetStream",
+			Handler:       _BurnAfter_GetStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/burnafter.proto",
 }
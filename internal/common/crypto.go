@@ -39,10 +39,21 @@ func DeriveKey(
 	return key, nil
 }
 
+// RandReader is the source of cryptographic randomness for salts, session IDs
+// and encryption nonces. It defaults to crypto/rand.Reader; tests substitute a
+// deterministic reader to reproduce ciphertexts and assert exact byte layouts.
+var RandReader io.Reader = rand.Reader
+
 // GenerateSalt creates a random salt used when deriving the key
 func GenerateSalt() ([]byte, error) {
+	return GenerateSaltFrom(RandReader)
+}
+
+// GenerateSaltFrom creates a random salt reading from r, for callers that
+// carry their own randomness source (see Deps).
+func GenerateSaltFrom(r io.Reader) ([]byte, error) {
 	salt := make([]byte, 32)
-	if _, err := rand.Read(salt); err != nil {
+	if _, err := io.ReadFull(r, salt); err != nil {
 		return nil, fmt.Errorf("failed to generate salt: %w", err)
 	}
 	return salt, nil
@@ -54,14 +65,23 @@ func GenerateSalt() ([]byte, error) {
 // secrets inaccesible.
 func GenerateSessionID() (string, error) {
 	sessionBytes := make([]byte, 32)
-	if _, err := rand.Read(sessionBytes); err != nil {
+	if _, err := io.ReadFull(RandReader, sessionBytes); err != nil {
 		return "", fmt.Errorf("failed to generate session ID: %w", err)
 	}
 	return hex.EncodeToString(sessionBytes), nil
 }
 
 // Encrypt encrypts a plaintext string using AES-256-GCM with the provided key.
+// The string conversion leaves an unwipeable copy behind; callers holding the
+// plaintext as bytes they intend to wipe use EncryptBytes instead.
 func Encrypt(plaintext string, key []byte) ([]byte, error) {
+	return EncryptBytes([]byte(plaintext), key)
+}
+
+// EncryptBytes encrypts a plaintext byte slice using AES-256-GCM with the
+// provided key. The caller keeps ownership of the plaintext and may wipe it
+// once the call returns.
+func EncryptBytes(plaintext, key []byte) ([]byte, error) {
 	block, err := aes.NewCipher(key)
 	if err != nil {
 		return nil, fmt.Errorf("creating cipher: %w", err)
@@ -74,39 +94,54 @@ func Encrypt(plaintext string, key []byte) ([]byte, error) {
 
 	// nonce for the encryption (not the client's)
 	nonce := make([]byte, gcm.NonceSize())
-	if _, err := rand.Read(nonce); err != nil {
+	if _, err := io.ReadFull(RandReader, nonce); err != nil {
 		return nil, fmt.Errorf("generating nonce: %w", err)
 	}
 
 	// Encrypt the secret
-	ciphertext := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	ciphertext := gcm.Seal(nonce, nonce, plaintext, nil)
 	return ciphertext, nil
 }
 
-// Decrypt decrypts ciphertext using AES-256-GCM with the provided key
+// Decrypt decrypts ciphertext using AES-256-GCM with the provided key. The
+// returned string cannot be wiped; callers that want control over the
+// plaintext's lifetime use DecryptBytes instead.
 func Decrypt(ciphertext, key []byte) (string, error) {
+	plaintext, err := DecryptBytes(ciphertext, key)
+	if err != nil {
+		return "", err
+	}
+	// The string conversion copies, so the intermediate buffer can go
+	defer ZeroBytes(plaintext)
+	return string(plaintext), nil
+}
+
+// DecryptBytes decrypts ciphertext using AES-256-GCM with the provided key,
+// returning the plaintext as a byte slice the caller owns and should zero
+// once the secret has been used.
+func DecryptBytes(ciphertext, key []byte) ([]byte, error) {
 	block, err := aes.NewCipher(key)
 	if err != nil {
-		return "", fmt.Errorf("creating cipher: %w", err)
+		return nil, fmt.Errorf("creating cipher: %w", err)
 	}
 
 	gcm, err := cipher.NewGCM(block)
 	if err != nil {
-		return "", fmt.Errorf("creating GCM: %w", err)
+		return nil, fmt.Errorf("creating GCM: %w", err)
 	}
 
 	nonceSize := gcm.NonceSize()
 	if len(ciphertext) < nonceSize {
-		return "", fmt.Errorf("ciphertext too short")
+		return nil, fmt.Errorf("ciphertext too short")
 	}
 
 	nonce, ciphertext := ciphertext[:nonceSize], ciphertext[nonceSize:]
 	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
 	if err != nil {
-		return "", fmt.Errorf("decrypting secret: %w", err)
+		return nil, fmt.Errorf("decrypting secret: %w", err)
 	}
 
-	return string(plaintext), nil
+	return plaintext, nil
 }
 
 // ZeroBytes securely zeros out a byte slice to wipe out the secrets
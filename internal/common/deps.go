@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"io"
+	"time"
+)
+
+// Deps bundles the environmental dependencies the client and server keep
+// behind seams: the clock and the source of randomness. The zero value (and a
+// nil pointer) means the real implementations, so production code never
+// constructs one. Tests inject a fixed clock to drive expiry without sleeping
+// and a deterministic reader to pin down exact byte layouts.
+type Deps struct {
+	// Clock reports the current time; nil means time.Now.
+	Clock func() time.Time
+
+	// Rand is the source of cryptographic randomness; nil means RandReader
+	// (crypto/rand.Reader unless substituted).
+	Rand io.Reader
+}
+
+// Now returns the current time according to the injected clock, falling back
+// to the real one. Safe to call on a nil Deps.
+func (d *Deps) Now() time.Time {
+	if d != nil && d.Clock != nil {
+		return d.Clock()
+	}
+	return time.Now()
+}
+
+// Reader returns the injected randomness source, falling back to RandReader.
+// Safe to call on a nil Deps.
+func (d *Deps) Reader() io.Reader {
+	if d != nil && d.Rand != nil {
+		return d.Rand
+	}
+	return RandReader
+}
@@ -0,0 +1,17 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+// grpcMessageOverhead is headroom added on top of the secret size limit when
+// sizing gRPC messages, covering the request's other fields (name, nonce,
+// labels) and the protobuf framing itself.
+const grpcMessageOverhead = 64 * 1024
+
+// MaxMessageSize returns the gRPC message size limit matching a configured
+// maximum secret size, so transfers up to the application-level limit are
+// never cut off at the transport layer (whose default 4MB would silently
+// override a larger MaxSecretSize with a confusing transport error).
+func MaxMessageSize(maxSecretSize int64) int {
+	return int(maxSecretSize) + grpcMessageOverhead
+}
@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Chunked payloads frame a secret as a sequence of independently sealed
+// AES-256-GCM records, so neither side ever has to hold the whole plaintext
+// to encrypt or decrypt it. Each frame is laid out as:
+//
+//	[4-byte big-endian record length][1-byte final flag][nonce][ciphertext+tag]
+//
+// where the record length covers the flag, the nonce and the ciphertext. The
+// chunk's index and its final flag are bound into the GCM additional data, so
+// a reordered, duplicated or truncated sequence of frames fails to open even
+// though every individual frame is authentic.
+
+// StreamChunkSize is the plaintext chunk size the streaming paths slice
+// secrets into. Frames on the wire and in storage stay near this size, which
+// bounds the memory both sides need per chunk.
+const StreamChunkSize = 64 * 1024
+
+// chunkAAD builds the additional data binding a chunk to its position in the
+// sequence and marking whether it is the last one.
+func chunkAAD(index uint64, final bool) []byte {
+	aad := make([]byte, 9)
+	binary.BigEndian.PutUint64(aad, index)
+	if final {
+		aad[8] = 1
+	}
+	return aad
+}
+
+// newChunkAEAD builds the AES-256-GCM sealer/opener the chunk frames share.
+func newChunkAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("creating GCM: %w", err)
+	}
+	return gcm, nil
+}
+
+// ChunkedEncrypter seals successive plaintext chunks into framed records
+// under one key. Chunks must be sealed in order and the last one flagged
+// final; the resulting frames concatenated form the chunked payload.
+type ChunkedEncrypter struct {
+	gcm    cipher.AEAD
+	index  uint64
+	sealed bool
+}
+
+// NewChunkedEncrypter creates an encrypter sealing chunks with the given
+// AES-256 key.
+func NewChunkedEncrypter(key []byte) (*ChunkedEncrypter, error) {
+	gcm, err := newChunkAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &ChunkedEncrypter{gcm: gcm}, nil
+}
+
+// Seal encrypts one chunk into a framed record. The caller keeps ownership of
+// the plaintext chunk and may wipe it once Seal returns.
+func (e *ChunkedEncrypter) Seal(chunk []byte, final bool) ([]byte, error) {
+	if e.sealed {
+		return nil, fmt.Errorf("chunk sealed after the final one")
+	}
+	nonce := make([]byte, e.gcm.NonceSize())
+	if _, err := io.ReadFull(RandReader, nonce); err != nil {
+		return nil, fmt.Errorf("generating nonce: %w", err)
+	}
+
+	sealed := e.gcm.Seal(nil, nonce, chunk, chunkAAD(e.index, final))
+	e.index++
+	e.sealed = final
+
+	record := make([]byte, 0, 4+1+len(nonce)+len(sealed))
+	record = binary.BigEndian.AppendUint32(record, uint32(1+len(nonce)+len(sealed))) //nolint:gosec // bounded by the chunk size
+	if final {
+		record = append(record, 1)
+	} else {
+		record = append(record, 0)
+	}
+	record = append(record, nonce...)
+	record = append(record, sealed...)
+	return record, nil
+}
+
+// ChunkedDecrypter opens the framed records of a chunked payload in order.
+type ChunkedDecrypter struct {
+	gcm   cipher.AEAD
+	rest  []byte
+	index uint64
+	done  bool
+}
+
+// NewChunkedDecrypter creates a decrypter over a complete chunked payload.
+func NewChunkedDecrypter(ciphertext, key []byte) (*ChunkedDecrypter, error) {
+	gcm, err := newChunkAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &ChunkedDecrypter{gcm: gcm, rest: ciphertext}, nil
+}
+
+// Next opens and returns the next plaintext chunk, which the caller owns and
+// should wipe once used. After the final chunk it returns io.EOF; a payload
+// that runs out of data without a final chunk is reported as truncated.
+func (d *ChunkedDecrypter) Next() ([]byte, error) {
+	if d.done {
+		return nil, io.EOF
+	}
+	if len(d.rest) == 0 {
+		return nil, fmt.Errorf("chunked payload truncated: no final chunk")
+	}
+	if len(d.rest) < 4 {
+		return nil, fmt.Errorf("chunked payload corrupt: short frame header")
+	}
+	length := binary.BigEndian.Uint32(d.rest)
+	nonceSize := d.gcm.NonceSize()
+	if int(length) < 1+nonceSize || len(d.rest) < 4+int(length) {
+		return nil, fmt.Errorf("chunked payload corrupt: bad frame length")
+	}
+	record := d.rest[4 : 4+length]
+	d.rest = d.rest[4+length:]
+
+	final := record[0] == 1
+	nonce, sealed := record[1:1+nonceSize], record[1+nonceSize:]
+	chunk, err := d.gcm.Open(nil, nonce, sealed, chunkAAD(d.index, final))
+	if err != nil {
+		return nil, fmt.Errorf("decrypting chunk %d: %w", d.index, err)
+	}
+	d.index++
+	if final {
+		if len(d.rest) != 0 {
+			ZeroBytes(chunk)
+			return nil, fmt.Errorf("chunked payload corrupt: data after the final chunk")
+		}
+		d.done = true
+	}
+	return chunk, nil
+}
+
+// DecryptChunkedBytes reassembles a whole chunked payload into one plaintext
+// slice, for the unary paths that need the complete secret anyway. The caller
+// owns the result and should zero it once used.
+func DecryptChunkedBytes(ciphertext, key []byte) ([]byte, error) {
+	decrypter, err := NewChunkedDecrypter(ciphertext, key)
+	if err != nil {
+		return nil, err
+	}
+	var plaintext []byte
+	for {
+		chunk, err := decrypter.Next()
+		if err == io.EOF {
+			return plaintext, nil
+		}
+		if err != nil {
+			ZeroBytes(plaintext)
+			return nil, err
+		}
+		plaintext = append(plaintext, chunk...)
+		ZeroBytes(chunk)
+	}
+}
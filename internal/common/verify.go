@@ -5,22 +5,54 @@ package common
 
 import (
 	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"os"
 	"path/filepath"
+
+	"github.com/zeebo/blake3"
+)
+
+// Supported binary fingerprinting hash algorithms. SHA-256 is the default;
+// SHA-512 aligns with provenance ecosystems that publish sha512 digests, and
+// BLAKE3 trades algorithm agility for speed when hashing large binaries on
+// every request is the bottleneck. The algorithm is a key-derivation input,
+// so changing it invalidates all existing secrets.
+const (
+	HashAlgorithmSHA256 = "sha256"
+	HashAlgorithmSHA512 = "sha512"
+	HashAlgorithmBLAKE3 = "blake3"
 )
 
+// ErrPeerPIDUnavailable is returned when a peer's process ID cannot be
+// determined. Some kernels and namespace configurations report a PID of 0
+// over SO_PEERCRED (e.g. when the peer lives in a different PID namespace),
+// in which case there is no process to resolve a binary from.
+var ErrPeerPIDUnavailable = errors.New("peer process ID unavailable")
+
 // GetClientBinaryInfo extracts the binary path and hash from the client's PID
 func GetClientBinaryInfo(pid int32) (binaryPath, binaryHash string, err error) {
+	return GetClientBinaryInfoWith(HashAlgorithmSHA256, pid)
+}
+
+// GetClientBinaryInfoWith is GetClientBinaryInfo hashing with an explicit
+// algorithm instead of the SHA-256 default.
+func GetClientBinaryInfoWith(algorithm string, pid int32) (binaryPath, binaryHash string, err error) {
+	if pid <= 0 {
+		return "", "", fmt.Errorf("cannot resolve binary of pid %d: %w", pid, ErrPeerPIDUnavailable)
+	}
+
 	binaryPath, err = getBinaryPath(pid)
 	if err != nil {
 		return "", "", fmt.Errorf("reading binary path %q: %w", binaryPath, err)
 	}
 
-	// Compute SHA256 hash of the binary
-	binaryHash, err = HashFile(binaryPath)
+	// Compute the hash of the binary
+	binaryHash, err = HashFileWith(algorithm, binaryPath)
 	if err != nil {
 		return "", "", fmt.Errorf("hashing client binary: %w", err)
 	}
@@ -28,15 +60,40 @@ func GetClientBinaryInfo(pid int32) (binaryPath, binaryHash string, err error) {
 	return binaryPath, binaryHash, nil
 }
 
+// newBinaryHasher returns a fresh hasher for a supported fingerprinting
+// algorithm. An empty algorithm selects the SHA-256 default.
+func newBinaryHasher(algorithm string) (hash.Hash, error) {
+	switch algorithm {
+	case "", HashAlgorithmSHA256:
+		return sha256.New(), nil
+	case HashAlgorithmSHA512:
+		return sha512.New(), nil
+	case HashAlgorithmBLAKE3:
+		return blake3.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported binary hash algorithm: %q", algorithm)
+	}
+}
+
 // HashFile computes the SHA256 hash of a file
 func HashFile(path string) (string, error) {
+	return HashFileWith(HashAlgorithmSHA256, path)
+}
+
+// HashFileWith computes the hash of a file with an explicit algorithm
+// instead of the SHA-256 default.
+func HashFileWith(algorithm, path string) (string, error) {
+	hasher, err := newBinaryHasher(algorithm)
+	if err != nil {
+		return "", err
+	}
+
 	file, err := os.Open(path)
 	if err != nil {
 		return "", fmt.Errorf("failed to open file: %w", err)
 	}
 	defer file.Close() //nolint:errcheck
 
-	hasher := sha256.New()
 	if _, err := io.Copy(hasher, file); err != nil {
 		return "", fmt.Errorf("hashing file: %w", err)
 	}
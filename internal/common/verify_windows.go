@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+
+package common
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows"
+)
+
+// getBinaryPath gets the binary path for a process on Windows via
+// QueryFullProcessImageName, which only needs the limited-information access
+// right and resolves the Win32 path of the process image.
+func getBinaryPath(pid int32) (string, error) {
+	h, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(pid)) //nolint:gosec // PIDs are non-negative
+	if err != nil {
+		return "", fmt.Errorf("opening process %d: %w", pid, err)
+	}
+	defer windows.CloseHandle(h) //nolint:errcheck
+
+	// Long paths can exceed MAX_PATH; retry with a bigger buffer if needed
+	size := uint32(windows.MAX_PATH)
+	for range 2 {
+		buf := make([]uint16, size)
+		if err := windows.QueryFullProcessImageName(h, 0, &buf[0], &size); err == nil {
+			return windows.UTF16ToString(buf[:size]), nil
+		} else if err != windows.ERROR_INSUFFICIENT_BUFFER {
+			return "", fmt.Errorf("querying image name for pid %d: %w", pid, err)
+		}
+		size = 32 * 1024
+	}
+	return "", fmt.Errorf("querying image name for pid %d: path too long", pid)
+}
@@ -7,11 +7,14 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
+	"strings"
 )
 
 // embeddedServerBinary is defined in platform-specific files with build tags:
@@ -23,7 +26,9 @@ import (
 // Each file embeds only the server binary for its specific platform,
 // reducing the final binary size significantly.
 
-// getServerBinary reads and decompresses the embedded server binary for the current platform
+// getServerBinary reads and decompresses the embedded server binary for the
+// current platform, verifying it against the SHA-256 recorded at build time
+// before handing it off for execution.
 func getServerBinary() ([]byte, error) {
 	// embeddedServerBinary is the compressed .gz data
 	compressedData := embeddedServerBinary
@@ -44,6 +49,17 @@ func getServerBinary() ([]byte, error) {
 		return nil, fmt.Errorf("failed to decompress server binary: %w", err)
 	}
 
+	// Refuse to run a binary that does not hash out against the digest the
+	// build recorded next to the embed: a mismatch means the embed was
+	// corrupted or tampered with somewhere between build and execution.
+	want := strings.TrimSpace(embeddedServerBinarySHA256)
+	if want == "" {
+		return nil, fmt.Errorf("no expected hash embedded for the server binary")
+	}
+	if got := fmt.Sprintf("%x", sha256.Sum256(decompressed)); got != want {
+		return nil, fmt.Errorf("embedded server binary failed integrity check: sha256 %s, expected %s", got, want)
+	}
+
 	return decompressed, nil
 }
 
@@ -90,3 +106,93 @@ func ExtractServerBinaryToTemp(ctx context.Context) (string, error) {
 
 	return tmpPath, nil
 }
+
+// ExtractServerBinaryToCache writes the server binary to a stable location in
+// cacheDir (or, when empty, $XDG_CACHE_HOME/burnafter via os.UserCacheDir) so
+// repeated launches don't extract a fresh copy every time. An existing cached
+// file is reused only when its SHA-256 digest matches the embedded binary:
+// size or timestamps are trivially forged, so anything that doesn't hash out
+// is rewritten. Returns the path to the cached binary.
+func ExtractServerBinaryToCache(ctx context.Context, cacheDir string) (string, error) {
+	serverBinary, err := getServerBinary()
+	if err != nil {
+		return "", err
+	}
+
+	if cacheDir == "" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			return "", fmt.Errorf("resolving user cache directory: %w", err)
+		}
+		cacheDir = filepath.Join(base, "burnafter")
+	}
+
+	if err := os.MkdirAll(cacheDir, 0o700); err != nil {
+		return "", fmt.Errorf("creating cache directory: %w", err)
+	}
+
+	cachePath := filepath.Join(cacheDir, "burnafter-server")
+	want := sha256.Sum256(serverBinary)
+
+	// Reuse the cached binary only when it hashes out against the embedded one
+	if data, err := os.ReadFile(cachePath); err == nil {
+		if sha256.Sum256(data) == want {
+			// Re-assert the mode in case something loosened it
+			if err := os.Chmod(cachePath, 0o700); err != nil {
+				return "", fmt.Errorf("setting cached binary permissions: %w", err)
+			}
+			return cachePath, nil
+		}
+	}
+
+	// (Re)write atomically so a concurrent launch never sees a partial binary
+	tmpFile, err := os.CreateTemp(cacheDir, "burnafter-server-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+
+	if _, err := tmpFile.Write(serverBinary); err != nil {
+		tmpFile.Close()    //nolint:errcheck,gosec
+		os.Remove(tmpPath) //nolint:errcheck,gosec
+		return "", fmt.Errorf("failed to write server binary: %w", err)
+	}
+	if err := tmpFile.Chmod(0o700); err != nil {
+		tmpFile.Close()    //nolint:errcheck,gosec
+		os.Remove(tmpPath) //nolint:errcheck,gosec
+		return "", fmt.Errorf("failed to make binary executable: %w", err)
+	}
+	tmpFile.Close() //nolint:errcheck,gosec
+
+	if err := os.Rename(tmpPath, cachePath); err != nil {
+		os.Remove(tmpPath) //nolint:errcheck,gosec
+		return "", fmt.Errorf("failed to move binary into cache: %w", err)
+	}
+
+	// On macOS, remove quarantine attribute to allow execution
+	if runtime.GOOS == "darwin" {
+		cmd := exec.CommandContext(ctx, "xattr", "-d", "com.apple.quarantine", cachePath) //nolint:gosec // path is controlled
+		//  Ignore if it fails, the attribute might not be set
+		_ = cmd.Run() //nolint:errcheck
+	}
+
+	return cachePath, nil
+}
+
+// ServerBinaryAvailable reports whether this build embeds a server binary for
+// the platform it is running on. Builds only ever embed the binary for their
+// own platform, so a false here means server mode can only work with an
+// externally provided daemon.
+func ServerBinaryAvailable() bool {
+	return len(embeddedServerBinary) > 0
+}
+
+// Platforms returns the os/arch pairs this build has an embedded server
+// binary for. Since each build embeds only its own platform's binary, the
+// result is either empty or the current platform.
+func Platforms() []string {
+	if !ServerBinaryAvailable() {
+		return nil
+	}
+	return []string{runtime.GOOS + "/" + runtime.GOARCH}
+}
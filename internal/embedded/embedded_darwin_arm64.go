@@ -14,3 +14,10 @@ import (
 //
 //go:embed servers/darwin/arm64/burnafter-server.gz
 var embeddedServerBinary []byte
+
+// Expected SHA-256 of the decompressed server binary, generated by the build
+// script alongside the compressed embed. getServerBinary refuses to hand the
+// binary off for execution unless it hashes out against this value.
+//
+//go:embed servers/darwin/arm64/burnafter-server.sha256
+var embeddedServerBinarySHA256 string
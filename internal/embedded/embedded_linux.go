@@ -14,6 +14,12 @@ import (
 	"golang.org/x/sys/unix"
 )
 
+// memfdWrite writes the server binary into the memfd. It is a variable so
+// tests can inject write failures (short writes, I/O errors) and prove they
+// surface as CreateMemfdServer errors, which the launcher treats as "memfd
+// unavailable" and routes to the temp-file fallback.
+var memfdWrite = unix.Write
+
 // createMemfdServer creates an anonymous in-memory file containing the server binary
 // and returns the file descriptor. This is Linux-specific.
 func CreateMemfdServer(ctx context.Context) (int, error) {
@@ -37,7 +43,7 @@ func CreateMemfdServer(ctx context.Context) (int, error) {
 	}
 
 	// Write the embedded binary to the memfd
-	n, err := unix.Write(fd, serverBinary)
+	n, err := memfdWrite(fd, serverBinary)
 	if err != nil {
 		unix.Close(fd) //nolint:errcheck,gosec
 		return -1, fmt.Errorf("failed to write binary to memfd: %w", err)
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+// +build linux
+
+package embedded
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestCreateMemfdServerShortWrite(t *testing.T) {
+	if !ServerBinaryAvailable() {
+		t.Skip("no server binary embedded in this build")
+	}
+
+	orig := memfdWrite
+	t.Cleanup(func() { memfdWrite = orig })
+
+	// A short write must surface as an error (which the launcher treats as
+	// "memfd unavailable"), never as a truncated server binary
+	memfdWrite = func(fd int, p []byte) (int, error) {
+		return len(p) / 2, nil
+	}
+
+	if _, err := CreateMemfdServer(context.Background()); err == nil {
+		t.Fatalf("Expected an error on a short memfd write")
+	} else if !strings.Contains(err.Error(), "incomplete write") {
+		t.Errorf("Expected an incomplete-write error, got %v", err)
+	}
+}
+
+func TestCreateMemfdServerWriteError(t *testing.T) {
+	if !ServerBinaryAvailable() {
+		t.Skip("no server binary embedded in this build")
+	}
+
+	orig := memfdWrite
+	t.Cleanup(func() { memfdWrite = orig })
+
+	memfdWrite = func(fd int, p []byte) (int, error) {
+		return 0, unix.EIO
+	}
+
+	if _, err := CreateMemfdServer(context.Background()); err == nil {
+		t.Fatalf("Expected an error on a failed memfd write")
+	} else if !strings.Contains(err.Error(), "failed to write binary to memfd") {
+		t.Errorf("Expected a write-failure error, got %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package embedded
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestExtractServerBinaryToCache(t *testing.T) {
+	if !ServerBinaryAvailable() {
+		t.Skip("no server binary embedded in this build")
+	}
+
+	ctx := context.Background()
+	cacheDir := t.TempDir()
+
+	path, err := ExtractServerBinaryToCache(ctx, cacheDir)
+	if err != nil {
+		t.Fatalf("ExtractServerBinaryToCache failed: %v", err)
+	}
+
+	want, err := getServerBinary()
+	if err != nil {
+		t.Fatalf("getServerBinary failed: %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading cached binary: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("cached binary does not match the embedded one")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat cached binary: %v", err)
+	}
+	if info.Mode().Perm() != 0o700 {
+		t.Errorf("Expected mode 0700, got %o", info.Mode().Perm())
+	}
+
+	// A second extraction reuses the verified file in place
+	firstModTime := info.ModTime()
+	if _, err := ExtractServerBinaryToCache(ctx, cacheDir); err != nil {
+		t.Fatalf("ExtractServerBinaryToCache failed on reuse: %v", err)
+	}
+	info, err = os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat cached binary: %v", err)
+	}
+	if !info.ModTime().Equal(firstModTime) {
+		t.Errorf("Expected the verified cached binary to be reused, not rewritten")
+	}
+}
+
+func TestExtractServerBinaryToCacheRejectsTampered(t *testing.T) {
+	if !ServerBinaryAvailable() {
+		t.Skip("no server binary embedded in this build")
+	}
+
+	ctx := context.Background()
+	cacheDir := t.TempDir()
+
+	path, err := ExtractServerBinaryToCache(ctx, cacheDir)
+	if err != nil {
+		t.Fatalf("ExtractServerBinaryToCache failed: %v", err)
+	}
+
+	want, err := getServerBinary()
+	if err != nil {
+		t.Fatalf("getServerBinary failed: %v", err)
+	}
+
+	// Plant a same-length but different binary: a pure size check would
+	// happily reuse it
+	tampered := make([]byte, len(want))
+	copy(tampered, want)
+	tampered[0] ^= 0xff
+	if err := os.WriteFile(path, tampered, 0o700); err != nil {
+		t.Fatalf("planting tampered binary: %v", err)
+	}
+
+	if _, err := ExtractServerBinaryToCache(ctx, cacheDir); err != nil {
+		t.Fatalf("ExtractServerBinaryToCache failed: %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading cached binary: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("Expected the tampered cached binary to be overwritten")
+	}
+}
+
+func TestGetServerBinaryIntegrity(t *testing.T) {
+	if !ServerBinaryAvailable() {
+		t.Skip("no server binary embedded in this build")
+	}
+
+	// The pristine embed hashes out against its recorded digest
+	want, err := getServerBinary()
+	if err != nil {
+		t.Fatalf("getServerBinary failed on the pristine embed: %v", err)
+	}
+
+	origBinary := embeddedServerBinary
+	origHash := embeddedServerBinarySHA256
+	t.Cleanup(func() {
+		embeddedServerBinary = origBinary
+		embeddedServerBinarySHA256 = origHash
+	})
+
+	// A tampered embed must be refused, never handed off for execution
+	tampered := make([]byte, len(want))
+	copy(tampered, want)
+	tampered[0] ^= 0xff
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	if _, err := gw.Write(tampered); err != nil {
+		t.Fatalf("compressing tampered binary: %v", err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatalf("compressing tampered binary: %v", err)
+	}
+	embeddedServerBinary = buf.Bytes()
+	if _, err := getServerBinary(); err == nil || !strings.Contains(err.Error(), "integrity") {
+		t.Errorf("Expected an integrity error for a tampered embed, got: %v", err)
+	}
+
+	// A build with the binary but no recorded digest is refused too
+	embeddedServerBinary = origBinary
+	embeddedServerBinarySHA256 = ""
+	if _, err := getServerBinary(); err == nil {
+		t.Errorf("Expected an error when no expected hash is embedded")
+	}
+}
@@ -0,0 +1,15 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+// +build windows
+
+package embedded
+
+// No server binary is embedded for Windows builds (yet): clients there run
+// the server in-process or use the memory and fallback modes. An empty slice
+// makes IsEmbedded report false, which routes Connect to those paths.
+var embeddedServerBinary []byte
+
+// No embedded binary means no expected hash either
+var embeddedServerBinarySHA256 string
@@ -30,15 +30,34 @@ const (
 //
 // Unlike the Linux keyring which requires a worker goroutine, macOS Keychain
 // is thread-safe and can be accessed directly from any thread.
-type KeychainStorage struct{}
+type KeychainStorage struct {
+	// sessionID scopes the service name the items are filed under, so two
+	// servers sharing the keychain do not clobber each other's items and a
+	// server's shutdown flush only removes its own.
+	sessionID string
+}
 
-// NewKeychainStorage creates a new macOS Keychain storage backend.
-// The macOS Keychain is always available on macOS systems, so this function
-// simply returns a new instance. Individual operations will fail if the
-// keychain is locked or inaccessible.
-func NewKeychainStorage(ctx context.Context) (*KeychainStorage, error) {
+// NewKeychainStorage creates a new macOS Keychain storage backend scoped by
+// sessionID. The macOS Keychain is always available on macOS systems, so this
+// function simply returns a new instance. Individual operations will fail if
+// the keychain is locked or inaccessible.
+//
+// Keychain items have no session-bound lifetime the way Linux session keyring
+// keys do: they are marked AccessibleWhenUnlocked so they stay sealed while
+// the keychain is locked, and the server's shutdown flush deletes them under
+// the session-scoped service name.
+func NewKeychainStorage(ctx context.Context, sessionID string) (*KeychainStorage, error) {
 	clog.FromContext(ctx).Debug("macOS Keychain storage initialized")
-	return &KeychainStorage{}, nil
+	return &KeychainStorage{sessionID: sessionID}, nil
+}
+
+// service returns the keychain service name the storage files its items
+// under, suffixed with the session ID when one is set.
+func (k *KeychainStorage) service() string {
+	if k.sessionID == "" {
+		return keychainService
+	}
+	return keychainService + "." + k.sessionID
 }
 
 // Store persists a secret in the macOS Keychain.
@@ -58,7 +77,7 @@ func (k *KeychainStorage) Store(ctx context.Context, id string, secret *secrets.
 	// Create a new keychain item
 	item := keychain.NewItem()
 	item.SetSecClass(keychain.SecClassGenericPassword)
-	item.SetService(keychainService)
+	item.SetService(k.service())
 	item.SetAccount(id)
 	item.SetData(buf.Bytes())
 	item.SetLabel(fmt.Sprintf("burnafter secret: %s", id))
@@ -81,7 +100,7 @@ func (k *KeychainStorage) Get(ctx context.Context, id string) (*secrets.Payload,
 	// Query the keychain
 	query := keychain.NewItem()
 	query.SetSecClass(keychain.SecClassGenericPassword)
-	query.SetService(keychainService)
+	query.SetService(k.service())
 	query.SetAccount(id)
 	query.SetMatchLimit(keychain.MatchLimitOne)
 	query.SetReturnData(true)
@@ -119,7 +138,7 @@ func (k *KeychainStorage) Delete(ctx context.Context, id string) error {
 	// Create delete query
 	item := keychain.NewItem()
 	item.SetSecClass(keychain.SecClassGenericPassword)
-	item.SetService(keychainService)
+	item.SetService(k.service())
 	item.SetAccount(id)
 
 	// Delete the item
@@ -135,3 +154,8 @@ func (k *KeychainStorage) Delete(ctx context.Context, id string) error {
 	clog.FromContext(ctx).Debugf("Successfully deleted secret %s from keychain", id)
 	return nil
 }
+
+// Backend returns the identifier of the macOS Keychain storage driver.
+func (k *KeychainStorage) Backend() string {
+	return "keychain"
+}
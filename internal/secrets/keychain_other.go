@@ -13,6 +13,6 @@ import (
 )
 
 // NewKeychainStorage always returns an error on non-macOS platforms.
-func NewKeychainStorage(context.Context) (secrets.Storage, error) {
+func NewKeychainStorage(context.Context, string) (secrets.Storage, error) {
 	return nil, fmt.Errorf("keychain storage is only supported on macOS")
 }
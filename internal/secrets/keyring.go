@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"strconv"
 	"sync"
 
 	"github.com/chainguard-dev/clog"
@@ -45,6 +46,24 @@ var (
 	workerKeyring int
 )
 
+const (
+	// keyringChunkSize is the maximum payload written to a single keyring key.
+	// The kernel caps "user" key payloads (typically at 32KiB), so larger
+	// gob-encoded payloads are split across multiple keys. Kept well below the
+	// kernel cap to leave headroom.
+	keyringChunkSize = 16 * 1024
+
+	// chunkIndexMagic prefixes the payload of a key that acts as a chunk
+	// index: the remainder of the payload is the decimal chunk count, and the
+	// chunks live under "<id>#0" ... "<id>#<n-1>".
+	chunkIndexMagic = "burnafter-chunks:"
+)
+
+// chunkKeyID returns the keyring description for chunk n of a secret.
+func chunkKeyID(id string, n int) string {
+	return fmt.Sprintf("%s#%d", id, n)
+}
+
 // KeyringStorage is a Linux kernel keyring implementation of the secrets.Storage
 // interface. It stores encrypted secrets in the process keyring.
 //
@@ -56,7 +75,14 @@ var (
 // get around this, all operations are dispatched to a shared worker goroutine
 // locked to a OS thread. Multiple instances share the same worker to ensure
 // consistent keyring access which should never happen outside of tests.
-type KeyringStorage struct{}
+type KeyringStorage struct {
+	// sessionPrefix namespaces the key descriptions this instance reads and
+	// writes. All instances share one process keyring (and one worker), so
+	// without a prefix two servers in the same process — tests, or two
+	// embedded servers — would clobber each other's keys when secret names
+	// collide. Empty means unscoped.
+	sessionPrefix string
+}
 
 // initWorker initializes the global keyring worker goroutine (called once)
 func initWorker(ctx context.Context) {
@@ -117,7 +143,11 @@ func initWorker(ctx context.Context) {
 // To handle this, a shared worker goroutine locked to an OS thread handles
 // all keyring operations, ensuring all calls come from the same thread.
 // Multiple KeyringStorage instances share the same worker.
-func NewKeyringStorage(ctx context.Context) (*KeyringStorage, error) {
+//
+// sessionID namespaces this instance's key descriptions so servers sharing
+// the process keyring stay isolated from each other; pass "" for the
+// historical unscoped names.
+func NewKeyringStorage(ctx context.Context, sessionID string) (*KeyringStorage, error) {
 	// Initialize the global worker exactly once
 	workerOnce.Do(func() {
 		initWorker(ctx)
@@ -128,7 +158,42 @@ func NewKeyringStorage(ctx context.Context) (*KeyringStorage, error) {
 		return nil, errWorkerInit
 	}
 
-	return &KeyringStorage{}, nil
+	return &KeyringStorage{sessionPrefix: sessionID}, nil
+}
+
+// scopedID returns the keyring description for a secret, namespaced by the
+// session prefix when one is set. The chunk keys of large payloads inherit
+// the scope automatically, since their ids derive from the scoped one.
+func (k *KeyringStorage) scopedID(id string) string {
+	if k.sessionPrefix == "" {
+		return id
+	}
+	return k.sessionPrefix + "/" + id
+}
+
+// dispatch sends a request to the keyring worker and waits for its response,
+// honoring context cancellation both while queueing on the unbuffered request
+// channel and while waiting for the reply. The response channel is buffered,
+// so the worker never blocks on a reply the caller abandoned.
+func dispatch(ctx context.Context, req keyringRequest) (keyringResponse, error) {
+	// An already-dead context never reaches the worker (a select with both
+	// cases ready picks one at random)
+	if err := ctx.Err(); err != nil {
+		return keyringResponse{}, err
+	}
+
+	select {
+	case workerReqChan <- req:
+	case <-ctx.Done():
+		return keyringResponse{}, ctx.Err()
+	}
+
+	select {
+	case resp := <-req.respChan:
+		return resp, nil
+	case <-ctx.Done():
+		return keyringResponse{}, ctx.Err()
+	}
 }
 
 // Store persists a secret in the kernel keyring.
@@ -137,15 +202,15 @@ func (k *KeyringStorage) Store(ctx context.Context, id string, secret *secrets.P
 		With("pid", os.Getpid()).With("tid", unix.Gettid()).
 		Debugf("Dispatching store for secret %s", id)
 
-	respChan := make(chan keyringResponse, 1)
-	workerReqChan <- keyringRequest{
+	resp, err := dispatch(ctx, keyringRequest{
 		op:       "store",
-		id:       id,
+		id:       k.scopedID(id),
 		payload:  secret,
-		respChan: respChan,
+		respChan: make(chan keyringResponse, 1),
+	})
+	if err != nil {
+		return err
 	}
-
-	resp := <-respChan
 	return resp.err
 }
 
@@ -161,7 +226,44 @@ func storeOnThread(ctx context.Context, keyringId int, id string, secret *secret
 	if err := enc.Encode(secret); err != nil {
 		return fmt.Errorf("failed to encode the secret payload: %w", err)
 	}
+	data := buf.Bytes()
+
+	// Remove chunks a previous (larger) version of this key may have left
+	removeChunksOnThread(keyringId, id)
 
+	// Small payloads fit in a single key
+	if len(data) <= keyringChunkSize {
+		return addKeyOnThread(keyringId, id, data)
+	}
+
+	// The payload exceeds the per-key limit: split it across chunk keys and
+	// store a small index under the secret's own id.
+	numChunks := (len(data) + keyringChunkSize - 1) / keyringChunkSize
+	for i := range numChunks {
+		start := i * keyringChunkSize
+		end := min(start+keyringChunkSize, len(data))
+		if err := addKeyOnThread(keyringId, chunkKeyID(id, i), data[start:end]); err != nil {
+			// Don't leave partial chunks behind
+			for j := range i {
+				unlinkKeyOnThread(keyringId, chunkKeyID(id, j))
+			}
+			return fmt.Errorf("adding chunk %d of %d: %w", i, numChunks, err)
+		}
+	}
+
+	if err := addKeyOnThread(keyringId, id, fmt.Appendf(nil, "%s%d", chunkIndexMagic, numChunks)); err != nil {
+		for i := range numChunks {
+			unlinkKeyOnThread(keyringId, chunkKeyID(id, i))
+		}
+		return fmt.Errorf("adding chunk index: %w", err)
+	}
+
+	return nil
+}
+
+// addKeyOnThread writes data to a single keyring key, replacing any existing
+// key with the same description.
+func addKeyOnThread(keyringId int, id string, data []byte) error {
 	// Check if key already exists and delete it first
 	if existingKeyID, err := unix.KeyctlSearch(keyringId, "user", id, 0); err == nil {
 		//nolint:errcheck // Don't err if key can't be removed it will be overwritten anyway.
@@ -169,26 +271,83 @@ func storeOnThread(ctx context.Context, keyringId int, id string, secret *secret
 	}
 
 	// Add the key to the keyring
-	_, err := unix.AddKey("user", id, buf.Bytes(), keyringId)
-	if err != nil {
+	if _, err := unix.AddKey("user", id, data, keyringId); err != nil {
 		return fmt.Errorf("adding key to keyring %d: %w", keyringId, err)
 	}
 
 	return nil
 }
 
+// readKeyOnThread reads the raw payload of a single keyring key.
+func readKeyOnThread(keyringId int, id string) ([]byte, error) {
+	// Search for the key by description
+	keyID, err := unix.KeyctlSearch(keyringId, "user", id, 0)
+	if err != nil {
+		return nil, fmt.Errorf("looking up secret in keyring %d: %w", keyringId, err)
+	}
+
+	// First, get the size of the key data
+	size, err := unix.KeyctlBuffer(unix.KEYCTL_READ, keyID, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("getting key size: %w", err)
+	}
+
+	// Allocate buffer and read the key data
+	buf := make([]byte, size)
+	if _, err := unix.KeyctlBuffer(unix.KEYCTL_READ, keyID, buf, 0); err != nil {
+		return nil, fmt.Errorf("failed to read key from keyring: %w", err)
+	}
+
+	return buf, nil
+}
+
+// unlinkKeyOnThread removes a single keyring key, ignoring missing keys.
+func unlinkKeyOnThread(keyringId int, id string) {
+	if keyID, err := unix.KeyctlSearch(keyringId, "user", id, 0); err == nil {
+		//nolint:errcheck // best effort
+		_, _ = unix.KeyctlInt(unix.KEYCTL_UNLINK, keyID, keyringId, 0, 0)
+	}
+}
+
+// parseChunkIndex reports whether data is a chunk index payload and, if so,
+// how many chunk keys it references.
+func parseChunkIndex(data []byte) (numChunks int, ok bool) {
+	if !bytes.HasPrefix(data, []byte(chunkIndexMagic)) {
+		return 0, false
+	}
+	n, err := strconv.Atoi(string(data[len(chunkIndexMagic):]))
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+// removeChunksOnThread removes the chunk keys referenced by a secret's chunk
+// index, if the secret is chunked. The index key itself is left alone.
+func removeChunksOnThread(keyringId int, id string) {
+	data, err := readKeyOnThread(keyringId, id)
+	if err != nil {
+		return
+	}
+	if numChunks, ok := parseChunkIndex(data); ok {
+		for i := range numChunks {
+			unlinkKeyOnThread(keyringId, chunkKeyID(id, i))
+		}
+	}
+}
+
 // Get retrieves a secret from the kernel keyring by its ID.
 func (k *KeyringStorage) Get(ctx context.Context, id string) (*secrets.Payload, error) {
 	clog.FromContext(ctx).Debugf("Dispatching get for secret %s (pid=%d, tid=%d)", id, os.Getpid(), unix.Gettid())
 
-	respChan := make(chan keyringResponse, 1)
-	workerReqChan <- keyringRequest{
+	resp, err := dispatch(ctx, keyringRequest{
 		op:       "get",
-		id:       id,
-		respChan: respChan,
+		id:       k.scopedID(id),
+		respChan: make(chan keyringResponse, 1),
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	resp := <-respChan
 	return resp.payload, resp.err
 }
 
@@ -196,23 +355,21 @@ func (k *KeyringStorage) Get(ctx context.Context, id string) (*secrets.Payload,
 func getOnThread(ctx context.Context, keyringId int, id string) (*secrets.Payload, error) {
 	clog.FromContext(ctx).Debugf("Getting secret %s from keyring %d (pid=%d, tid=%d)", id, keyringId, os.Getpid(), unix.Gettid())
 
-	// Search for the key by description
-	keyID, err := unix.KeyctlSearch(keyringId, "user", id, 0)
+	buf, err := readKeyOnThread(keyringId, id)
 	if err != nil {
-		return nil, fmt.Errorf("looking up secret in keyring %d: %w", keyringId, err)
+		return nil, err
 	}
 
-	// First, get the size of the key data
-	size, err := unix.KeyctlBuffer(unix.KEYCTL_READ, keyID, nil, 0)
-	if err != nil {
-		return nil, fmt.Errorf("getting key size: %w", err)
-	}
-
-	// Allocate buffer and read the key data
-	buf := make([]byte, size)
-	_, err = unix.KeyctlBuffer(unix.KEYCTL_READ, keyID, buf, 0)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read key from keyring: %w", err)
+	// Chunked payloads store an index under the secret's id, reassemble them
+	if numChunks, ok := parseChunkIndex(buf); ok {
+		buf = buf[:0]
+		for i := range numChunks {
+			chunk, err := readKeyOnThread(keyringId, chunkKeyID(id, i))
+			if err != nil {
+				return nil, fmt.Errorf("reading chunk %d of %d: %w", i, numChunks, err)
+			}
+			buf = append(buf, chunk...)
+		}
 	}
 
 	// Deserialize the payload
@@ -229,14 +386,14 @@ func getOnThread(ctx context.Context, keyringId int, id string) (*secrets.Payloa
 func (k *KeyringStorage) Delete(ctx context.Context, id string) error {
 	clog.FromContext(ctx).Debugf("Dispatching delete for secret %s (pid=%d, tid=%d)", id, os.Getpid(), unix.Gettid())
 
-	respChan := make(chan keyringResponse, 1)
-	workerReqChan <- keyringRequest{
+	resp, err := dispatch(ctx, keyringRequest{
 		op:       "delete",
-		id:       id,
-		respChan: respChan,
+		id:       k.scopedID(id),
+		respChan: make(chan keyringResponse, 1),
+	})
+	if err != nil {
+		return err
 	}
-
-	resp := <-respChan
 	return resp.err
 }
 
@@ -244,6 +401,9 @@ func (k *KeyringStorage) Delete(ctx context.Context, id string) error {
 func deleteOnThread(ctx context.Context, keyringId int, id string) error {
 	clog.FromContext(ctx).Debugf("Deleting secret %s from keyring %d (pid=%d, tid=%d)", id, keyringId, os.Getpid(), unix.Gettid())
 
+	// Remove any chunk keys referenced by the secret's index first
+	removeChunksOnThread(keyringId, id)
+
 	// Search for the key by its ID
 	keyID, err := unix.KeyctlSearch(keyringId, "user", id, 0)
 	if err != nil {
@@ -259,3 +419,8 @@ func deleteOnThread(ctx context.Context, keyringId int, id string) error {
 
 	return nil
 }
+
+// Backend returns the identifier of the kernel keyring storage driver.
+func (k *KeyringStorage) Backend() string {
+	return "keyring"
+}
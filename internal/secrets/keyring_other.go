@@ -13,6 +13,6 @@ import (
 )
 
 // NewKeyringStorage always returns an error on non-Linux platforms.
-func NewKeyringStorage(context.Context) (secrets.Storage, error) {
+func NewKeyringStorage(context.Context, string) (secrets.Storage, error) {
 	return nil, fmt.Errorf("kernel keyring storage is only supported on Linux")
 }
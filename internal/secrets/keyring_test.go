@@ -8,13 +8,16 @@ package secrets
 import (
 	"bytes"
 	"context"
+	"errors"
 	"testing"
 
+	"golang.org/x/sys/unix"
+
 	"github.com/carabiner-dev/burnafter/secrets"
 )
 
 func TestKeyringStorageStoreAndGet(t *testing.T) {
-	storage, err := NewKeyringStorage(t.Context())
+	storage, err := NewKeyringStorage(t.Context(), "")
 	if err != nil {
 		t.Skipf("Skipping keyring test: %v", err)
 	}
@@ -59,7 +62,7 @@ func TestKeyringStorageStoreAndGet(t *testing.T) {
 }
 
 func TestKeyringStorageDelete(t *testing.T) {
-	storage, err := NewKeyringStorage(t.Context())
+	storage, err := NewKeyringStorage(t.Context(), "")
 	if err != nil {
 		t.Skipf("Skipping keyring test: %v", err)
 	}
@@ -89,10 +92,15 @@ func TestKeyringStorageDelete(t *testing.T) {
 	if err == nil {
 		t.Error("Expected error when getting deleted secret, got nil")
 	}
+
+	// Deletes are idempotent: removing a missing key is a success
+	if err := storage.Delete(ctx, "test-delete"); err != nil {
+		t.Errorf("Expected idempotent delete of a missing secret, got: %v", err)
+	}
 }
 
 func TestKeyringStorageOverwrite(t *testing.T) {
-	storage, err := NewKeyringStorage(t.Context())
+	storage, err := NewKeyringStorage(t.Context(), "")
 	if err != nil {
 		t.Skipf("Skipping keyring test: %v", err)
 	}
@@ -137,8 +145,88 @@ func TestKeyringStorageOverwrite(t *testing.T) {
 	_ = storage.Delete(ctx, "test-overwrite") //nolint:errcheck
 }
 
+func TestKeyringStorageChunkedPayload(t *testing.T) {
+	storage, err := NewKeyringStorage(t.Context(), "")
+	if err != nil {
+		t.Skipf("Skipping keyring test: %v", err)
+	}
+
+	ctx := context.Background()
+
+	// Payload larger than a single keyring key can hold, forcing chunking
+	large := make([]byte, 3*keyringChunkSize+100)
+	for i := range large {
+		large[i] = byte(i % 251)
+	}
+
+	payload := &secrets.Payload{
+		EncryptedData:    large,
+		Salt:             []byte("chunk-salt"),
+		ClientBinaryHash: "chunk-hash",
+	}
+
+	if err := storage.Store(ctx, "test-chunked", payload); err != nil {
+		// The per-user keyring byte quota may be too small for this test
+		if errors.Is(err, unix.EDQUOT) {
+			t.Skipf("Skipping chunked test, keyring quota too small: %v", err)
+		}
+		t.Fatalf("Failed to store chunked secret: %v", err)
+	}
+
+	retrieved, err := storage.Get(ctx, "test-chunked")
+	if err != nil {
+		t.Fatalf("Failed to get chunked secret: %v", err)
+	}
+	if !bytes.Equal(retrieved.EncryptedData, large) {
+		t.Errorf("Chunked payload mismatch after reassembly")
+	}
+
+	// Overwriting with a small payload must remove the stale chunks
+	small := &secrets.Payload{
+		EncryptedData:    []byte("small"),
+		Salt:             []byte("small-salt"),
+		ClientBinaryHash: "small-hash",
+	}
+	if err := storage.Store(ctx, "test-chunked", small); err != nil {
+		t.Fatalf("Failed to overwrite chunked secret: %v", err)
+	}
+
+	retrieved, err = storage.Get(ctx, "test-chunked")
+	if err != nil {
+		t.Fatalf("Failed to get overwritten secret: %v", err)
+	}
+	if string(retrieved.EncryptedData) != "small" {
+		t.Errorf("Expected small payload after overwrite, got %d bytes", len(retrieved.EncryptedData))
+	}
+
+	// Delete must remove the secret (and all chunks)
+	if err := storage.Delete(ctx, "test-chunked"); err != nil {
+		t.Fatalf("Failed to delete chunked secret: %v", err)
+	}
+	if _, err := storage.Get(ctx, "test-chunked"); err == nil {
+		t.Error("Expected error when getting deleted chunked secret, got nil")
+	}
+}
+
+func TestParseChunkIndex(t *testing.T) {
+	if n, ok := parseChunkIndex([]byte("burnafter-chunks:7")); !ok || n != 7 {
+		t.Errorf("Expected (7, true), got (%d, %v)", n, ok)
+	}
+	for _, bad := range [][]byte{
+		[]byte("not-an-index"),
+		[]byte("burnafter-chunks:"),
+		[]byte("burnafter-chunks:0"),
+		[]byte("burnafter-chunks:nope"),
+		{},
+	} {
+		if _, ok := parseChunkIndex(bad); ok {
+			t.Errorf("Expected %q to not parse as a chunk index", bad)
+		}
+	}
+}
+
 func TestKeyringStorageGetNonExistent(t *testing.T) {
-	storage, err := NewKeyringStorage(t.Context())
+	storage, err := NewKeyringStorage(t.Context(), "")
 	if err != nil {
 		t.Skipf("Skipping keyring test: %v", err)
 	}
@@ -163,7 +251,7 @@ func TestKeyringStoragePersistsAcrossInstances(t *testing.T) {
 	ctx := context.Background()
 
 	// Create first storage instance and store a secret
-	storage1, err := NewKeyringStorage(t.Context())
+	storage1, err := NewKeyringStorage(t.Context(), "")
 	if err != nil {
 		t.Skipf("Skipping keyring test: %v", err)
 	}
@@ -180,7 +268,7 @@ func TestKeyringStoragePersistsAcrossInstances(t *testing.T) {
 	}
 
 	// Create a NEW storage instance (simulating server still running but new request)
-	storage2, err := NewKeyringStorage(t.Context())
+	storage2, err := NewKeyringStorage(t.Context(), "")
 	if err != nil {
 		t.Skipf("Skipping keyring test: %v", err)
 	}
@@ -202,3 +290,79 @@ func TestKeyringStoragePersistsAcrossInstances(t *testing.T) {
 		t.Fatalf("Failed to delete secret: %v", err)
 	}
 }
+
+func TestKeyringStorageCancelledContext(t *testing.T) {
+	storage, err := NewKeyringStorage(t.Context(), "")
+	if err != nil {
+		t.Skipf("Skipping keyring test: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	payload := &secrets.Payload{EncryptedData: []byte("data")}
+
+	if err := storage.Store(ctx, "cancelled-dispatch", payload); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected Store to honor cancellation, got %v", err)
+	}
+	if _, err := storage.Get(ctx, "cancelled-dispatch"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected Get to honor cancellation, got %v", err)
+	}
+	if err := storage.Delete(ctx, "cancelled-dispatch"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected Delete to honor cancellation, got %v", err)
+	}
+}
+
+func TestKeyringStorageSessionIsolation(t *testing.T) {
+	ctx := context.Background()
+
+	// Two sessions sharing the process keyring must not see (or clobber)
+	// each other's keys, even under the same secret name
+	storageA, err := NewKeyringStorage(t.Context(), "isolation-session-a")
+	if err != nil {
+		t.Skipf("Skipping keyring test: %v", err)
+	}
+	storageB, err := NewKeyringStorage(t.Context(), "isolation-session-b")
+	if err != nil {
+		t.Skipf("Skipping keyring test: %v", err)
+	}
+
+	payloadA := &secrets.Payload{EncryptedData: []byte("payload-of-session-a")}
+	payloadB := &secrets.Payload{EncryptedData: []byte("payload-of-session-b")}
+
+	if err := storageA.Store(ctx, "shared-name", payloadA); err != nil {
+		t.Fatalf("Failed to store in session A: %v", err)
+	}
+	if err := storageB.Store(ctx, "shared-name", payloadB); err != nil {
+		t.Fatalf("Failed to store in session B: %v", err)
+	}
+	defer storageA.Delete(ctx, "shared-name") //nolint:errcheck
+	defer storageB.Delete(ctx, "shared-name") //nolint:errcheck
+
+	gotA, err := storageA.Get(ctx, "shared-name")
+	if err != nil {
+		t.Fatalf("Failed to get from session A: %v", err)
+	}
+	if !bytes.Equal(gotA.EncryptedData, payloadA.EncryptedData) {
+		t.Errorf("Session A read the wrong payload: %s", gotA.EncryptedData)
+	}
+
+	gotB, err := storageB.Get(ctx, "shared-name")
+	if err != nil {
+		t.Fatalf("Failed to get from session B: %v", err)
+	}
+	if !bytes.Equal(gotB.EncryptedData, payloadB.EncryptedData) {
+		t.Errorf("Session B read the wrong payload: %s", gotB.EncryptedData)
+	}
+
+	// Deleting in one session must leave the other session's key alone
+	if err := storageA.Delete(ctx, "shared-name"); err != nil {
+		t.Fatalf("Failed to delete in session A: %v", err)
+	}
+	if _, err := storageA.Get(ctx, "shared-name"); err == nil {
+		t.Errorf("Expected session A's key to be gone")
+	}
+	if _, err := storageB.Get(ctx, "shared-name"); err != nil {
+		t.Errorf("Expected session B's key to survive: %v", err)
+	}
+}
@@ -27,6 +27,10 @@ func NewMemoryStorage() *MemoryStorage {
 
 // Store persists a secret in memory.
 func (m *MemoryStorage) Store(ctx context.Context, id string, secret *secrets.Payload) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -36,6 +40,10 @@ func (m *MemoryStorage) Store(ctx context.Context, id string, secret *secrets.Pa
 
 // Get retrieves a secret from memory by its ID.
 func (m *MemoryStorage) Get(ctx context.Context, id string) (*secrets.Payload, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
@@ -49,9 +57,18 @@ func (m *MemoryStorage) Get(ctx context.Context, id string) (*secrets.Payload, e
 
 // Delete removes a secret from memory by its id.
 func (m *MemoryStorage) Delete(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	delete(m.data, id)
 	return nil
 }
+
+// Backend returns the identifier of the memory storage driver.
+func (m *MemoryStorage) Backend() string {
+	return "memory"
+}
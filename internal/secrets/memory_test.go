@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package secrets
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+func TestMemoryStorageCancelledContext(t *testing.T) {
+	storage := NewMemoryStorage()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	payload := &secrets.Payload{EncryptedData: []byte("data")}
+
+	if err := storage.Store(ctx, "cancelled", payload); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected Store to honor cancellation, got %v", err)
+	}
+	if _, err := storage.Get(ctx, "cancelled"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected Get to honor cancellation, got %v", err)
+	}
+	if err := storage.Delete(ctx, "cancelled"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected Delete to honor cancellation, got %v", err)
+	}
+
+	// A live context still works
+	if err := storage.Store(context.Background(), "alive", payload); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if _, err := storage.Get(context.Background(), "alive"); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chainguard-dev/clog"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+// StoreAlias implements the StoreAlias RPC. It creates a metadata-only entry
+// that resolves to an existing secret: a Get on the alias serves the target's
+// value and the target's expiry governs both, so config templates can point
+// several keys at one underlying secret without duplicating the encrypted
+// payload. Deleting the target invalidates the alias.
+func (s *Server) StoreAlias(ctx context.Context, req *common.StoreAliasRequest) (*common.StoreAliasResponse, error) {
+	s.updateActivity()
+
+	clog.FromContext(ctx).Debugf("StoreAlias request: %s -> %s", req.Alias, req.Target)
+
+	if req.Alias == "" || req.Target == "" {
+		return &common.StoreAliasResponse{
+			Success: false,
+			Error:   "alias and target names must be set",
+		}, nil
+	}
+	if req.Alias == req.Target {
+		return &common.StoreAliasResponse{
+			Success: false,
+			Error:   "alias cannot point to itself",
+		}, nil
+	}
+
+	now := s.now()
+	s.secretsMu.Lock()
+	defer s.secretsMu.Unlock()
+
+	// The target (or, for an alias chain, its terminal secret) must exist
+	targetName, target, err := s.resolveAliasLocked(req.Target)
+	if err != nil {
+		return &common.StoreAliasResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	// Refuse to close a cycle: the chain from the target must not lead back
+	// to the alias being created
+	if targetName == req.Alias {
+		return &common.StoreAliasResponse{
+			Success: false,
+			Error:   "alias would create a cycle",
+		}, nil
+	}
+
+	_, exists := s.secrets[req.Alias]
+	if !exists && len(s.secrets) >= s.options.MaxSecrets {
+		return &common.StoreAliasResponse{
+			Success: false,
+			Error:   fmt.Sprintf("maximum number of secrets (%d) reached", s.options.MaxSecrets),
+		}, nil
+	}
+
+	// Replacing an existing entry invalidates any handle it had
+	if old, exists := s.secrets[req.Alias]; exists {
+		s.dropHandleLocked(old)
+	}
+
+	// The alias carries a snapshot of the target's expiry settings for
+	// listings; it is only informational. Access and the cleanup sweep are
+	// both governed by the target's live metadata (the sweep reaps an alias
+	// when its chain stops resolving), so a later ExtendDeadline on the
+	// target extends the alias with it.
+	s.secrets[req.Alias] = &secrets.Metadata{
+		Name:              req.Alias,
+		AliasTarget:       req.Target,
+		InactivityTTL:     target.InactivityTTL,
+		AbsoluteExpiresAt: target.AbsoluteExpiresAt,
+		LastAccessed:      now,
+		CreatedAt:         now,
+	}
+
+	return &common.StoreAliasResponse{Success: true}, nil
+}
+
+// resolveAliasLocked follows an alias chain to its terminal secret, returning
+// the resolved name and metadata. The caller must hold secretsMu. A broken
+// chain reports the secret as not found; a cycle is detected by tracking the
+// visited names.
+func (s *Server) resolveAliasLocked(name string) (string, *secrets.Metadata, error) {
+	visited := map[string]bool{}
+	for {
+		metadata, exists := s.secrets[name]
+		if !exists {
+			return "", nil, errSecretNotFound
+		}
+		if metadata.AliasTarget == "" {
+			return name, metadata, nil
+		}
+		if visited[name] {
+			return "", nil, fmt.Errorf("alias cycle detected at '%s'", name)
+		}
+		visited[name] = true
+		name = metadata.AliasTarget
+	}
+}
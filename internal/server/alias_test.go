@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"testing"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+func TestStoreAliasResolution(t *testing.T) {
+	srv := newTestServer(t)
+	ctx := testPeerContext()
+
+	storeResp, err := srv.Store(ctx, &common.StoreRequest{
+		Name:        "alias-target",
+		Secret:      []byte("aliased-value"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if !storeResp.Success {
+		t.Fatalf("Store error: %s", storeResp.Error)
+	}
+
+	aliasResp, err := srv.StoreAlias(ctx, &common.StoreAliasRequest{
+		Alias:  "alias-name",
+		Target: "alias-target",
+	})
+	if err != nil {
+		t.Fatalf("StoreAlias failed: %v", err)
+	}
+	if !aliasResp.Success {
+		t.Fatalf("StoreAlias error: %s", aliasResp.Error)
+	}
+
+	// A get on the alias serves the target's value
+	getResp, err := srv.Get(ctx, &common.GetRequest{Name: "alias-name", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !getResp.Success {
+		t.Fatalf("Get error: %s", getResp.Error)
+	}
+	if string(getResp.Secret) != "aliased-value" {
+		t.Errorf("Expected aliased-value, got %q", getResp.Secret)
+	}
+
+	// The target is still retrievable under its own name
+	getResp, err = srv.Get(ctx, &common.GetRequest{Name: "alias-target", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !getResp.Success {
+		t.Fatalf("Get error: %s", getResp.Error)
+	}
+}
+
+func TestStoreAliasUnknownTarget(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := srv.StoreAlias(testPeerContext(), &common.StoreAliasRequest{
+		Alias:  "dangling",
+		Target: "no-such-secret",
+	})
+	if err != nil {
+		t.Fatalf("StoreAlias failed: %v", err)
+	}
+	if resp.Success {
+		t.Errorf("Expected aliasing a missing target to fail")
+	}
+}
+
+func TestStoreAliasTargetDeleted(t *testing.T) {
+	srv := newTestServer(t)
+	ctx := testPeerContext()
+
+	storeResp, err := srv.Store(ctx, &common.StoreRequest{
+		Name:        "doomed-target",
+		Secret:      []byte("value"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if !storeResp.Success {
+		t.Fatalf("Store error: %s", storeResp.Error)
+	}
+
+	aliasResp, err := srv.StoreAlias(ctx, &common.StoreAliasRequest{
+		Alias:  "doomed-alias",
+		Target: "doomed-target",
+	})
+	if err != nil {
+		t.Fatalf("StoreAlias failed: %v", err)
+	}
+	if !aliasResp.Success {
+		t.Fatalf("StoreAlias error: %s", aliasResp.Error)
+	}
+
+	// Drop the target; the alias must stop resolving
+	srv.secretsMu.Lock()
+	delete(srv.secrets, "doomed-target")
+	srv.secretsMu.Unlock()
+
+	getResp, err := srv.Get(ctx, &common.GetRequest{Name: "doomed-alias", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if getResp.Success {
+		t.Errorf("Expected get through a dangling alias to fail")
+	}
+}
+
+func TestStoreAliasCycleDetection(t *testing.T) {
+	srv := newTestServer(t)
+	ctx := testPeerContext()
+
+	// Self-references are rejected outright
+	resp, err := srv.StoreAlias(ctx, &common.StoreAliasRequest{Alias: "self", Target: "self"})
+	if err != nil {
+		t.Fatalf("StoreAlias failed: %v", err)
+	}
+	if resp.Success {
+		t.Errorf("Expected a self-referencing alias to be rejected")
+	}
+
+	// Build a chain a -> b -> target, then try to close the loop target -> a
+	storeResp, err := srv.Store(ctx, &common.StoreRequest{
+		Name:        "cycle-target",
+		Secret:      []byte("value"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if !storeResp.Success {
+		t.Fatalf("Store error: %s", storeResp.Error)
+	}
+	for _, link := range []struct{ alias, target string }{
+		{"cycle-b", "cycle-target"},
+		{"cycle-a", "cycle-b"},
+	} {
+		resp, err := srv.StoreAlias(ctx, &common.StoreAliasRequest{Alias: link.alias, Target: link.target})
+		if err != nil {
+			t.Fatalf("StoreAlias failed: %v", err)
+		}
+		if !resp.Success {
+			t.Fatalf("StoreAlias error: %s", resp.Error)
+		}
+	}
+
+	// Replacing the terminal secret with an alias back into the chain would
+	// create a cycle
+	resp, err = srv.StoreAlias(ctx, &common.StoreAliasRequest{Alias: "cycle-target", Target: "cycle-a"})
+	if err != nil {
+		t.Fatalf("StoreAlias failed: %v", err)
+	}
+	if resp.Success {
+		t.Errorf("Expected closing an alias cycle to be rejected")
+	}
+
+	// Even if a cycle sneaks into the map, resolution must detect it instead
+	// of spinning forever
+	srv.secretsMu.Lock()
+	srv.secrets["cycle-b"].AliasTarget = "cycle-a"
+	srv.secretsMu.Unlock()
+
+	getResp, err := srv.Get(ctx, &common.GetRequest{Name: "cycle-a", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if getResp.Success {
+		t.Errorf("Expected get through an alias cycle to fail")
+	}
+}
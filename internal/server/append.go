@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chainguard-dev/clog"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+// Append implements the Append RPC. It appends data to an existing secret's
+// plaintext entirely server-side: decrypt, concatenate, re-encrypt under a
+// fresh salt, store. Doing it here instead of a client read-modify-write
+// keeps the accumulated value (e.g. a certificate chain being assembled from
+// several sources) from ever crossing to the client. The whole sequence runs
+// under the secrets lock, so concurrent appends serialize instead of losing
+// updates. A missing secret is created with the default TTL when the request
+// asks for that, and is an error otherwise.
+func (s *Server) Append(ctx context.Context, req *common.AppendRequest) (*common.AppendResponse, error) {
+	s.updateActivity()
+
+	clog.FromContext(ctx).Debugf("Append request for secret: %s", req.Name)
+
+	if req.Name == "" {
+		return &common.AppendResponse{
+			Success: false,
+			Error:   "secret name is required",
+		}, nil
+	}
+	if len(req.Data) == 0 {
+		return &common.AppendResponse{
+			Success: false,
+			Error:   "no data to append",
+		}, nil
+	}
+
+	// Get client PID and verify binary
+	authInfo, err := GetPeerAuthInfo(ctx)
+	if err != nil {
+		return &common.AppendResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get client credentials: %v", err),
+		}, nil
+	}
+
+	clientHash, err := s.clientKeyHash(authInfo)
+	if err != nil {
+		return &common.AppendResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to verify client binary: %v", err),
+		}, nil
+	}
+
+	// Hold the lock across the whole decrypt-append-reencrypt cycle so
+	// concurrent appends to the same secret serialize
+	s.secretsMu.Lock()
+	defer s.secretsMu.Unlock()
+
+	now := s.now()
+	name, metadata, aliasErr := s.resolveAliasLocked(req.Name)
+	exists := aliasErr == nil && metadata.State(now) == secrets.StateLive
+
+	// Start from the existing plaintext, or from nothing when creating. The
+	// plaintext stays in byte slices throughout — a string hop here would
+	// leave unwipeable copies of the accumulating secret behind.
+	var plaintext []byte
+	var ttl time.Duration
+	if exists {
+		payload, err := s.storage.Get(ctx, name)
+		if err != nil {
+			return &common.AppendResponse{
+				Success: false,
+				Error:   fmt.Sprintf("failed to retrieve secret from storage: %v", err),
+			}, nil
+		}
+
+		key, err := common.DeriveKey(clientHash, req.ClientNonce, s.sessionID, name, payload.Salt)
+		if err != nil {
+			return &common.AppendResponse{
+				Success: false,
+				Error:   fmt.Sprintf("failed to derive key: %v", err),
+			}, nil
+		}
+		plaintext, err = decryptStoredBytes(payload, key)
+		common.ZeroBytes(key)
+		if err != nil {
+			return &common.AppendResponse{
+				Success: false,
+				Error:   "failed to decrypt secret (client verification failed)",
+			}, nil
+		}
+		ttl = metadata.InactivityTTL
+	} else {
+		if !req.CreateIfMissing {
+			return &common.AppendResponse{
+				Success: false,
+				Error:   "secret not found",
+			}, nil
+		}
+		if len(s.secrets) >= s.options.MaxSecrets {
+			return &common.AppendResponse{
+				Success: false,
+				Error:   fmt.Sprintf("maximum number of secrets (%d) reached", s.options.MaxSecrets),
+			}, nil
+		}
+		name = req.Name
+		ttl = s.options.DefaultTTL
+	}
+
+	combined := make([]byte, 0, len(plaintext)+len(req.Data))
+	combined = append(combined, plaintext...)
+	combined = append(combined, req.Data...)
+	common.ZeroBytes(plaintext)
+	common.ZeroBytes(req.Data)
+	// Wipe the accumulated value on every exit, including panics
+	defer common.ZeroBytes(combined)
+	if int64(len(combined)) > s.options.MaxSecretSize {
+		return &common.AppendResponse{
+			Success: false,
+			Error: fmt.Sprintf("appended secret size (%d bytes) exceeds maximum allowed size (%d bytes)",
+				len(combined), s.options.MaxSecretSize),
+		}, nil
+	}
+
+	// Re-encrypt under a fresh salt (and therefore a fresh key)
+	salt, err := common.GenerateSaltFrom(s.deps.Reader())
+	if err != nil {
+		return &common.AppendResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to generate salt: %v", err),
+		}, nil
+	}
+	key, err := common.DeriveKey(clientHash, req.ClientNonce, s.sessionID, name, salt)
+	if err != nil {
+		return &common.AppendResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to derive key: %v", err),
+		}, nil
+	}
+	// Wipe out the key from memory when we are done. This is deliberately a
+	// defer so the key is also wiped when a later step panics.
+	defer common.ZeroBytes(key)
+	observeDerivedKey(key)
+
+	encrypted, err := common.EncryptBytes(combined, key)
+	if err != nil {
+		return &common.AppendResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to encrypt secret: %v", err),
+		}, nil
+	}
+
+	if err := s.storage.Store(ctx, name, &secrets.Payload{
+		EncryptedData:    encrypted,
+		Salt:             salt,
+		ClientBinaryHash: clientHash,
+	}); err != nil {
+		return &common.AppendResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to store secret in backend: %v", err),
+		}, nil
+	}
+
+	if exists {
+		metadata.LastAccessed = now
+		metadata.PlaintextLength = int64(len(combined))
+	} else {
+		s.secrets[name] = &secrets.Metadata{
+			Name:            name,
+			InactivityTTL:   ttl,
+			LastAccessed:    now,
+			CreatedAt:       now,
+			OwnerUID:        authInfo.UID,
+			PlaintextLength: int64(len(combined)),
+		}
+	}
+
+	clog.FromContext(ctx).Debugf("Appended %d byte(s) to secret '%s'", len(req.Data), name)
+
+	return &common.AppendResponse{Success: true}, nil
+}
@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	isecrets "github.com/carabiner-dev/burnafter/internal/secrets"
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func TestAppendToExistingSecret(t *testing.T) {
+	srv := newTestServer(t)
+	ctx := testPeerContext()
+
+	storeResp, err := srv.Store(ctx, &common.StoreRequest{
+		Name:        "chain",
+		Secret:      []byte("cert-one\n"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, storeResp.GetError())
+	}
+
+	for _, part := range []string{"cert-two\n", "cert-three\n"} {
+		resp, err := srv.Append(ctx, &common.AppendRequest{
+			Name:        "chain",
+			Data:        []byte(part),
+			ClientNonce: "test-nonce",
+		})
+		if err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+		if !resp.Success {
+			t.Fatalf("Append error: %s", resp.Error)
+		}
+	}
+
+	getResp, err := srv.Get(ctx, &common.GetRequest{Name: "chain", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !getResp.Success {
+		t.Fatalf("Get error: %s", getResp.Error)
+	}
+	if want := "cert-one\ncert-two\ncert-three\n"; string(getResp.Secret) != want {
+		t.Errorf("Expected %q, got %q", want, getResp.Secret)
+	}
+}
+
+func TestAppendToMissingSecret(t *testing.T) {
+	srv := newTestServer(t)
+	ctx := testPeerContext()
+
+	// Without the create flag a missing secret fails the append
+	resp, err := srv.Append(ctx, &common.AppendRequest{
+		Name:        "absent",
+		Data:        []byte("data"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if resp.Success {
+		t.Fatalf("Expected an append to a missing secret to fail")
+	}
+
+	// With it, the append creates the secret
+	resp, err = srv.Append(ctx, &common.AppendRequest{
+		Name:            "absent",
+		Data:            []byte("data"),
+		ClientNonce:     "test-nonce",
+		CreateIfMissing: true,
+	})
+	if err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("Append error: %s", resp.Error)
+	}
+
+	getResp, err := srv.Get(ctx, &common.GetRequest{Name: "absent", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !getResp.Success {
+		t.Fatalf("Get error: %s", getResp.Error)
+	}
+	if string(getResp.Secret) != "data" {
+		t.Errorf("Expected data, got %q", getResp.Secret)
+	}
+
+	// The created secret carries the server's default inactivity TTL
+	srv.secretsMu.RLock()
+	ttl := srv.secrets["absent"].InactivityTTL
+	srv.secretsMu.RUnlock()
+	if ttl != srv.options.DefaultTTL {
+		t.Errorf("Expected the default TTL %v, got %v", srv.options.DefaultTTL, ttl)
+	}
+}
+
+func TestAppendSizeLimit(t *testing.T) {
+	opts := *options.DefaultServer
+	opts.MaxSecretSize = 16
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	srv.storage = isecrets.NewMemoryStorage()
+	ctx := testPeerContext()
+
+	resp, err := srv.Append(ctx, &common.AppendRequest{
+		Name:            "bounded",
+		Data:            []byte("0123456789"),
+		ClientNonce:     "test-nonce",
+		CreateIfMissing: true,
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("Append failed: %v (%s)", err, resp.GetError())
+	}
+
+	// The second append would push the secret past the size limit
+	resp, err = srv.Append(ctx, &common.AppendRequest{
+		Name:        "bounded",
+		Data:        []byte("0123456789"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if resp.Success {
+		t.Fatalf("Expected an append past the size limit to be rejected")
+	}
+}
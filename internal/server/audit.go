@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// auditRecorder accumulates one audit event while its handler runs. Handlers
+// open one with beginAudit, fill in details as they become known (the
+// verified binary hash, the resolved secret name) and emit the event with
+// finish once the outcome is decided. A nil recorder — the case when no sink
+// is configured — makes every method a no-op, so handlers need no guards.
+type auditRecorder struct {
+	sink  options.AuditSink
+	ctx   context.Context //nolint:containedctx // carries the request logger to Record
+	now   func() time.Time
+	event options.AuditEvent
+}
+
+// beginAudit opens an audit record for one operation, capturing the caller's
+// credentials from the peer context when they are available. It returns nil
+// when no sink is configured.
+func (s *Server) beginAudit(ctx context.Context, operation, secretName string) *auditRecorder {
+	if s.auditSink == nil {
+		return nil
+	}
+	r := &auditRecorder{
+		sink: s.auditSink,
+		ctx:  ctx,
+		now:  s.now,
+		event: options.AuditEvent{
+			Operation:  operation,
+			SecretName: secretName,
+		},
+	}
+	if authInfo, err := GetPeerAuthInfo(ctx); err == nil {
+		r.event.PeerPID = authInfo.PID
+		r.event.PeerUID = authInfo.UID
+	}
+	return r
+}
+
+// setBinaryHash records the caller's verified binary fingerprint once the
+// handler has computed it.
+func (r *auditRecorder) setBinaryHash(hash string) {
+	if r != nil {
+		r.event.BinaryHash = hash
+	}
+}
+
+// setName updates the secret name after an indirection (alias chain, retrieval
+// handle) has been resolved, so the event names the secret actually touched.
+func (r *auditRecorder) setName(name string) {
+	if r != nil {
+		r.event.SecretName = name
+	}
+}
+
+// finish stamps the outcome and hands the event to the sink.
+func (r *auditRecorder) finish(success bool, errMsg string) {
+	if r == nil {
+		return
+	}
+	r.event.Timestamp = r.now()
+	r.event.Success = success
+	if !success {
+		r.event.Error = errMsg
+	}
+	r.sink.Record(r.ctx, r.event)
+}
+
+// auditExpiry emits the event for a secret removed by the cleanup sweep.
+// There is no caller to identify: the server's own lifecycle retired the
+// secret, so the peer fields stay zero.
+func (s *Server) auditExpiry(name string) {
+	if s.auditSink == nil {
+		return
+	}
+	s.auditSink.Record(s.ctx, options.AuditEvent{
+		Operation:  "expire",
+		SecretName: name,
+		Timestamp:  s.now(),
+		Success:    true,
+	})
+}
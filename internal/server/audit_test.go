@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	isecrets "github.com/carabiner-dev/burnafter/internal/secrets"
+	"github.com/carabiner-dev/burnafter/options"
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+// newAuditTestServer builds a server whose audit trail lands in the returned
+// buffer as JSON lines, exercising the default sink end to end.
+func newAuditTestServer(t *testing.T) (*Server, *bytes.Buffer) {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	opts := *options.DefaultServer
+	opts.AuditSink = options.NewJSONAuditSink(buf)
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	srv.storage = isecrets.NewMemoryStorage()
+	return srv, buf
+}
+
+// decodeAuditEvents parses the JSON lines the sink wrote so far.
+func decodeAuditEvents(t *testing.T, buf *bytes.Buffer) []options.AuditEvent {
+	t.Helper()
+
+	var events []options.AuditEvent
+	decoder := json.NewDecoder(bytes.NewReader(buf.Bytes()))
+	for decoder.More() {
+		var event options.AuditEvent
+		if err := decoder.Decode(&event); err != nil {
+			t.Fatalf("decoding audit line: %v", err)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestAuditTrailCoversOperations(t *testing.T) {
+	srv, buf := newAuditTestServer(t)
+	ctx := testPeerContext()
+
+	storeResp, err := srv.Store(ctx, &common.StoreRequest{
+		Name:        "audited",
+		Secret:      []byte("value"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v %s", err, storeResp.GetError())
+	}
+	if _, err := srv.Get(ctx, &common.GetRequest{Name: "audited", ClientNonce: "test-nonce"}); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if _, err := srv.Get(ctx, &common.GetRequest{Name: "missing", ClientNonce: "test-nonce"}); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if _, err := srv.Delete(ctx, &common.DeleteRequest{Name: "audited"}); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	events := decodeAuditEvents(t, buf)
+	if len(events) != 4 {
+		t.Fatalf("Expected 4 audit events, got %d", len(events))
+	}
+
+	for i, expected := range []struct {
+		operation string
+		name      string
+		success   bool
+	}{
+		{"store", "audited", true},
+		{"get", "audited", true},
+		{"get", "missing", false},
+		{"delete", "audited", true},
+	} {
+		event := events[i]
+		if event.Operation != expected.operation || event.SecretName != expected.name || event.Success != expected.success {
+			t.Errorf("Event %d: expected %v, got %s/%s success=%v",
+				i, expected, event.Operation, event.SecretName, event.Success)
+		}
+		if event.PeerPID != int32(os.Getpid()) || event.PeerUID != uint32(os.Getuid()) { //nolint:gosec // PIDs fit in int32
+			t.Errorf("Event %d: expected the test process credentials, got pid %d uid %d",
+				i, event.PeerPID, event.PeerUID)
+		}
+		if event.Timestamp.IsZero() {
+			t.Errorf("Event %d: expected a timestamp", i)
+		}
+	}
+
+	// Successful operations carry the caller's verified hash, failures carry
+	// the error the client saw, and no event carries the secret value
+	if events[0].BinaryHash == "" {
+		t.Errorf("Expected the store event to carry the binary hash")
+	}
+	if events[2].Error == "" {
+		t.Errorf("Expected the failed get event to carry the error message")
+	}
+	if bytes.Contains(buf.Bytes(), []byte("value")) {
+		t.Errorf("Expected no secret value in the audit trail")
+	}
+}
+
+func TestAuditTrailExpiry(t *testing.T) {
+	srv, buf := newAuditTestServer(t)
+
+	// Plant a secret whose inactivity window has long passed and sweep it
+	srv.secretsMu.Lock()
+	srv.secrets["stale"] = &secrets.Metadata{
+		Name:          "stale",
+		InactivityTTL: time.Second,
+		LastAccessed:  time.Now().Add(-time.Hour),
+	}
+	srv.secretsMu.Unlock()
+	srv.runCleanupSweep()
+
+	events := decodeAuditEvents(t, buf)
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 audit event, got %d", len(events))
+	}
+	event := events[0]
+	if event.Operation != "expire" || event.SecretName != "stale" || !event.Success {
+		t.Errorf("Expected a successful expire event for 'stale', got %+v", event)
+	}
+	if event.PeerPID != 0 || event.PeerUID != 0 {
+		t.Errorf("Expected no peer credentials on an expiry event, got pid %d uid %d",
+			event.PeerPID, event.PeerUID)
+	}
+}
+
+func TestAuditLogPath(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "audit.jsonl")
+	opts := *options.DefaultServer
+	opts.AuditLogPath = logPath
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	srv.storage = isecrets.NewMemoryStorage()
+
+	storeResp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:        "filed",
+		Secret:      []byte("value"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v %s", err, storeResp.GetError())
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("reading audit log: %v", err)
+	}
+	var event options.AuditEvent
+	if err := json.Unmarshal(bytes.TrimSpace(data), &event); err != nil {
+		t.Fatalf("parsing audit log line: %v", err)
+	}
+	if event.Operation != "store" || event.SecretName != "filed" || !event.Success {
+		t.Errorf("Expected a successful store event in the log file, got %+v", event)
+	}
+}
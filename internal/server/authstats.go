@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import "sync/atomic"
+
+// authFailureCounters tallies failed client authorizations by class, feeding
+// security monitoring. The split matters: a hash mismatch from the secret's
+// own UID is the signature of a binary upgrade, while one from a different
+// UID is the signature of a foreign binary probing for someone else's
+// secrets. Peer-credential failures (no usable SO_PEERCRED, unreadable
+// binary) are counted separately since they happen before any hash can be
+// compared.
+type authFailureCounters struct {
+	peerCredential   atomic.Int64
+	mismatchSameUID  atomic.Int64
+	mismatchOtherUID atomic.Int64
+}
+
+// AuthFailureCounts is a point-in-time snapshot of the server's failed
+// authorization counters.
+type AuthFailureCounts struct {
+	// PeerCredential counts requests whose peer credentials could not be
+	// resolved or whose binary could not be verified
+	PeerCredential int64
+	// HashMismatchSameUID counts binary-hash mismatches from the UID that
+	// stored the secret (the "legit upgrade" signature)
+	HashMismatchSameUID int64
+	// HashMismatchOtherUID counts binary-hash mismatches from a different UID
+	// (the "foreign binary" signature)
+	HashMismatchOtherUID int64
+}
+
+// AuthFailureCounts returns a snapshot of the failed authorization counters
+// accumulated since the server started.
+func (s *Server) AuthFailureCounts() AuthFailureCounts {
+	return AuthFailureCounts{
+		PeerCredential:       s.authFailures.peerCredential.Load(),
+		HashMismatchSameUID:  s.authFailures.mismatchSameUID.Load(),
+		HashMismatchOtherUID: s.authFailures.mismatchOtherUID.Load(),
+	}
+}
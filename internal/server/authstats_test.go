@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/peer"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+func TestAuthFailureCountsHashMismatch(t *testing.T) {
+	srv := newTestServer(t)
+	ctx := testPeerContext()
+
+	storeResp, err := srv.Store(ctx, &common.StoreRequest{
+		Name: "watched", Secret: []byte("value"), ClientNonce: "test-nonce",
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, storeResp.GetError())
+	}
+	rebindToFakeHash(t, srv, "watched", "test-nonce", "previous-binary-hash")
+
+	// Same UID, different hash: the "legit upgrade" signature
+	getResp, err := srv.Get(ctx, &common.GetRequest{Name: "watched", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if getResp.Success {
+		t.Fatalf("Expected the mismatching Get to be denied")
+	}
+	counts := srv.AuthFailureCounts()
+	if counts.HashMismatchSameUID != 1 {
+		t.Errorf("Expected 1 same-UID mismatch, got %d", counts.HashMismatchSameUID)
+	}
+	if counts.HashMismatchOtherUID != 0 {
+		t.Errorf("Expected 0 other-UID mismatches, got %d", counts.HashMismatchOtherUID)
+	}
+
+	// Different UID and hash: the "foreign binary" signature
+	srv.secretsMu.Lock()
+	srv.secrets["watched"].OwnerUID++
+	srv.secretsMu.Unlock()
+
+	getResp, err = srv.Get(ctx, &common.GetRequest{Name: "watched", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if getResp.Success {
+		t.Fatalf("Expected the foreign Get to be denied")
+	}
+	counts = srv.AuthFailureCounts()
+	if counts.HashMismatchSameUID != 1 {
+		t.Errorf("Expected the same-UID count to stay at 1, got %d", counts.HashMismatchSameUID)
+	}
+	if counts.HashMismatchOtherUID != 1 {
+		t.Errorf("Expected 1 other-UID mismatch, got %d", counts.HashMismatchOtherUID)
+	}
+}
+
+func TestAuthFailureCountsPeerCredential(t *testing.T) {
+	srv := newTestServer(t)
+
+	// A context without peer credentials fails before any hash comparison
+	resp, err := srv.Get(context.Background(), &common.GetRequest{Name: "x", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if resp.Success {
+		t.Fatalf("Expected a credential-less Get to fail")
+	}
+	if counts := srv.AuthFailureCounts(); counts.PeerCredential != 1 {
+		t.Errorf("Expected 1 peer-credential failure, got %d", counts.PeerCredential)
+	}
+
+	// A peer whose PID the kernel did not report is rejected (by default)
+	// before any hash comparison too
+	pidless := peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: &peerAuthInfo{PID: 0, UID: 0, GID: 0},
+	})
+	storeResp, err := srv.Store(pidless, &common.StoreRequest{
+		Name: "x", Secret: []byte("v"), ClientNonce: "test-nonce",
+	})
+	if err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if storeResp.Success {
+		t.Fatalf("Expected a PID-less Store to fail")
+	}
+	if counts := srv.AuthFailureCounts(); counts.PeerCredential != 2 {
+		t.Errorf("Expected 2 peer-credential failures, got %d", counts.PeerCredential)
+	}
+}
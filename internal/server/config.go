@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+
+	"github.com/chainguard-dev/clog"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+// GetConfig implements the GetConfig RPC. It reports the server's effective
+// non-secret configuration so clients can confirm the daemon is running with
+// the options they expect — options travel over the argv-JSON channel and a
+// mis-parsed set would otherwise only surface as odd behavior. Secret names
+// and values are never included.
+func (s *Server) GetConfig(ctx context.Context, req *common.GetConfigRequest) (*common.GetConfigResponse, error) {
+	s.updateActivity()
+
+	clog.FromContext(ctx).Debugf("GetConfig request")
+
+	resp := &common.GetConfigResponse{
+		Success:                  true,
+		DefaultTtlSeconds:        int64(s.options.DefaultTTL.Seconds()),
+		MaxSecrets:               int64(s.options.MaxSecrets),
+		MaxSecretSize:            s.options.MaxSecretSize,
+		InactivityTimeoutSeconds: int64(s.options.InactivityTimeout.Seconds()),
+		Backend:                  s.storage.Backend(),
+		CleanupIntervalSeconds:   int64(cleanupInterval.Seconds()),
+	}
+	if req.IncludeStats {
+		resp.Stats = s.secretSizeStats()
+	}
+
+	return resp, nil
+}
+
+// secretSizeStats aggregates the plaintext sizes recorded at store time, so
+// operators can set the capacity options from the actual size distribution.
+// Alias entries hold no payload and are not counted.
+func (s *Server) secretSizeStats() *common.SecretStats {
+	s.secretsMu.RLock()
+	defer s.secretsMu.RUnlock()
+
+	stats := &common.SecretStats{}
+	for _, metadata := range s.secrets {
+		if metadata.AliasTarget != "" {
+			continue
+		}
+		size := metadata.PlaintextLength
+		if stats.Count == 0 || size < stats.MinBytes {
+			stats.MinBytes = size
+		}
+		if size > stats.MaxBytes {
+			stats.MaxBytes = size
+		}
+		stats.Count++
+		stats.TotalBytes += size
+	}
+	if stats.Count > 0 {
+		stats.MeanBytes = float64(stats.TotalBytes) / float64(stats.Count)
+	}
+	return stats
+}
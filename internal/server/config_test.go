@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	isecrets "github.com/carabiner-dev/burnafter/internal/secrets"
+	"github.com/carabiner-dev/burnafter/options"
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+func TestGetConfig(t *testing.T) {
+	opts := *options.DefaultServer
+	opts.DefaultTTL = 90 * time.Minute
+	opts.MaxSecrets = 42
+	opts.MaxSecretSize = 2048
+	opts.InactivityTimeout = 30 * time.Second
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	srv.storage = isecrets.NewMemoryStorage()
+
+	resp, err := srv.GetConfig(context.Background(), &common.GetConfigRequest{})
+	if err != nil {
+		t.Fatalf("GetConfig failed: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("Expected success, got error: %s", resp.Error)
+	}
+
+	if resp.DefaultTtlSeconds != int64(opts.DefaultTTL.Seconds()) {
+		t.Errorf("Expected default TTL %d, got %d", int64(opts.DefaultTTL.Seconds()), resp.DefaultTtlSeconds)
+	}
+	if resp.MaxSecrets != int64(opts.MaxSecrets) {
+		t.Errorf("Expected max secrets %d, got %d", opts.MaxSecrets, resp.MaxSecrets)
+	}
+	if resp.MaxSecretSize != opts.MaxSecretSize {
+		t.Errorf("Expected max secret size %d, got %d", opts.MaxSecretSize, resp.MaxSecretSize)
+	}
+	if resp.InactivityTimeoutSeconds != int64(opts.InactivityTimeout.Seconds()) {
+		t.Errorf("Expected inactivity timeout %d, got %d",
+			int64(opts.InactivityTimeout.Seconds()), resp.InactivityTimeoutSeconds)
+	}
+	if resp.Backend != "memory" {
+		t.Errorf("Expected memory backend, got %q", resp.Backend)
+	}
+	if resp.CleanupIntervalSeconds != int64(cleanupInterval.Seconds()) {
+		t.Errorf("Expected cleanup interval %d, got %d",
+			int64(cleanupInterval.Seconds()), resp.CleanupIntervalSeconds)
+	}
+}
+
+func TestGetConfigStats(t *testing.T) {
+	srv := newTestServer(t)
+
+	// Three differently-sized secrets plus an alias, which holds no payload
+	// and must not count
+	for name, secret := range map[string]string{
+		"stats-small":  "abcd",
+		"stats-medium": "0123456789",
+		"stats-large":  strings.Repeat("x", 100),
+	} {
+		resp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+			Name:        name,
+			Secret:      []byte(secret),
+			ClientNonce: "test-nonce",
+		})
+		if err != nil || !resp.Success {
+			t.Fatalf("Store failed: %v (%s)", err, resp.GetError())
+		}
+	}
+	srv.secretsMu.Lock()
+	srv.secrets["stats-alias"] = &secrets.Metadata{
+		Name:        "stats-alias",
+		AliasTarget: "stats-small",
+	}
+	srv.secretsMu.Unlock()
+
+	// Without the flag the response carries no statistics
+	resp, err := srv.GetConfig(context.Background(), &common.GetConfigRequest{})
+	if err != nil {
+		t.Fatalf("GetConfig failed: %v", err)
+	}
+	if resp.Stats != nil {
+		t.Errorf("Expected no stats without include_stats")
+	}
+
+	resp, err = srv.GetConfig(context.Background(), &common.GetConfigRequest{IncludeStats: true})
+	if err != nil {
+		t.Fatalf("GetConfig failed: %v", err)
+	}
+	stats := resp.Stats
+	if stats == nil {
+		t.Fatalf("Expected stats in the response")
+	}
+	if stats.Count != 3 {
+		t.Errorf("Expected a count of 3, got %d", stats.Count)
+	}
+	if stats.TotalBytes != 114 {
+		t.Errorf("Expected 114 total bytes, got %d", stats.TotalBytes)
+	}
+	if stats.MinBytes != 4 {
+		t.Errorf("Expected a 4 byte minimum, got %d", stats.MinBytes)
+	}
+	if stats.MaxBytes != 100 {
+		t.Errorf("Expected a 100 byte maximum, got %d", stats.MaxBytes)
+	}
+	if stats.MeanBytes != 38 {
+		t.Errorf("Expected a mean of 38 bytes, got %f", stats.MeanBytes)
+	}
+}
+
+func TestGetConfigStatsEmpty(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := srv.GetConfig(context.Background(), &common.GetConfigRequest{IncludeStats: true})
+	if err != nil {
+		t.Fatalf("GetConfig failed: %v", err)
+	}
+	if resp.Stats == nil {
+		t.Fatalf("Expected stats in the response")
+	}
+	if resp.Stats.Count != 0 || resp.Stats.TotalBytes != 0 || resp.Stats.MeanBytes != 0 {
+		t.Errorf("Expected zeroed stats on an empty server")
+	}
+}
@@ -32,7 +32,9 @@ func (c *peerCredentials) ServerHandshake(rawConn net.Conn) (net.Conn, credentia
 	// Extract peer credentials from Unix socket
 	unixConn, ok := rawConn.(*net.UnixConn)
 	if !ok {
-		return rawConn, &peerAuthInfo{}, nil
+		// Not a Unix socket: on Windows this is a named pipe carrying the
+		// client PID, elsewhere there is nothing to extract.
+		return rawConn, pipeAuthInfo(rawConn), nil
 	}
 
 	pid, uid, gid, err := GetPeerCredentials(unixConn)
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/chainguard-dev/clog"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+// SetDebug implements the SetDebug RPC. It toggles the server's debug logging
+// at runtime by swapping the logger injected into request contexts, so a live
+// daemon can be debugged without a restart (which would discard its secrets).
+// The RPC is rejected unless the server was started with AllowRuntimeDebug.
+func (s *Server) SetDebug(ctx context.Context, req *common.SetDebugRequest) (*common.SetDebugResponse, error) {
+	s.updateActivity()
+
+	if !s.options.AllowRuntimeDebug {
+		return &common.SetDebugResponse{
+			Success: false,
+			Error:   "runtime debug toggling is not enabled on this server",
+		}, nil
+	}
+
+	if req.Enabled {
+		s.swapLogger(clog.New(debugLogHandler(s.options.LogFormat, s.logWriter)))
+		s.requestLogger().Debug("Runtime debug logging enabled")
+	} else {
+		s.requestLogger().Debug("Runtime debug logging disabled")
+		s.swapLogger(clog.New(silentLogHandler{}))
+	}
+
+	return &common.SetDebugResponse{Success: true}, nil
+}
+
+// requestLogger returns the logger currently injected into request contexts.
+func (s *Server) requestLogger() *clog.Logger {
+	s.logMu.RLock()
+	defer s.logMu.RUnlock()
+	return s.logger
+}
+
+// swapLogger replaces the logger injected into request contexts.
+func (s *Server) swapLogger(logger *clog.Logger) {
+	s.logMu.Lock()
+	s.logger = logger
+	s.logMu.Unlock()
+}
+
+// debugLogHandler builds a debug-level slog handler in the server's configured
+// log format.
+func debugLogHandler(format string, w io.Writer) slog.Handler {
+	opts := &slog.HandlerOptions{Level: slog.LevelDebug}
+	if format == "json" {
+		return slog.NewJSONHandler(w, opts)
+	}
+	return slog.NewTextHandler(w, opts)
+}
+
+// silentLogHandler is an slog handler that discards everything, used when
+// debug logging is toggled off at runtime.
+type silentLogHandler struct{}
+
+func (silentLogHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (silentLogHandler) Handle(context.Context, slog.Record) error { return nil }
+func (silentLogHandler) WithAttrs([]slog.Attr) slog.Handler        { return silentLogHandler{} }
+func (silentLogHandler) WithGroup(string) slog.Handler             { return silentLogHandler{} }
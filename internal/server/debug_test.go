@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	isecrets "github.com/carabiner-dev/burnafter/internal/secrets"
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// newDebugTestServer creates a server with runtime debug toggling enabled and
+// its log output captured in the returned buffer.
+func newDebugTestServer(t *testing.T) (*Server, *bytes.Buffer) {
+	t.Helper()
+
+	opts := *options.DefaultServer
+	opts.AllowRuntimeDebug = true
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	srv.storage = isecrets.NewMemoryStorage()
+
+	var buf bytes.Buffer
+	srv.logWriter = &buf
+	return srv, &buf
+}
+
+// loggedGet runs a Get request through the logger interceptor, the same path
+// real RPCs take, so the request context carries the server's current logger.
+func loggedGet(t *testing.T, srv *Server, ctx context.Context) {
+	t.Helper()
+
+	if _, err := srv.loggerInterceptor(ctx, &common.GetRequest{Name: "debug-probe"}, nil,
+		func(ctx context.Context, req any) (any, error) {
+			return srv.Get(ctx, req.(*common.GetRequest)) //nolint:forcetypeassert
+		}); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+}
+
+func TestSetDebugDisabledByDefault(t *testing.T) {
+	srv := newTestServer(t)
+	ctx := testPeerContext()
+
+	resp, err := srv.SetDebug(ctx, &common.SetDebugRequest{Enabled: true})
+	if err != nil {
+		t.Fatalf("SetDebug failed: %v", err)
+	}
+	if resp.Success {
+		t.Errorf("Expected SetDebug to be rejected without AllowRuntimeDebug")
+	}
+	if !strings.Contains(resp.Error, "not enabled") {
+		t.Errorf("Unexpected error message: %s", resp.Error)
+	}
+}
+
+func TestSetDebugToggle(t *testing.T) {
+	srv, buf := newDebugTestServer(t)
+	ctx := testPeerContext()
+
+	// Before toggling, operations must not log
+	loggedGet(t, srv, ctx)
+	if buf.Len() != 0 {
+		t.Fatalf("Expected no output before enabling runtime debug, got %q", buf.String())
+	}
+
+	// Enable debug logging at runtime
+	resp, err := srv.SetDebug(ctx, &common.SetDebugRequest{Enabled: true})
+	if err != nil {
+		t.Fatalf("SetDebug failed: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("SetDebug rejected: %s", resp.Error)
+	}
+
+	// Subsequent operations routed through the logger interceptor must log
+	loggedGet(t, srv, ctx)
+	if !strings.Contains(buf.String(), "debug-probe") {
+		t.Errorf("Expected debug output after enabling runtime debug, got %q", buf.String())
+	}
+
+	// Disable again: the log must go quiet
+	if resp, err := srv.SetDebug(ctx, &common.SetDebugRequest{Enabled: false}); err != nil || !resp.Success {
+		t.Fatalf("SetDebug disable failed: %v (%s)", err, resp.GetError())
+	}
+	buf.Reset()
+	loggedGet(t, srv, ctx)
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output after disabling runtime debug, got %q", buf.String())
+	}
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chainguard-dev/clog"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+// Delete implements the Delete RPC. It removes a single secret from both the
+// metadata map and the storage backend, after verifying the caller's binary
+// hash against the one recorded at store time — deleting a secret destroys it
+// just as surely as reading it leaks it, so eviction gets the same
+// authorization as retrieval.
+func (s *Server) Delete(ctx context.Context, req *common.DeleteRequest) (resp *common.DeleteResponse, _ error) {
+	s.updateActivity()
+
+	clog.FromContext(ctx).Debugf("Delete request for secret: %s", req.Name)
+
+	audit := s.beginAudit(ctx, "delete", req.Name)
+	defer func() { audit.finish(resp.GetSuccess(), resp.GetError()) }()
+
+	// Get client PID and verify binary
+	authInfo, err := GetPeerAuthInfo(ctx)
+	if err != nil {
+		s.authFailures.peerCredential.Add(1)
+		return &common.DeleteResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get client credentials: %v", err),
+		}, nil
+	}
+
+	clientHash, err := s.clientKeyHash(authInfo)
+	if err != nil {
+		s.authFailures.peerCredential.Add(1)
+		return &common.DeleteResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to verify client binary: %v", err),
+		}, nil
+	}
+	audit.setBinaryHash(clientHash)
+
+	s.secretsMu.Lock()
+	metadata, exists := s.secrets[req.Name]
+	if !exists {
+		s.secretsMu.Unlock()
+		return &common.DeleteResponse{
+			Success:   false,
+			Error:     errSecretNotFound.Error(),
+			ErrorCode: common.ErrorCode_ERROR_NOT_FOUND,
+		}, nil
+	}
+
+	// An alias holds no payload of its own, so there is no stored hash to
+	// check; deleting it only detaches the name and leaves the target intact.
+	if metadata.AliasTarget != "" {
+		delete(s.secrets, req.Name)
+		s.dropHandleLocked(metadata)
+		s.secretsMu.Unlock()
+		clog.FromContext(ctx).Debugf("Deleted alias '%s'", req.Name)
+		return &common.DeleteResponse{Success: true}, nil
+	}
+
+	ownerUID := metadata.OwnerUID
+	s.secretsMu.Unlock()
+
+	// Fetch the payload to check the stored binary hash before destroying it
+	stored, err := s.storage.Get(ctx, req.Name)
+	if err != nil {
+		return &common.DeleteResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to retrieve secret from storage: %v", err),
+		}, nil
+	}
+
+	// Same failure classes as Get: the owner UID with a new hash looks like a
+	// binary upgrade, any other UID looks like a foreign binary trying to
+	// evict a secret it didn't create.
+	if stored.ClientBinaryHash != clientHash {
+		if authInfo.UID == ownerUID {
+			s.authFailures.mismatchSameUID.Add(1)
+			clog.FromContext(ctx).Warnf(
+				"AUDIT: binary hash mismatch deleting secret '%s' from owner uid %d (likely a binary upgrade)",
+				req.Name, authInfo.UID,
+			)
+		} else {
+			s.authFailures.mismatchOtherUID.Add(1)
+			clog.FromContext(ctx).Warnf(
+				"AUDIT: binary hash mismatch deleting secret '%s' from foreign uid %d (stored by uid %d)",
+				req.Name, authInfo.UID, ownerUID,
+			)
+		}
+		common.ZeroBytes(stored.EncryptedData)
+		common.ZeroBytes(stored.Salt)
+		return &common.DeleteResponse{
+			Success:   false,
+			Error:     "client binary hash mismatch - unauthorized",
+			ErrorCode: common.ErrorCode_ERROR_BINARY_MISMATCH,
+		}, nil
+	}
+
+	s.secretsMu.Lock()
+	if metadata, ok := s.secrets[req.Name]; ok {
+		delete(s.secrets, req.Name)
+		s.dropHandleLocked(metadata)
+	}
+	s.secretsMu.Unlock()
+
+	if err := s.storage.Delete(ctx, req.Name); err != nil {
+		clog.FromContext(ctx).Warnf("Failed to delete secret '%s' from storage: %v", req.Name, err)
+	}
+
+	// Wipe the payload copy fetched for the hash check
+	common.ZeroBytes(stored.EncryptedData)
+	common.ZeroBytes(stored.Salt)
+
+	clog.FromContext(ctx).Debugf("Deleted secret '%s'", req.Name)
+	return &common.DeleteResponse{Success: true}, nil
+}
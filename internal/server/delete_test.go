@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+func TestDeleteRemovesStoredSecret(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:        "doomed",
+		Secret:      []byte("value"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, resp.GetError())
+	}
+
+	delResp, err := srv.Delete(testPeerContext(), &common.DeleteRequest{Name: "doomed"})
+	if err != nil || !delResp.Success {
+		t.Fatalf("Delete failed: %v (%s)", err, delResp.GetError())
+	}
+
+	// Both the metadata and the payload must be gone
+	getResp, err := srv.Get(testPeerContext(), &common.GetRequest{Name: "doomed", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if getResp.Success || getResp.Error != "secret not found" {
+		t.Errorf("Expected a not-found after delete, got success=%v error=%q", getResp.Success, getResp.Error)
+	}
+	if _, err := srv.storage.Get(t.Context(), "doomed"); err == nil {
+		t.Errorf("Expected the payload to be gone from the storage backend")
+	}
+}
+
+func TestDeleteMissingSecret(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := srv.Delete(testPeerContext(), &common.DeleteRequest{Name: "never-stored"})
+	if err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if resp.Success || resp.Error != "secret not found" {
+		t.Errorf("Expected a not-found error, got success=%v error=%q", resp.Success, resp.Error)
+	}
+}
+
+func TestDeleteRejectsForeignBinary(t *testing.T) {
+	srv := newTestServer(t)
+
+	// addTestSecret writes a payload with no binary hash, so the caller's
+	// real hash cannot match: this stands in for a secret stored by a
+	// different binary
+	addTestSecret(t, srv, "not-ours", nil, time.Now())
+
+	resp, err := srv.Delete(testPeerContext(), &common.DeleteRequest{Name: "not-ours"})
+	if err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if resp.Success || resp.Error != "client binary hash mismatch - unauthorized" {
+		t.Errorf("Expected an unauthorized error, got success=%v error=%q", resp.Success, resp.Error)
+	}
+
+	// The secret must still be there
+	srv.secretsMu.RLock()
+	_, ok := srv.secrets["not-ours"]
+	srv.secretsMu.RUnlock()
+	if !ok {
+		t.Errorf("Expected the secret to survive an unauthorized delete")
+	}
+}
+
+func TestDeleteAliasLeavesTarget(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:        "target",
+		Secret:      []byte("value"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, resp.GetError())
+	}
+	aliasResp, err := srv.StoreAlias(testPeerContext(), &common.StoreAliasRequest{Alias: "nickname", Target: "target"})
+	if err != nil || !aliasResp.Success {
+		t.Fatalf("StoreAlias failed: %v (%s)", err, aliasResp.GetError())
+	}
+
+	delResp, err := srv.Delete(testPeerContext(), &common.DeleteRequest{Name: "nickname"})
+	if err != nil || !delResp.Success {
+		t.Fatalf("Deleting the alias failed: %v (%s)", err, delResp.GetError())
+	}
+
+	// The target keeps working without its alias
+	getResp, err := srv.Get(testPeerContext(), &common.GetRequest{Name: "target", ClientNonce: "test-nonce"})
+	if err != nil || !getResp.Success {
+		t.Errorf("Expected the alias target to survive, got %v (%s)", err, getResp.GetError())
+	}
+}
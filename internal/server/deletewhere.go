@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/chainguard-dev/clog"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+// DeleteWhere implements the DeleteWhere RPC. It deletes every secret whose
+// metadata matches the request's predicate: all the given labels must be
+// present (with equal values) and, when older_than_seconds is set, the secret
+// must have been stored at least that long ago. An empty predicate is
+// rejected so a caller cannot accidentally burn every secret.
+func (s *Server) DeleteWhere(ctx context.Context, req *common.DeleteWhereRequest) (*common.DeleteWhereResponse, error) {
+	s.updateActivity()
+
+	if len(req.Labels) == 0 && req.OlderThanSeconds <= 0 {
+		return &common.DeleteWhereResponse{
+			Success: false,
+			Error:   "empty predicate: specify labels and/or a minimum age",
+		}, nil
+	}
+
+	now := s.now()
+
+	s.secretsMu.Lock()
+	var matched []string
+	for name, metadata := range s.secrets {
+		if metadataMatches(metadata.Labels, metadata.CreatedAt, req, now) {
+			matched = append(matched, name)
+		}
+	}
+	for _, name := range matched {
+		s.dropHandleLocked(s.secrets[name])
+		delete(s.secrets, name)
+	}
+	s.secretsMu.Unlock()
+
+	// Delete the payloads from the storage backend
+	for _, name := range matched {
+		clog.FromContext(ctx).Debugf("Deleting secret '%s' (matched DeleteWhere predicate)", name)
+		_ = s.storage.Delete(ctx, name) //nolint:errcheck
+	}
+
+	return &common.DeleteWhereResponse{
+		Success: true,
+		Deleted: int64(len(matched)),
+	}, nil
+}
+
+// metadataMatches reports whether a secret's labels and creation time match a
+// DeleteWhere predicate.
+func metadataMatches(labels map[string]string, createdAt time.Time, req *common.DeleteWhereRequest, now time.Time) bool {
+	for k, v := range req.Labels {
+		if labels[k] != v {
+			return false
+		}
+	}
+	if req.OlderThanSeconds > 0 {
+		if now.Sub(createdAt) < time.Duration(req.OlderThanSeconds)*time.Second {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+func TestDeleteWhereByLabel(t *testing.T) {
+	srv := newTestServer(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	addTestSecret(t, srv, "test-a", map[string]string{"env": "test"}, now)
+	addTestSecret(t, srv, "test-b", map[string]string{"env": "test", "team": "ci"}, now)
+	addTestSecret(t, srv, "prod-a", map[string]string{"env": "prod"}, now)
+	addTestSecret(t, srv, "unlabeled", nil, now)
+
+	resp, err := srv.DeleteWhere(ctx, &common.DeleteWhereRequest{
+		Labels: map[string]string{"env": "test"},
+	})
+	if err != nil {
+		t.Fatalf("DeleteWhere failed: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("DeleteWhere error: %s", resp.Error)
+	}
+	if resp.Deleted != 2 {
+		t.Errorf("Expected 2 deletions, got %d", resp.Deleted)
+	}
+
+	// Matching secrets are gone from metadata and storage
+	srv.secretsMu.RLock()
+	_, aExists := srv.secrets["test-a"]
+	_, prodExists := srv.secrets["prod-a"]
+	_, unlabeledExists := srv.secrets["unlabeled"]
+	srv.secretsMu.RUnlock()
+
+	if aExists {
+		t.Errorf("Expected test-a to be deleted")
+	}
+	if !prodExists || !unlabeledExists {
+		t.Errorf("Expected non-matching secrets to remain")
+	}
+	if _, err := srv.storage.Get(ctx, "test-a"); err == nil {
+		t.Errorf("Expected test-a payload to be deleted from storage")
+	}
+	if _, err := srv.storage.Get(ctx, "prod-a"); err != nil {
+		t.Errorf("Expected prod-a payload to remain in storage: %v", err)
+	}
+}
+
+func TestDeleteWhereByAge(t *testing.T) {
+	srv := newTestServer(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	addTestSecret(t, srv, "old", nil, now.Add(-2*time.Hour))
+	addTestSecret(t, srv, "fresh", nil, now)
+
+	resp, err := srv.DeleteWhere(ctx, &common.DeleteWhereRequest{
+		OlderThanSeconds: 3600,
+	})
+	if err != nil {
+		t.Fatalf("DeleteWhere failed: %v", err)
+	}
+	if resp.Deleted != 1 {
+		t.Errorf("Expected 1 deletion, got %d", resp.Deleted)
+	}
+
+	srv.secretsMu.RLock()
+	_, oldExists := srv.secrets["old"]
+	_, freshExists := srv.secrets["fresh"]
+	srv.secretsMu.RUnlock()
+
+	if oldExists {
+		t.Errorf("Expected old secret to be deleted")
+	}
+	if !freshExists {
+		t.Errorf("Expected fresh secret to remain")
+	}
+}
+
+func TestDeleteWhereEmptyPredicate(t *testing.T) {
+	srv := newTestServer(t)
+
+	addTestSecret(t, srv, "keep-me", nil, time.Now())
+
+	resp, err := srv.DeleteWhere(context.Background(), &common.DeleteWhereRequest{})
+	if err != nil {
+		t.Fatalf("DeleteWhere failed: %v", err)
+	}
+	if resp.Success {
+		t.Errorf("Expected an empty predicate to be rejected")
+	}
+
+	srv.secretsMu.RLock()
+	_, exists := srv.secrets["keep-me"]
+	srv.secretsMu.RUnlock()
+	if !exists {
+		t.Errorf("Expected no secrets to be deleted on an empty predicate")
+	}
+}
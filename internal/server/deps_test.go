@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+func TestDepsClockDrivesInactivityExpiry(t *testing.T) {
+	srv := newTestServer(t)
+	now := time.Unix(1700000000, 0)
+	srv.deps = &common.Deps{Clock: func() time.Time { return now }}
+
+	resp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:        "ticking",
+		Secret:      []byte("value"),
+		ClientNonce: "test-nonce",
+		TtlSeconds:  60,
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, resp.GetError())
+	}
+
+	// Within the TTL the secret reads back fine
+	getResp, err := srv.Get(testPeerContext(), &common.GetRequest{Name: "ticking", ClientNonce: "test-nonce"})
+	if err != nil || !getResp.Success {
+		t.Fatalf("Expected a live secret, got %v (%s)", err, getResp.GetError())
+	}
+
+	// Advance the clock past the TTL: the secret inactivity-expires without
+	// the test ever sleeping
+	now = now.Add(2 * time.Minute)
+	getResp, err = srv.Get(testPeerContext(), &common.GetRequest{Name: "ticking", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if getResp.Success || getResp.Error != "secret has expired due to inactivity" {
+		t.Errorf("Expected an inactivity expiry after advancing the clock, got success=%v error=%q",
+			getResp.Success, getResp.Error)
+	}
+}
+
+func TestDepsClockDrivesCleanupSweep(t *testing.T) {
+	srv := newTestServer(t)
+	now := time.Unix(1700000000, 0)
+	srv.deps = &common.Deps{Clock: func() time.Time { return now }}
+
+	addTestSecret(t, srv, "sweepable", nil, now)
+
+	// The sweep sees a fresh secret and leaves it alone
+	srv.runCleanupSweep()
+	srv.secretsMu.RLock()
+	_, ok := srv.secrets["sweepable"]
+	srv.secretsMu.RUnlock()
+	if !ok {
+		t.Fatalf("Expected the sweep to keep a fresh secret")
+	}
+
+	// Jump the clock past the secret's inactivity TTL: the next sweep reaps it
+	now = now.Add(time.Hour + time.Minute)
+	srv.runCleanupSweep()
+	srv.secretsMu.RLock()
+	_, ok = srv.secrets["sweepable"]
+	srv.secretsMu.RUnlock()
+	if ok {
+		t.Errorf("Expected the sweep to reap the secret after advancing the clock")
+	}
+}
+
+func TestDepsRandMakesSaltsDeterministic(t *testing.T) {
+	srv := newTestServer(t)
+	srv.deps = &common.Deps{Rand: bytes.NewReader(bytes.Repeat([]byte{0xAB}, 32))}
+
+	resp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:        "pinned",
+		Secret:      []byte("v"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, resp.GetError())
+	}
+
+	payload, err := srv.storage.Get(t.Context(), "pinned")
+	if err != nil {
+		t.Fatalf("Reading stored payload: %v", err)
+	}
+	if !bytes.Equal(payload.Salt, bytes.Repeat([]byte{0xAB}, 32)) {
+		t.Errorf("Expected the salt to come from the injected reader, got %x", payload.Salt)
+	}
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"testing"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+func TestStoreEchoesDerivationInfo(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:           "echoed",
+		Secret:         []byte("v"),
+		ClientNonce:    "test-nonce",
+		EchoDerivation: true,
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, resp.GetError())
+	}
+
+	d := resp.Derivation
+	if d == nil {
+		t.Fatalf("Expected derivation info in the response")
+	}
+	if d.Kdf != "hkdf-sha256" {
+		t.Errorf("Expected the hkdf-sha256 KDF, got %q", d.Kdf)
+	}
+	if d.Cipher != "aes-256-gcm" {
+		t.Errorf("Expected the aes-256-gcm cipher, got %q", d.Cipher)
+	}
+	if d.SaltLength != 32 {
+		t.Errorf("Expected a 32 byte salt, got %d", d.SaltLength)
+	}
+
+	// The echoed hash must match the fingerprint the client computes for
+	// itself, proving the server read the right binary
+	want, err := common.GetCurrentBinaryHash()
+	if err != nil {
+		t.Fatalf("GetCurrentBinaryHash: %v", err)
+	}
+	if d.ClientBinaryHash != want {
+		t.Errorf("Expected the echoed hash to match our own fingerprint: got %s, want %s",
+			d.ClientBinaryHash, want)
+	}
+}
+
+func TestStoreOmitsDerivationByDefault(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:        "silent",
+		Secret:      []byte("v"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, resp.GetError())
+	}
+	if resp.Derivation != nil {
+		t.Errorf("Expected no derivation info without echo_derivation")
+	}
+}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"strings"
+
+	"github.com/chainguard-dev/clog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+// digestInterceptor is a grpc unary interceptor that rejects RPCs from client
+// binaries whose fingerprint is not in the configured trusted digest list
+// (the TrustedClientDigests option). The list typically comes from an
+// attestation or provenance step, so with it set only attested binaries may
+// use the daemon at all — the per-secret binary gate still applies on top.
+// An empty list preserves the default trust-on-first-store behavior.
+func (s *Server) digestInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := s.admitClientDigest(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// admitClientDigest performs the trusted digest list check shared by the
+// unary and stream interceptors.
+func (s *Server) admitClientDigest(ctx context.Context, fullMethod string) error {
+	if len(s.options.TrustedClientDigests) == 0 {
+		return nil
+	}
+
+	authInfo, err := GetPeerAuthInfo(ctx)
+	if err != nil {
+		return status.Errorf(codes.PermissionDenied, "cannot verify peer credentials: %v", err)
+	}
+
+	// A peer whose binary cannot be resolved (e.g. a PID-less peer across a
+	// namespace boundary) cannot be matched against the list, so it is
+	// rejected even when uid-only verification is otherwise allowed.
+	_, clientHash, err := common.GetClientBinaryInfoWith(s.options.BinaryHashAlgorithm, authInfo.PID)
+	if err != nil {
+		return status.Errorf(codes.PermissionDenied, "cannot verify peer binary: %v", err)
+	}
+
+	for _, digest := range s.options.TrustedClientDigests {
+		if strings.EqualFold(digest, clientHash) {
+			return nil
+		}
+	}
+
+	clog.FromContext(ctx).Warnf(
+		"AUDIT: rejected %s from uid %d: binary digest %s is not in the trusted digest list",
+		fullMethod, authInfo.UID, clientHash,
+	)
+	return status.Errorf(codes.PermissionDenied, "client binary digest is not in the trusted digest list")
+}
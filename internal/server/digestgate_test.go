@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// selfDigest returns the test binary's own fingerprint, the digest the
+// server resolves for requests arriving through testPeerContext.
+func selfDigest(t *testing.T) string {
+	t.Helper()
+
+	exePath, err := os.Executable()
+	if err != nil {
+		t.Fatalf("getting the executable path: %v", err)
+	}
+	digest, err := common.HashFile(exePath)
+	if err != nil {
+		t.Fatalf("hashing the test binary: %v", err)
+	}
+	return digest
+}
+
+func TestDigestInterceptorAllowlist(t *testing.T) {
+	opts := *options.DefaultServer
+	opts.TrustedClientDigests = []string{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		selfDigest(t),
+	}
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+	info := &grpc.UnaryServerInfo{FullMethod: "/burnafter.BurnAfter/Get"}
+
+	// The test binary's own digest is on the list, so its requests pass
+	if _, err := srv.digestInterceptor(testPeerContext(), nil, info, handler); err != nil {
+		t.Errorf("Expected a trusted binary to pass: %v", err)
+	}
+
+	// A peer without credentials cannot be matched, so it is rejected
+	if _, err := srv.digestInterceptor(context.Background(), nil, info, handler); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied without peer credentials, got %v", err)
+	}
+}
+
+func TestDigestInterceptorRejectsUnlisted(t *testing.T) {
+	opts := *options.DefaultServer
+	// Only a digest no real binary hashes to: the test binary is not attested
+	opts.TrustedClientDigests = []string{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+	}
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+	info := &grpc.UnaryServerInfo{FullMethod: "/burnafter.BurnAfter/Store"}
+
+	if _, err := srv.digestInterceptor(testPeerContext(), nil, info, handler); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for an unlisted binary, got %v", err)
+	}
+}
+
+func TestDigestInterceptorEmptyListAllowsAny(t *testing.T) {
+	srv, err := NewServer(context.Background(), options.DefaultServer)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+	info := &grpc.UnaryServerInfo{FullMethod: "/burnafter.BurnAfter/Get"}
+
+	if _, err := srv.digestInterceptor(testPeerContext(), nil, info, handler); err != nil {
+		t.Errorf("Expected any binary to pass with no digest list: %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+func TestGetSetsErrorCodes(t *testing.T) {
+	srv := newTestServer(t)
+	ctx := testPeerContext()
+
+	// A missing secret is classified as not found
+	resp, err := srv.Get(ctx, &common.GetRequest{Name: "absent", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if resp.ErrorCode != common.ErrorCode_ERROR_NOT_FOUND {
+		t.Errorf("Expected ERROR_NOT_FOUND, got %v (%s)", resp.ErrorCode, resp.Error)
+	}
+
+	// A secret stored by a foreign binary is classified as a binary mismatch
+	addTestSecret(t, srv, "foreign", nil, time.Now())
+	resp, err = srv.Get(ctx, &common.GetRequest{Name: "foreign", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if resp.ErrorCode != common.ErrorCode_ERROR_BINARY_MISMATCH {
+		t.Errorf("Expected ERROR_BINARY_MISMATCH, got %v (%s)", resp.ErrorCode, resp.Error)
+	}
+
+	// A sealed secret is classified as not yet valid
+	storeResp, err := srv.Store(ctx, &common.StoreRequest{
+		Name:        "sealed-code",
+		Secret:      []byte("v"),
+		ClientNonce: "test-nonce",
+		NotBefore:   time.Now().Add(time.Hour).Unix(),
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, storeResp.GetError())
+	}
+	resp, err = srv.Get(ctx, &common.GetRequest{Name: "sealed-code", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if resp.ErrorCode != common.ErrorCode_ERROR_NOT_YET_VALID {
+		t.Errorf("Expected ERROR_NOT_YET_VALID, got %v (%s)", resp.ErrorCode, resp.Error)
+	}
+}
+
+func TestDeleteSetsErrorCodes(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := srv.Delete(testPeerContext(), &common.DeleteRequest{Name: "absent"})
+	if err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if resp.ErrorCode != common.ErrorCode_ERROR_NOT_FOUND {
+		t.Errorf("Expected ERROR_NOT_FOUND, got %v (%s)", resp.ErrorCode, resp.Error)
+	}
+}
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	isecrets "github.com/carabiner-dev/burnafter/internal/secrets"
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// newCapacityTestServer creates a server that holds at most two secrets,
+// running the given eviction policy over memory storage.
+func newCapacityTestServer(t *testing.T, policy string) *Server {
+	t.Helper()
+
+	opts := *options.DefaultServer
+	opts.MaxSecrets = 2
+	opts.EvictionPolicy = policy
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	srv.storage = isecrets.NewMemoryStorage()
+	return srv
+}
+
+func TestStoreAtCapacityRejects(t *testing.T) {
+	srv := newCapacityTestServer(t, "")
+	ctx := testPeerContext()
+
+	for _, name := range []string{"first", "second"} {
+		resp, err := srv.Store(ctx, &common.StoreRequest{Name: name, Secret: []byte("v"), ClientNonce: "test-nonce"})
+		if err != nil || !resp.Success {
+			t.Fatalf("Store failed: %v (%s)", err, resp.GetError())
+		}
+	}
+
+	// The default policy refuses a third secret
+	resp, err := srv.Store(ctx, &common.StoreRequest{Name: "third", Secret: []byte("v"), ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if resp.Success {
+		t.Errorf("Expected store at capacity to be rejected")
+	}
+
+	// Overwriting an existing secret is not a new secret and still works
+	resp, err = srv.Store(ctx, &common.StoreRequest{Name: "first", Secret: []byte("v2"), ClientNonce: "test-nonce"})
+	if err != nil || !resp.Success {
+		t.Fatalf("Expected overwrite at capacity to succeed: %v (%s)", err, resp.GetError())
+	}
+}
+
+func TestStoreAtCapacityEvictsLRU(t *testing.T) {
+	srv := newCapacityTestServer(t, "lru")
+	ctx := testPeerContext()
+
+	for _, name := range []string{"oldest", "newer"} {
+		resp, err := srv.Store(ctx, &common.StoreRequest{Name: name, Secret: []byte("v"), ClientNonce: "test-nonce"})
+		if err != nil || !resp.Success {
+			t.Fatalf("Store failed: %v (%s)", err, resp.GetError())
+		}
+	}
+
+	// Make "oldest" the least-recently-accessed entry
+	srv.secretsMu.Lock()
+	srv.secrets["oldest"].LastAccessed = time.Now().Add(-time.Hour)
+	srv.secretsMu.Unlock()
+
+	resp, err := srv.Store(ctx, &common.StoreRequest{Name: "third", Secret: []byte("v3"), ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("Expected LRU store at capacity to succeed, got: %s", resp.Error)
+	}
+
+	// The least-recently-accessed secret was evicted, the others remain
+	getResp, err := srv.Get(ctx, &common.GetRequest{Name: "oldest", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if getResp.Success {
+		t.Errorf("Expected the least-recently-accessed secret to be evicted")
+	}
+	for _, name := range []string{"newer", "third"} {
+		getResp, err := srv.Get(ctx, &common.GetRequest{Name: name, ClientNonce: "test-nonce"})
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if !getResp.Success {
+			t.Errorf("Expected secret %q to survive the eviction: %s", name, getResp.Error)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"fmt"
+	"os"
+
+	"github.com/chainguard-dev/clog"
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+// The shutdown export writes the server's state — encrypted payloads,
+// lifecycle metadata and the session ID — to a passphrase-protected bundle as
+// the server's last act before it goes away. Unlike the periodic snapshot,
+// which is keyed to the server binary so only a respawned server can read it,
+// the bundle key is derived from an operator-supplied passphrase, so the
+// bundle can travel: to another host for migration, or to a debugger's bench
+// after an unexpected idle shutdown. The secrets inside stay under their
+// original per-client encryption; no plaintext is ever written.
+
+// exportVersion identifies the bundle file layout:
+// [version:1][salt:32][aes-gcm blob]
+const exportVersion = byte(1)
+
+// exportKeyIterations is the PBKDF2 work factor for the bundle key. The
+// passphrase is the weakest input in the scheme, so stretching it matters
+// more here than in the binary-hash-keyed snapshot.
+const exportKeyIterations = 600000
+
+// exportKey derives the bundle encryption key from the operator passphrase
+// and the given salt.
+func exportKey(passphrase string, salt []byte) []byte {
+	return pbkdf2.Key([]byte(passphrase), salt, exportKeyIterations, 32, sha256.New)
+}
+
+// saveShutdownExport writes the passphrase-protected shutdown bundle to the
+// configured path. It is a no-op when the export is not (fully) configured:
+// a path without a passphrase writes nothing, since the bundle must never
+// leave the server unprotected.
+func (s *Server) saveShutdownExport(ctx context.Context) error {
+	path := s.options.ShutdownExportPath
+	if path == "" {
+		return nil
+	}
+	if s.options.ShutdownExportPassphrase == "" {
+		clog.FromContext(ctx).Warnf("Shutdown export path set without a passphrase, not exporting")
+		return nil
+	}
+
+	// The bundle carries the same state a snapshot does
+	state := snapshotState{
+		SessionID: s.sessionID,
+		Payloads:  map[string]*secrets.Payload{},
+		Metadata:  map[string]*secrets.Metadata{},
+	}
+
+	s.secretsMu.RLock()
+	for name, metadata := range s.secrets {
+		payload, err := s.storage.Get(ctx, name)
+		if err != nil {
+			// A secret without a payload is already lost; skip it
+			continue
+		}
+		metadataCopy := *metadata
+		state.Metadata[name] = &metadataCopy
+		state.Payloads[name] = payload
+	}
+	s.secretsMu.RUnlock()
+
+	var encoded bytes.Buffer
+	if err := gob.NewEncoder(&encoded).Encode(state); err != nil {
+		return fmt.Errorf("encoding export bundle: %w", err)
+	}
+
+	salt, err := common.GenerateSaltFrom(s.deps.Reader())
+	if err != nil {
+		return fmt.Errorf("generating export salt: %w", err)
+	}
+
+	key := exportKey(s.options.ShutdownExportPassphrase, salt)
+	defer common.ZeroBytes(key)
+
+	ciphertext, err := common.Encrypt(encoded.String(), key)
+	if err != nil {
+		return fmt.Errorf("encrypting export bundle: %w", err)
+	}
+	common.ZeroBytes(encoded.Bytes())
+
+	data := make([]byte, 0, 1+len(salt)+len(ciphertext))
+	data = append(data, exportVersion)
+	data = append(data, salt...)
+	data = append(data, ciphertext...)
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("writing export bundle: %w", err)
+	}
+
+	clog.FromContext(ctx).Warnf("AUDIT: wrote shutdown export bundle to %s", path)
+	return nil
+}
+
+// readShutdownExport opens a shutdown export bundle with the passphrase it
+// was written under, for migration tooling (and tests) that load a departed
+// server's state.
+func readShutdownExport(path, passphrase string) (*snapshotState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading export bundle: %w", err)
+	}
+
+	if len(data) < 1+32 || data[0] != exportVersion {
+		return nil, fmt.Errorf("invalid export bundle format")
+	}
+	salt, ciphertext := data[1:33], data[33:]
+
+	key := exportKey(passphrase, salt)
+	defer common.ZeroBytes(key)
+
+	plaintext, err := common.Decrypt(ciphertext, key)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting export bundle: %w", err)
+	}
+
+	var state snapshotState
+	if err := gob.NewDecoder(bytes.NewReader([]byte(plaintext))).Decode(&state); err != nil {
+		return nil, fmt.Errorf("decoding export bundle: %w", err)
+	}
+
+	return &state, nil
+}
@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	isecrets "github.com/carabiner-dev/burnafter/internal/secrets"
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func TestShutdownExportBundle(t *testing.T) {
+	dir := t.TempDir()
+	bundlePath := filepath.Join(dir, "export.bundle")
+
+	opts := *options.DefaultServer
+	opts.SocketPath = filepath.Join(dir, "export.sock")
+	opts.ShutdownExportPath = bundlePath
+	opts.ShutdownExportPassphrase = "correct horse battery staple"
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	srv.storage = isecrets.NewMemoryStorage()
+
+	done := make(chan error, 1)
+	go func() { done <- srv.Run(context.Background()) }()
+
+	// Give Run a moment to bind the socket, surfacing early failures
+	select {
+	case err := <-done:
+		t.Fatalf("Run exited early: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	storeResp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:        "exported",
+		Secret:      []byte("travels-in-the-bundle"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, storeResp.GetError())
+	}
+
+	srv.Stop()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Run did not return after Stop")
+	}
+
+	// The bundle must open with the passphrase and carry the state needed to
+	// revive the secret elsewhere
+	state, err := readShutdownExport(bundlePath, opts.ShutdownExportPassphrase)
+	if err != nil {
+		t.Fatalf("Reading the export bundle: %v", err)
+	}
+	if state.SessionID != srv.sessionID {
+		t.Errorf("Expected the bundle to carry the session ID")
+	}
+	payload, ok := state.Payloads["exported"]
+	if !ok {
+		t.Fatalf("Expected the bundle to carry the secret's payload")
+	}
+	if len(payload.EncryptedData) == 0 {
+		t.Errorf("Expected an encrypted payload in the bundle")
+	}
+	if _, ok := state.Metadata["exported"]; !ok {
+		t.Errorf("Expected the bundle to carry the secret's metadata")
+	}
+
+	// The wrong passphrase must not open it
+	if _, err := readShutdownExport(bundlePath, "wrong"); err == nil {
+		t.Fatalf("Expected the bundle to reject a wrong passphrase")
+	}
+}
+
+func TestShutdownExportRequiresPassphrase(t *testing.T) {
+	dir := t.TempDir()
+	bundlePath := filepath.Join(dir, "export.bundle")
+
+	opts := *options.DefaultServer
+	opts.ShutdownExportPath = bundlePath
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	srv.storage = isecrets.NewMemoryStorage()
+
+	// Without a passphrase nothing may be written
+	if err := srv.saveShutdownExport(context.Background()); err != nil {
+		t.Fatalf("saveShutdownExport failed: %v", err)
+	}
+	if _, err := readShutdownExport(bundlePath, ""); err == nil {
+		t.Fatalf("Expected no bundle to be written without a passphrase")
+	}
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chainguard-dev/clog"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+// ExtendDeadline implements the ExtendDeadline RPC. It pushes a live secret's
+// absolute expiration deadline forward, for long-running jobs that outlast
+// the deadline set at store time. This is distinct from the inactivity
+// refresh a Get performs: it moves the hard deadline itself, so it is bounded
+// by the MaxAbsoluteLifetime option — measured from the secret's original
+// store time — which guarantees operators that no amount of extending keeps a
+// secret alive forever.
+func (s *Server) ExtendDeadline(ctx context.Context, req *common.ExtendDeadlineRequest) (*common.ExtendDeadlineResponse, error) {
+	s.updateActivity()
+
+	clog.FromContext(ctx).Debugf("ExtendDeadline request for secret: %s", req.Name)
+
+	if req.Name == "" {
+		return &common.ExtendDeadlineResponse{
+			Success: false,
+			Error:   "secret name is required",
+		}, nil
+	}
+	if req.NewAbsoluteExpiresAt <= 0 {
+		return &common.ExtendDeadlineResponse{
+			Success: false,
+			Error:   "a new absolute expiration deadline is required",
+		}, nil
+	}
+
+	now := s.now()
+	newDeadline := time.Unix(req.NewAbsoluteExpiresAt, 0)
+	if !newDeadline.After(now) {
+		return &common.ExtendDeadlineResponse{
+			Success: false,
+			Error:   "new deadline is in the past",
+		}, nil
+	}
+
+	s.secretsMu.Lock()
+	defer s.secretsMu.Unlock()
+
+	// Extensions follow aliases, like reads do: extending an alias extends
+	// the secret it resolves to.
+	name, metadata, aliasErr := s.resolveAliasLocked(req.Name)
+	if aliasErr != nil {
+		return &common.ExtendDeadlineResponse{
+			Success: false,
+			Error:   aliasErr.Error(),
+		}, nil
+	}
+
+	// An expired secret is gone; its deadline cannot be revived
+	if metadata.State(now) != secrets.StateLive {
+		return &common.ExtendDeadlineResponse{
+			Success: false,
+			Error:   "secret not found",
+		}, nil
+	}
+
+	if metadata.AbsoluteExpiresAt != nil && !newDeadline.After(*metadata.AbsoluteExpiresAt) {
+		return &common.ExtendDeadlineResponse{
+			Success: false,
+			Error: fmt.Sprintf("new deadline is not after the current one (%s)",
+				metadata.AbsoluteExpiresAt.Format(time.RFC3339)),
+		}, nil
+	}
+
+	// Enforce the hard ceiling: no deadline may land further from the
+	// secret's original store time than the configured maximum lifetime
+	if s.options.MaxAbsoluteLifetime > 0 {
+		ceiling := metadata.CreatedAt.Add(s.options.MaxAbsoluteLifetime)
+		if newDeadline.After(ceiling) {
+			return &common.ExtendDeadlineResponse{
+				Success: false,
+				Error: fmt.Sprintf("new deadline exceeds the maximum lifetime (stored %s, ceiling %s)",
+					metadata.CreatedAt.Format(time.RFC3339), ceiling.Format(time.RFC3339)),
+				LifetimeExceeded: true,
+			}, nil
+		}
+	}
+
+	metadata.AbsoluteExpiresAt = &newDeadline
+	clog.FromContext(ctx).Warnf("AUDIT: extended absolute deadline of secret '%s' to %s",
+		name, newDeadline.Format(time.RFC3339))
+
+	return &common.ExtendDeadlineResponse{Success: true}, nil
+}
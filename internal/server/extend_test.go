@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	isecrets "github.com/carabiner-dev/burnafter/internal/secrets"
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// newExtendTestServer builds a test server with the given absolute-lifetime
+// ceiling.
+func newExtendTestServer(t *testing.T, maxLifetime time.Duration) *Server {
+	t.Helper()
+
+	opts := *options.DefaultServer
+	opts.MaxAbsoluteLifetime = maxLifetime
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	srv.storage = isecrets.NewMemoryStorage()
+	return srv
+}
+
+func TestExtendDeadlineWithinCeiling(t *testing.T) {
+	srv := newExtendTestServer(t, 24*time.Hour)
+	ctx := testPeerContext()
+
+	storeResp, err := srv.Store(ctx, &common.StoreRequest{
+		Name:                      "extendable",
+		Secret:                    []byte("value"),
+		ClientNonce:               "test-nonce",
+		AbsoluteExpirationSeconds: 600,
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, storeResp.GetError())
+	}
+
+	newDeadline := time.Now().Add(2 * time.Hour)
+	resp, err := srv.ExtendDeadline(ctx, &common.ExtendDeadlineRequest{
+		Name:                 "extendable",
+		NewAbsoluteExpiresAt: newDeadline.Unix(),
+	})
+	if err != nil {
+		t.Fatalf("ExtendDeadline failed: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("Expected the extension to succeed, got: %s", resp.Error)
+	}
+
+	srv.secretsMu.RLock()
+	metadata := srv.secrets["extendable"]
+	srv.secretsMu.RUnlock()
+	if metadata.AbsoluteExpiresAt == nil {
+		t.Fatalf("Expected an absolute deadline to remain set")
+	}
+	if got := metadata.AbsoluteExpiresAt.Unix(); got != newDeadline.Unix() {
+		t.Errorf("Expected deadline %d, got %d", newDeadline.Unix(), got)
+	}
+}
+
+func TestExtendDeadlineKeepsAliasAlive(t *testing.T) {
+	srv := newExtendTestServer(t, 24*time.Hour)
+	ctx := testPeerContext()
+
+	storeResp, err := srv.Store(ctx, &common.StoreRequest{
+		Name:                      "extended-target",
+		Secret:                    []byte("value"),
+		ClientNonce:               "test-nonce",
+		AbsoluteExpirationSeconds: 600,
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, storeResp.GetError())
+	}
+	aliasResp, err := srv.StoreAlias(ctx, &common.StoreAliasRequest{
+		Alias:  "extended-alias",
+		Target: "extended-target",
+	})
+	if err != nil || !aliasResp.Success {
+		t.Fatalf("StoreAlias failed: %v (%s)", err, aliasResp.GetError())
+	}
+
+	resp, err := srv.ExtendDeadline(ctx, &common.ExtendDeadlineRequest{
+		Name:                 "extended-target",
+		NewAbsoluteExpiresAt: time.Now().Add(2 * time.Hour).Unix(),
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("ExtendDeadline failed: %v (%s)", err, resp.GetError())
+	}
+
+	// Age the alias's copied deadline past expiry, as wall time passing the
+	// original (pre-extension) deadline would: the sweep must keep the alias,
+	// whose life follows the extended target, not the stale snapshot
+	past := time.Now().Add(-time.Minute)
+	srv.secretsMu.Lock()
+	srv.secrets["extended-alias"].AbsoluteExpiresAt = &past
+	srv.secretsMu.Unlock()
+	srv.runCleanupSweep()
+
+	getResp, err := srv.Get(ctx, &common.GetRequest{Name: "extended-alias", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !getResp.Success {
+		t.Errorf("Expected the alias to survive its target's extension, got: %s", getResp.Error)
+	}
+
+	// Once the target itself expires, the sweep reaps it and the alias with it
+	srv.secretsMu.Lock()
+	srv.secrets["extended-target"].AbsoluteExpiresAt = &past
+	srv.secretsMu.Unlock()
+	srv.runCleanupSweep()
+
+	srv.secretsMu.RLock()
+	_, aliasLeft := srv.secrets["extended-alias"]
+	srv.secretsMu.RUnlock()
+	if aliasLeft {
+		t.Errorf("Expected the alias to be reaped with its expired target")
+	}
+}
+
+func TestExtendDeadlineBeyondCeiling(t *testing.T) {
+	srv := newExtendTestServer(t, time.Hour)
+	ctx := testPeerContext()
+
+	storeResp, err := srv.Store(ctx, &common.StoreRequest{
+		Name:                      "capped",
+		Secret:                    []byte("value"),
+		ClientNonce:               "test-nonce",
+		AbsoluteExpirationSeconds: 600,
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, storeResp.GetError())
+	}
+
+	resp, err := srv.ExtendDeadline(ctx, &common.ExtendDeadlineRequest{
+		Name:                 "capped",
+		NewAbsoluteExpiresAt: time.Now().Add(2 * time.Hour).Unix(),
+	})
+	if err != nil {
+		t.Fatalf("ExtendDeadline failed: %v", err)
+	}
+	if resp.Success {
+		t.Fatalf("Expected an extension past the ceiling to be rejected")
+	}
+	if !resp.LifetimeExceeded {
+		t.Errorf("Expected the lifetime-exceeded flag to be set, got error: %s", resp.Error)
+	}
+
+	// The rejected extension must not have touched the deadline
+	srv.secretsMu.RLock()
+	metadata := srv.secrets["capped"]
+	srv.secretsMu.RUnlock()
+	if metadata.AbsoluteExpiresAt == nil {
+		t.Fatalf("Expected the original deadline to remain set")
+	}
+	if remaining := time.Until(*metadata.AbsoluteExpiresAt); remaining > 11*time.Minute {
+		t.Errorf("Expected the original ~10 minute deadline, got %s remaining", remaining)
+	}
+}
+
+func TestExtendDeadlineValidation(t *testing.T) {
+	srv := newExtendTestServer(t, 0)
+	ctx := testPeerContext()
+
+	storeResp, err := srv.Store(ctx, &common.StoreRequest{
+		Name:                      "validated",
+		Secret:                    []byte("value"),
+		ClientNonce:               "test-nonce",
+		AbsoluteExpirationSeconds: 3600,
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, storeResp.GetError())
+	}
+
+	for _, tc := range []struct {
+		name string
+		req  *common.ExtendDeadlineRequest
+	}{
+		{"missing name", &common.ExtendDeadlineRequest{NewAbsoluteExpiresAt: time.Now().Add(time.Hour).Unix()}},
+		{"missing deadline", &common.ExtendDeadlineRequest{Name: "validated"}},
+		{"past deadline", &common.ExtendDeadlineRequest{Name: "validated", NewAbsoluteExpiresAt: time.Now().Add(-time.Hour).Unix()}},
+		{"not after current", &common.ExtendDeadlineRequest{Name: "validated", NewAbsoluteExpiresAt: time.Now().Add(30 * time.Minute).Unix()}},
+		{"unknown secret", &common.ExtendDeadlineRequest{Name: "missing", NewAbsoluteExpiresAt: time.Now().Add(time.Hour).Unix()}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := srv.ExtendDeadline(ctx, tc.req)
+			if err != nil {
+				t.Fatalf("ExtendDeadline failed: %v", err)
+			}
+			if resp.Success {
+				t.Fatalf("Expected the request to be rejected")
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+
+	"github.com/chainguard-dev/clog"
+
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+// flushStorage deletes every secret the server stored from the storage
+// backend, as the last step of a graceful shutdown. On the kernel keyring
+// backend the keys would otherwise linger until the process keyring is
+// garbage-collected on exit — and potentially longer if the process dies
+// uncleanly — so each one is explicitly unlinked through the keyring worker
+// instead. Alias entries hold no payload and are skipped.
+func (s *Server) flushStorage(ctx context.Context) {
+	s.secretsMu.Lock()
+	defer s.secretsMu.Unlock()
+
+	for name, metadata := range s.secrets {
+		if metadata.AliasTarget != "" {
+			continue
+		}
+		if err := s.storage.Delete(ctx, name); err != nil {
+			clog.FromContext(ctx).Warnf("Failed to flush secret from storage on shutdown: %v", err)
+		}
+	}
+	s.secrets = make(map[string]*secrets.Metadata)
+}
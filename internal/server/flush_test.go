@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+// +build linux
+
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	isecrets "github.com/carabiner-dev/burnafter/internal/secrets"
+	"github.com/carabiner-dev/burnafter/options"
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+// TestShutdownFlushesKeyring proves a graceful shutdown unlinks every stored
+// key from the kernel keyring instead of leaving them for process-exit
+// garbage collection.
+func TestShutdownFlushesKeyring(t *testing.T) {
+	ctx := context.Background()
+
+	storage, err := isecrets.NewKeyringStorage(ctx, "flush-test-session")
+	if err != nil {
+		t.Skipf("kernel keyring unavailable: %v", err)
+	}
+
+	opts := *options.DefaultServer
+	opts.SocketPath = filepath.Join(t.TempDir(), "flush.sock")
+
+	srv, err := NewServer(ctx, &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	srv.storage = storage
+
+	addTestSecret(t, srv, "flush-1", nil, time.Now())
+	addTestSecret(t, srv, "flush-2", nil, time.Now())
+
+	// An alias holds no payload, so the flush must not trip over it
+	srv.secretsMu.Lock()
+	srv.secrets["flush-alias"] = &secrets.Metadata{
+		Name:        "flush-alias",
+		AliasTarget: "flush-1",
+	}
+	srv.secretsMu.Unlock()
+
+	done := make(chan error, 1)
+	go func() { done <- srv.Run(context.Background()) }()
+
+	// Give Run a moment to bind the socket, surfacing early failures
+	select {
+	case err := <-done:
+		t.Fatalf("Run exited early: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	srv.Stop()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Run did not return after Stop")
+	}
+
+	// The keys must be unfindable now, not merely awaiting keyring GC
+	for _, name := range []string{"flush-1", "flush-2"} {
+		if payload, err := storage.Get(ctx, name); err == nil && payload != nil {
+			t.Errorf("Expected secret %q to be gone from the keyring after shutdown", name)
+		}
+	}
+}
@@ -5,14 +5,22 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/chainguard-dev/clog"
 
 	"github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/secrets"
 )
 
+// errSecretNotFound is the shared not-found failure inside the server. The
+// handlers whose responses carry an error code translate it into
+// ERROR_NOT_FOUND on the wire, so clients can test the class with errors.Is
+// instead of matching the message text.
+var errSecretNotFound = errors.New("secret not found")
+
 // Get implements the Get RPC by handling the full get lifecycle:
 // getting the client fingerprint, deriving the secret's encryption
 // key, decrpypting the secret and sending it back./
@@ -21,104 +29,336 @@ func (s *Server) Get(ctx context.Context, req *common.GetRequest) (*common.GetRe
 
 	clog.FromContext(ctx).Debugf("Get request for secret: %s", req.Name)
 
+	audit := s.beginAudit(ctx, "get", req.Name)
+	resp := s.getAndDecrypt(ctx, req.Name, req.ClientNonce, audit)
+	audit.finish(resp.Success, resp.Error)
+	return resp, nil
+}
+
+// GetByHandle implements the GetByHandle RPC. It resolves a single-use
+// retrieval handle issued at store time to its secret and runs the normal get
+// lifecycle (including the client binary verification). The handle is
+// invalidated by the redemption attempt, even when the subsequent decryption
+// fails.
+func (s *Server) GetByHandle(ctx context.Context, req *common.GetByHandleRequest) (*common.GetResponse, error) {
+	s.updateActivity()
+
+	clog.FromContext(ctx).Debug("GetByHandle request")
+
+	// The handle is not a secret name; the recorder learns the name once
+	// (and if) the handle resolves
+	audit := s.beginAudit(ctx, "get", "")
+
+	// Resolve and burn the handle
+	s.secretsMu.Lock()
+	name, exists := s.handles[req.Handle]
+	if exists {
+		delete(s.handles, req.Handle)
+		if metadata, ok := s.secrets[name]; ok {
+			metadata.Handle = ""
+		}
+	}
+	s.secretsMu.Unlock()
+
+	if !exists {
+		audit.finish(false, "handle not found or already redeemed")
+		return &common.GetResponse{
+			Success:   false,
+			Error:     "handle not found or already redeemed",
+			ErrorCode: common.ErrorCode_ERROR_NOT_FOUND,
+		}, nil
+	}
+
+	audit.setName(name)
+	resp := s.getAndDecrypt(ctx, name, req.ClientNonce, audit)
+	audit.finish(resp.Success, resp.Error)
+	return resp, nil
+}
+
+// getAndDecrypt runs the shared get lifecycle for a resolved secret name:
+// verify the caller's binary, check expiration, fetch the payload from
+// storage and decrypt it. It fills in the audit recorder's details as it
+// learns them; emitting the event stays with the caller.
+func (s *Server) getAndDecrypt(ctx context.Context, name, clientNonce string, audit *auditRecorder) *common.GetResponse {
+	lease, errResp := s.fetchForRead(ctx, name, clientNonce, audit)
+	if errResp != nil {
+		return errResp
+	}
+	defer common.ZeroBytes(lease.key)
+
+	// Decrypt the secret. The plaintext slice goes into the response as-is;
+	// the server's wipe codec zeroes it once the response has been
+	// serialized for the wire, so the cleartext does not linger on the heap.
+	// Payloads written by the streaming store are chunk-framed and get
+	// reassembled here, since the unary response carries the whole value.
+	var plaintext []byte
+	var err error
+	if lease.stored.Chunked {
+		plaintext, err = common.DecryptChunkedBytes(lease.stored.EncryptedData, lease.key)
+	} else {
+		plaintext, err = common.DecryptBytes(lease.stored.EncryptedData, lease.key)
+	}
+	if err != nil {
+		return &common.GetResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to decrypt secret: %v", err),
+		}
+	}
+
+	s.finishRead(ctx, lease)
+
+	clog.FromContext(ctx).Debugf("Retrieved secret '%s'", lease.name)
+
+	return &common.GetResponse{
+		Success:           true,
+		Secret:            plaintext,
+		TtlSeconds:        lease.ttlSeconds,
+		AbsoluteExpiresAt: lease.absoluteExpiresAt,
+	}
+}
+
+// readLease carries what a read needs after the lifecycle checks have passed:
+// the stored payload, the derived key and the bookkeeping finishRead settles
+// once the plaintext has actually been served.
+type readLease struct {
+	name              string
+	stored            *secrets.Payload
+	key               []byte
+	graceReason       string
+	deleteAfterServe  bool
+	maxReads          int64
+	ttlSeconds        int64
+	absoluteExpiresAt int64
+}
+
+// fetchForRead runs the get lifecycle shared by the unary and streaming reads
+// up to (but not including) decryption: verify the caller's binary, resolve
+// aliases, check expiration, fetch the payload and derive the key. On failure
+// the error response is returned and the lease is nil; on success the caller
+// owns the lease's key and must wipe it.
+func (s *Server) fetchForRead(ctx context.Context, name, clientNonce string, audit *auditRecorder) (*readLease, *common.GetResponse) {
 	// Get client PID and verify binary
 	authInfo, err := GetPeerAuthInfo(ctx)
 	if err != nil {
-		return &common.GetResponse{
+		s.authFailures.peerCredential.Add(1)
+		return nil, &common.GetResponse{
 			Success: false,
 			Error:   fmt.Sprintf("failed to get client credentials: %v", err),
-		}, nil
+		}
 	}
 
-	_, clientHash, err := common.GetClientBinaryInfo(authInfo.PID)
+	clientHash, err := s.clientKeyHash(authInfo)
 	if err != nil {
-		return &common.GetResponse{
+		s.authFailures.peerCredential.Add(1)
+		return nil, &common.GetResponse{
 			Success: false,
 			Error:   fmt.Sprintf("failed to verify client binary: %v", err),
-		}, nil
+		}
 	}
+	audit.setBinaryHash(clientHash)
 
-	// Retrieve the secret metadata
+	// Retrieve the secret metadata, resolving aliases to the secret they
+	// point at. The resolved target's lifecycle governs the whole chain.
 	s.secretsMu.Lock()
-	metadata, exists := s.secrets[req.Name]
-	if !exists {
+	name, metadata, aliasErr := s.resolveAliasLocked(name)
+	if aliasErr != nil {
 		s.secretsMu.Unlock()
-		return &common.GetResponse{
-			Success: false,
-			Error:   "secret not found",
-		}, nil
+		code := common.ErrorCode_ERROR_UNSPECIFIED
+		if errors.Is(aliasErr, errSecretNotFound) {
+			code = common.ErrorCode_ERROR_NOT_FOUND
+		}
+		return nil, &common.GetResponse{
+			Success:   false,
+			Error:     aliasErr.Error(),
+			ErrorCode: code,
+		}
 	}
+	audit.setName(name)
 
-	now := time.Now()
+	now := s.now()
+	grace := s.options.GracePeriod
 
-	// Check if secret expired due to inactivity
-	if time.Since(metadata.LastAccessed) > metadata.InactivityTTL {
-		delete(s.secrets, req.Name)
+	// A sealed secret refuses reads until its not-before time. The attempt
+	// still counts as activity (LastAccessed is updated below), so a secret
+	// polled before its window does not inactivity-expire while waiting.
+	if metadata.NotBefore != nil && now.Before(*metadata.NotBefore) {
+		metadata.LastAccessed = now
 		s.secretsMu.Unlock()
-		// Also delete from storage backend
-		_ = s.storage.Delete(ctx, req.Name) //nolint:errcheck
-		return &common.GetResponse{
-			Success: false,
-			Error:   "secret has expired due to inactivity",
-		}, nil
+		return nil, &common.GetResponse{
+			Success:   false,
+			Error:     fmt.Sprintf("secret not yet valid (not before %s)", metadata.NotBefore.Format(time.RFC3339)),
+			ErrorCode: common.ErrorCode_ERROR_NOT_YET_VALID,
+		}
+	}
+
+	// graceReason records why the secret is past its expiry but still being
+	// served within the configured grace window; deleteAfterServe marks
+	// secrets that must die right after this last read.
+	var graceReason string
+	var deleteAfterServe bool
+
+	// Check if secret expired due to inactivity (skipped entirely for secrets
+	// stored without inactivity expiry). Within the grace window the read
+	// still succeeds and renews the secret (LastAccessed is updated below);
+	// beyond it the secret is gone for good.
+	if inactiveFor := s.now().Sub(metadata.LastAccessed); metadata.InactivityTTL >= 0 && inactiveFor > metadata.InactivityTTL {
+		if inactiveFor > metadata.InactivityTTL+grace {
+			delete(s.secrets, name)
+			s.dropHandleLocked(metadata)
+			s.secretsMu.Unlock()
+			// Also delete from storage backend
+			_ = s.storage.Delete(ctx, name) //nolint:errcheck
+			return nil, &common.GetResponse{
+				Success:   false,
+				Error:     "secret has expired due to inactivity",
+				ErrorCode: common.ErrorCode_ERROR_EXPIRED,
+			}
+		}
+		graceReason = "inactivity timeout"
 	}
 
-	// Check if secret has expired due to absolute expiration
+	// Check if secret has expired due to absolute expiration. A deadline
+	// cannot be renewed, so a grace-window read serves the secret one last
+	// time and then deletes it.
 	if metadata.AbsoluteExpiresAt != nil && now.After(*metadata.AbsoluteExpiresAt) {
-		delete(s.secrets, req.Name)
-		s.secretsMu.Unlock()
-		// Also delete from storage backend
-		_ = s.storage.Delete(ctx, req.Name) //nolint:errcheck
-		return &common.GetResponse{
-			Success: false,
-			Error:   "secret has expired (absolute deadline reached)",
-		}, nil
+		if now.After(metadata.AbsoluteExpiresAt.Add(grace)) {
+			delete(s.secrets, name)
+			s.dropHandleLocked(metadata)
+			s.secretsMu.Unlock()
+			// Also delete from storage backend
+			_ = s.storage.Delete(ctx, name) //nolint:errcheck
+			return nil, &common.GetResponse{
+				Success:   false,
+				Error:     "secret has expired (absolute deadline reached)",
+				ErrorCode: common.ErrorCode_ERROR_EXPIRED,
+			}
+		}
+		graceReason = "absolute deadline reached"
+		deleteAfterServe = true
 	}
 
 	// Update last accessed time
-	metadata.LastAccessed = time.Now()
+	metadata.LastAccessed = s.now()
+
+	// Capture the lifecycle metadata for the response while the lock is held
+	ownerUID := metadata.OwnerUID
+	maxReads := metadata.MaxReads
+	ttlSeconds := int64(metadata.InactivityTTL.Seconds())
+	var absoluteExpiresAt int64
+	if metadata.AbsoluteExpiresAt != nil {
+		absoluteExpiresAt = metadata.AbsoluteExpiresAt.Unix()
+	}
 	s.secretsMu.Unlock()
 
 	// Retrieve the actual secret from storage backend
-	stored, err := s.storage.Get(ctx, req.Name)
+	stored, err := s.storage.Get(ctx, name)
 	if err != nil {
-		return &common.GetResponse{
+		// The metadata checks passed before the lock was released, so a
+		// concurrent Delete or cleanup sweep may have emptied the backend in
+		// between. When the metadata entry is gone too, report the race as a
+		// clean not-found instead of a raw backend error.
+		s.secretsMu.RLock()
+		_, stillTracked := s.secrets[name]
+		s.secretsMu.RUnlock()
+		if !stillTracked {
+			return nil, &common.GetResponse{
+				Success:   false,
+				Error:     errSecretNotFound.Error(),
+				ErrorCode: common.ErrorCode_ERROR_NOT_FOUND,
+			}
+		}
+		return nil, &common.GetResponse{
 			Success: false,
 			Error:   fmt.Sprintf("failed to retrieve secret from storage: %v", err),
-		}, nil
+		}
 	}
 
-	// Verify that client binary hash matches
+	// Verify that client binary hash matches. The failure classes are counted
+	// and logged apart: the owner UID arriving with a new hash looks like a
+	// binary upgrade, any other UID looks like a foreign binary probing for
+	// someone else's secret.
 	if stored.ClientBinaryHash != clientHash {
-		return &common.GetResponse{
-			Success: false,
-			Error:   "client binary hash mismatch - unauthorized",
-		}, nil
+		if authInfo.UID == ownerUID {
+			s.authFailures.mismatchSameUID.Add(1)
+			clog.FromContext(ctx).Warnf(
+				"AUDIT: binary hash mismatch for secret '%s' from owner uid %d (likely a binary upgrade)",
+				name, authInfo.UID,
+			)
+		} else {
+			s.authFailures.mismatchOtherUID.Add(1)
+			clog.FromContext(ctx).Warnf(
+				"AUDIT: binary hash mismatch for secret '%s' from foreign uid %d (stored by uid %d)",
+				name, authInfo.UID, ownerUID,
+			)
+		}
+		return nil, &common.GetResponse{
+			Success:   false,
+			Error:     "client binary hash mismatch - unauthorized",
+			ErrorCode: common.ErrorCode_ERROR_BINARY_MISMATCH,
+		}
 	}
 
 	// Derive the key again
-	key, err := common.DeriveKey(clientHash, req.ClientNonce, s.sessionID, req.Name, stored.Salt)
+	key, err := common.DeriveKey(clientHash, clientNonce, s.sessionID, name, stored.Salt)
 	if err != nil {
-		return &common.GetResponse{
+		return nil, &common.GetResponse{
 			Success: false,
 			Error:   fmt.Sprintf("failed to derive key: %v", err),
-		}, nil
+		}
 	}
-	defer common.ZeroBytes(key)
+	observeDerivedKey(key)
 
-	// Decrypt the secret
-	plaintext, err := common.Decrypt(stored.EncryptedData, key)
-	if err != nil {
-		return &common.GetResponse{
-			Success: false,
-			Error:   fmt.Sprintf("failed to decrypt secret: %v", err),
-		}, nil
-	}
+	return &readLease{
+		name:              name,
+		stored:            stored,
+		key:               key,
+		graceReason:       graceReason,
+		deleteAfterServe:  deleteAfterServe,
+		maxReads:          maxReads,
+		ttlSeconds:        ttlSeconds,
+		absoluteExpiresAt: absoluteExpiresAt,
+	}, nil
+}
 
-	clog.FromContext(ctx).Debugf("Retrieved secret '%s'", req.Name)
+// finishRead settles a served read's bookkeeping: the grace-period warning,
+// the delete-after-serve of a deadline-expired secret and the read budget.
+// It runs only after the plaintext was actually decrypted and served; a
+// failed decryption leaves the secret untouched.
+func (s *Server) finishRead(ctx context.Context, lease *readLease) {
+	name := lease.name
 
-	return &common.GetResponse{
-		Success: true,
-		Secret:  plaintext,
-	}, nil
+	if lease.graceReason != "" {
+		clog.FromContext(ctx).Warnf("Serving expired secret '%s' within its grace period (reason: %s)",
+			name, lease.graceReason)
+	}
+	if lease.deleteAfterServe {
+		s.secretsMu.Lock()
+		if metadata, ok := s.secrets[name]; ok {
+			delete(s.secrets, name)
+			s.dropHandleLocked(metadata)
+		}
+		s.secretsMu.Unlock()
+		_ = s.storage.Delete(ctx, name) //nolint:errcheck
+	}
+
+	// Count the successful read against the secret's read budget and burn
+	// the secret once it is exhausted. Only a decrypted-and-served read
+	// counts: a refused binary or a sealed secret does not consume reads.
+	if lease.maxReads > 0 {
+		var burned bool
+		s.secretsMu.Lock()
+		if metadata, ok := s.secrets[name]; ok {
+			metadata.ReadCount++
+			if metadata.ReadCount >= metadata.MaxReads {
+				delete(s.secrets, name)
+				s.dropHandleLocked(metadata)
+				burned = true
+			}
+		}
+		s.secretsMu.Unlock()
+		if burned {
+			_ = s.storage.Delete(ctx, name) //nolint:errcheck
+			clog.FromContext(ctx).Debugf("Secret '%s' exhausted its read budget (%d reads), destroyed", name, lease.maxReads)
+		}
+	}
 }
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chainguard-dev/clog"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+// GetEncrypted implements the GetEncrypted RPC. It hands out a secret's raw
+// encrypted payload so a trusted proxy can relay it to another burnafter
+// instance without ever seeing plaintext. The blob is useless without the
+// client nonce, the owner's binary hash and this server's session ID, but
+// exporting it still widens the attack surface, so the RPC only works when
+// the server was started with AllowEncryptedExport and every export leaves a
+// loud audit entry.
+func (s *Server) GetEncrypted(ctx context.Context, req *common.GetRequest) (*common.GetEncryptedResponse, error) {
+	s.updateActivity()
+
+	if !s.options.AllowEncryptedExport {
+		return &common.GetEncryptedResponse{
+			Success: false,
+			Error:   "encrypted export is not enabled on this server",
+		}, nil
+	}
+
+	authInfo, err := GetPeerAuthInfo(ctx)
+	if err != nil {
+		return &common.GetEncryptedResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get client credentials: %v", err),
+		}, nil
+	}
+
+	s.secretsMu.RLock()
+	metadata, exists := s.secrets[req.Name]
+	if exists {
+		if (metadata.InactivityTTL >= 0 && s.now().Sub(metadata.LastAccessed) > metadata.InactivityTTL) ||
+			(metadata.AbsoluteExpiresAt != nil && s.now().After(*metadata.AbsoluteExpiresAt)) {
+			exists = false
+		}
+	}
+	s.secretsMu.RUnlock()
+
+	if !exists {
+		return &common.GetEncryptedResponse{
+			Success: false,
+			Error:   "secret not found",
+		}, nil
+	}
+
+	stored, err := s.storage.Get(ctx, req.Name)
+	if err != nil {
+		return &common.GetEncryptedResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to retrieve secret from storage: %v", err),
+		}, nil
+	}
+
+	// The audit trail for exports is unconditional and deliberately loud
+	clog.FromContext(ctx).Warnf(
+		"AUDIT: encrypted payload of secret '%s' exported to pid %d (uid %d)",
+		req.Name, authInfo.PID, authInfo.UID,
+	)
+
+	return &common.GetEncryptedResponse{
+		Success:          true,
+		EncryptedData:    stored.EncryptedData,
+		Salt:             stored.Salt,
+		ClientBinaryHash: stored.ClientBinaryHash,
+		Chunked:          stored.Chunked,
+	}, nil
+}
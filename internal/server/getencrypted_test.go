@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	isecrets "github.com/carabiner-dev/burnafter/internal/secrets"
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func TestGetEncryptedDisabledByDefault(t *testing.T) {
+	srv := newTestServer(t)
+	addTestSecret(t, srv, "not-exportable", nil, time.Now())
+
+	resp, err := srv.GetEncrypted(testPeerContext(), &common.GetRequest{Name: "not-exportable"})
+	if err != nil {
+		t.Fatalf("GetEncrypted failed: %v", err)
+	}
+	if resp.Success {
+		t.Errorf("Expected encrypted export to be rejected by default")
+	}
+}
+
+func TestGetEncryptedExportsPayload(t *testing.T) {
+	opts := *options.DefaultServer
+	opts.AllowEncryptedExport = true
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	srv.storage = isecrets.NewMemoryStorage()
+
+	addTestSecret(t, srv, "exportable", nil, time.Now())
+
+	resp, err := srv.GetEncrypted(testPeerContext(), &common.GetRequest{Name: "exportable"})
+	if err != nil {
+		t.Fatalf("GetEncrypted failed: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("GetEncrypted error: %s", resp.Error)
+	}
+	if string(resp.EncryptedData) != "test-payload" {
+		t.Errorf("Expected the raw encrypted payload, got %q", resp.EncryptedData)
+	}
+
+	// An unknown secret is still reported as missing
+	resp, err = srv.GetEncrypted(testPeerContext(), &common.GetRequest{Name: "missing"})
+	if err != nil {
+		t.Fatalf("GetEncrypted failed: %v", err)
+	}
+	if resp.Success {
+		t.Errorf("Expected export of an unknown secret to fail")
+	}
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+// racingStorage simulates a Delete winning the race between Get's metadata
+// check and its backend read: the first backend Get removes the secret (map
+// entry and payload) before delegating, exactly as a concurrent Delete or
+// cleanup sweep would in the window where secretsMu is not held.
+type racingStorage struct {
+	secrets.Storage
+	srv  *Server
+	once sync.Once
+}
+
+func (r *racingStorage) Get(ctx context.Context, name string) (*secrets.Payload, error) {
+	r.once.Do(func() {
+		r.srv.secretsMu.Lock()
+		delete(r.srv.secrets, name)
+		r.srv.secretsMu.Unlock()
+		_ = r.Storage.Delete(ctx, name) //nolint:errcheck
+	})
+	return r.Storage.Get(ctx, name)
+}
+
+func TestGetConcurrentDeleteReportsNotFound(t *testing.T) {
+	srv := newTestServer(t)
+
+	storeResp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:        "raced",
+		Secret:      []byte("value"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, storeResp.GetError())
+	}
+
+	srv.storage = &racingStorage{Storage: srv.storage, srv: srv}
+
+	getResp, err := srv.Get(testPeerContext(), &common.GetRequest{
+		Name:        "raced",
+		ClientNonce: "test-nonce",
+	})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if getResp.Success {
+		t.Fatalf("Expected the raced Get to fail")
+	}
+	if getResp.Error != "secret not found" {
+		t.Errorf("Expected a clean not-found for the raced Get, got %q", getResp.Error)
+	}
+}
+
+// failingStorage errors on every read while leaving the metadata in place, so
+// the miss cannot be explained by a concurrent delete.
+type failingStorage struct {
+	secrets.Storage
+}
+
+func (f *failingStorage) Get(_ context.Context, _ string) (*secrets.Payload, error) {
+	return nil, fmt.Errorf("backend offline")
+}
+
+func TestGetStorageErrorStillSurfaces(t *testing.T) {
+	srv := newTestServer(t)
+
+	storeResp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:        "still-tracked",
+		Secret:      []byte("value"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, storeResp.GetError())
+	}
+
+	srv.storage = &failingStorage{Storage: srv.storage}
+
+	getResp, err := srv.Get(testPeerContext(), &common.GetRequest{
+		Name:        "still-tracked",
+		ClientNonce: "test-nonce",
+	})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if getResp.Success {
+		t.Fatalf("Expected the Get to fail")
+	}
+	if !strings.Contains(getResp.Error, "backend offline") {
+		t.Errorf("Expected the raw backend error to surface, got %q", getResp.Error)
+	}
+}
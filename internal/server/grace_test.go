@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	isecrets "github.com/carabiner-dev/burnafter/internal/secrets"
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// newGraceTestServer creates a server with a ten minute expiry grace period
+// and one stored secret, returning both.
+func newGraceTestServer(t *testing.T, name string) *Server {
+	t.Helper()
+
+	opts := *options.DefaultServer
+	opts.GracePeriod = 10 * time.Minute
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	srv.storage = isecrets.NewMemoryStorage()
+
+	resp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name: name, Secret: []byte("grace-value"), TtlSeconds: 60,
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, resp.GetError())
+	}
+	return srv
+}
+
+func TestGracePeriodInactivity(t *testing.T) {
+	srv := newGraceTestServer(t, "grace-inactive")
+	ctx := testPeerContext()
+
+	// Past the inactivity TTL but inside the grace window: the read succeeds
+	// and renews the secret.
+	srv.secretsMu.Lock()
+	srv.secrets["grace-inactive"].LastAccessed = time.Now().Add(-5 * time.Minute)
+	srv.secretsMu.Unlock()
+
+	resp, err := srv.Get(ctx, &common.GetRequest{Name: "grace-inactive"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !resp.Success || string(resp.Secret) != "grace-value" {
+		t.Fatalf("Expected a grace-window read to succeed, got: %s", resp.Error)
+	}
+
+	// The grace read renewed the secret, so a second read needs no grace
+	srv.secretsMu.RLock()
+	sinceAccess := time.Since(srv.secrets["grace-inactive"].LastAccessed)
+	srv.secretsMu.RUnlock()
+	if sinceAccess > time.Minute {
+		t.Errorf("Expected the grace-window read to renew LastAccessed")
+	}
+
+	// Past TTL plus grace: the secret is gone for good
+	srv.secretsMu.Lock()
+	srv.secrets["grace-inactive"].LastAccessed = time.Now().Add(-12 * time.Minute)
+	srv.secretsMu.Unlock()
+
+	resp, err = srv.Get(ctx, &common.GetRequest{Name: "grace-inactive"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if resp.Success {
+		t.Errorf("Expected the read past the grace window to fail")
+	}
+}
+
+func TestGracePeriodAbsoluteDeadline(t *testing.T) {
+	srv := newGraceTestServer(t, "grace-absolute")
+	ctx := testPeerContext()
+
+	// Past the absolute deadline but inside the grace window: a deadline
+	// cannot be renewed, so the secret is served one last time and deleted.
+	past := time.Now().Add(-5 * time.Minute)
+	srv.secretsMu.Lock()
+	srv.secrets["grace-absolute"].AbsoluteExpiresAt = &past
+	srv.secretsMu.Unlock()
+
+	resp, err := srv.Get(ctx, &common.GetRequest{Name: "grace-absolute"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !resp.Success || string(resp.Secret) != "grace-value" {
+		t.Fatalf("Expected a grace-window read to succeed, got: %s", resp.Error)
+	}
+
+	// The last-chance read consumed the secret
+	resp, err = srv.Get(ctx, &common.GetRequest{Name: "grace-absolute"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if resp.Success {
+		t.Errorf("Expected the secret to be deleted after its grace read")
+	}
+}
+
+func TestGracePeriodAbsoluteBeyondWindow(t *testing.T) {
+	srv := newGraceTestServer(t, "grace-absolute-late")
+	ctx := testPeerContext()
+
+	past := time.Now().Add(-11 * time.Minute)
+	srv.secretsMu.Lock()
+	srv.secrets["grace-absolute-late"].AbsoluteExpiresAt = &past
+	srv.secretsMu.Unlock()
+
+	resp, err := srv.Get(ctx, &common.GetRequest{Name: "grace-absolute-late"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if resp.Success {
+		t.Errorf("Expected a read past the grace window to fail")
+	}
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+func TestGetByHandleRedemption(t *testing.T) {
+	srv := newTestServer(t)
+	ctx := testPeerContext()
+
+	storeResp, err := srv.Store(ctx, &common.StoreRequest{
+		Name:         "handle-secret",
+		Secret:       []byte("handle-value"),
+		ClientNonce:  "test-nonce",
+		ReturnHandle: true,
+	})
+	if err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if !storeResp.Success {
+		t.Fatalf("Store error: %s", storeResp.Error)
+	}
+	if storeResp.Handle == "" {
+		t.Fatalf("Expected a retrieval handle in the store response")
+	}
+
+	// Redeeming the handle returns the secret
+	getResp, err := srv.GetByHandle(ctx, &common.GetByHandleRequest{
+		Handle:      storeResp.Handle,
+		ClientNonce: "test-nonce",
+	})
+	if err != nil {
+		t.Fatalf("GetByHandle failed: %v", err)
+	}
+	if !getResp.Success {
+		t.Fatalf("GetByHandle error: %s", getResp.Error)
+	}
+	if string(getResp.Secret) != "handle-value" {
+		t.Errorf("Expected handle-value, got %q", getResp.Secret)
+	}
+
+	// A second redemption must fail: handles are single-use
+	getResp, err = srv.GetByHandle(ctx, &common.GetByHandleRequest{
+		Handle:      storeResp.Handle,
+		ClientNonce: "test-nonce",
+	})
+	if err != nil {
+		t.Fatalf("GetByHandle failed: %v", err)
+	}
+	if getResp.Success {
+		t.Errorf("Expected double redemption to fail")
+	}
+
+	// The secret itself is still retrievable by name
+	byName, err := srv.Get(ctx, &common.GetRequest{Name: "handle-secret", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !byName.Success {
+		t.Errorf("Expected the secret to remain readable by name: %s", byName.Error)
+	}
+}
+
+func TestGetByHandleUnknown(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := srv.GetByHandle(testPeerContext(), &common.GetByHandleRequest{
+		Handle: "not-a-real-handle",
+	})
+	if err != nil {
+		t.Fatalf("GetByHandle failed: %v", err)
+	}
+	if resp.Success {
+		t.Errorf("Expected unknown handle to be rejected")
+	}
+}
+
+func TestGetByHandleExpiredSecret(t *testing.T) {
+	srv := newTestServer(t)
+	ctx := testPeerContext()
+
+	storeResp, err := srv.Store(ctx, &common.StoreRequest{
+		Name:         "expiring-handle",
+		Secret:       []byte("value"),
+		ClientNonce:  "test-nonce",
+		TtlSeconds:   60,
+		ReturnHandle: true,
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v %s", err, storeResp.GetError())
+	}
+
+	// Force the secret past its inactivity TTL
+	srv.secretsMu.Lock()
+	srv.secrets["expiring-handle"].LastAccessed = time.Now().Add(-2 * time.Hour)
+	srv.secretsMu.Unlock()
+
+	resp, err := srv.GetByHandle(ctx, &common.GetByHandleRequest{
+		Handle:      storeResp.Handle,
+		ClientNonce: "test-nonce",
+	})
+	if err != nil {
+		t.Fatalf("GetByHandle failed: %v", err)
+	}
+	if resp.Success {
+		t.Errorf("Expected redemption of an expired secret to fail")
+	}
+
+	// The handle died with the secret
+	srv.secretsMu.RLock()
+	handleCount := len(srv.handles)
+	srv.secretsMu.RUnlock()
+	if handleCount != 0 {
+		t.Errorf("Expected no outstanding handles, got %d", handleCount)
+	}
+}
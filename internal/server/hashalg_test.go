@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	isecrets "github.com/carabiner-dev/burnafter/internal/secrets"
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func TestBinaryHashAlgorithmRoundTrip(t *testing.T) {
+	for _, algorithm := range []string{
+		common.HashAlgorithmSHA256,
+		common.HashAlgorithmSHA512,
+		common.HashAlgorithmBLAKE3,
+	} {
+		t.Run(algorithm, func(t *testing.T) {
+			opts := *options.DefaultServer
+			opts.BinaryHashAlgorithm = algorithm
+			srv, err := NewServer(context.Background(), &opts)
+			if err != nil {
+				t.Fatalf("NewServer failed: %v", err)
+			}
+			srv.storage = isecrets.NewMemoryStorage()
+			ctx := testPeerContext()
+
+			storeResp, err := srv.Store(ctx, &common.StoreRequest{
+				Name: "hashed", Secret: []byte("round-trip"), ClientNonce: "test-nonce",
+			})
+			if err != nil || !storeResp.Success {
+				t.Fatalf("Store failed: %v (%s)", err, storeResp.GetError())
+			}
+
+			getResp, err := srv.Get(ctx, &common.GetRequest{Name: "hashed", ClientNonce: "test-nonce"})
+			if err != nil {
+				t.Fatalf("Get failed: %v", err)
+			}
+			if !getResp.Success {
+				t.Fatalf("Get was denied: %s", getResp.Error)
+			}
+			if string(getResp.Secret) != "round-trip" {
+				t.Errorf("Expected round-trip, got %q", getResp.Secret)
+			}
+		})
+	}
+}
+
+func TestNewServerUnknownHashAlgorithm(t *testing.T) {
+	opts := *options.DefaultServer
+	opts.BinaryHashAlgorithm = "md5"
+	if _, err := NewServer(context.Background(), &opts); err == nil {
+		t.Fatalf("Expected NewServer to reject an unknown hash algorithm")
+	}
+}
+
+func TestBinaryHashAlgorithmsDiffer(t *testing.T) {
+	// The fingerprint is a key-derivation input: the same binary must yield a
+	// different hash under each algorithm, or switching would not invalidate
+	// existing secrets the way the option documents.
+	exePath, err := os.Executable()
+	if err != nil {
+		t.Fatalf("getting the executable path: %v", err)
+	}
+
+	seen := map[string]string{}
+	for _, algorithm := range []string{
+		common.HashAlgorithmSHA256,
+		common.HashAlgorithmSHA512,
+		common.HashAlgorithmBLAKE3,
+	} {
+		hash, err := common.HashFileWith(algorithm, exePath)
+		if err != nil {
+			t.Fatalf("HashFileWith(%s) failed: %v", algorithm, err)
+		}
+		for other, otherHash := range seen {
+			if hash == otherHash {
+				t.Errorf("%s and %s produced the same digest", algorithm, other)
+			}
+		}
+		seen[algorithm] = hash
+	}
+
+	// The empty algorithm is the SHA-256 default
+	defaulted, err := common.HashFileWith("", exePath)
+	if err != nil {
+		t.Fatalf("HashFileWith(\"\") failed: %v", err)
+	}
+	if defaulted != seen[common.HashAlgorithmSHA256] {
+		t.Errorf("Expected the empty algorithm to mean sha256")
+	}
+}
+
+func BenchmarkHashFileWith(b *testing.B) {
+	exePath, err := os.Executable()
+	if err != nil {
+		b.Fatalf("getting the executable path: %v", err)
+	}
+
+	for _, algorithm := range []string{
+		common.HashAlgorithmSHA256,
+		common.HashAlgorithmSHA512,
+		common.HashAlgorithmBLAKE3,
+	} {
+		b.Run(algorithm, func(b *testing.B) {
+			for b.Loop() {
+				if _, err := common.HashFileWith(algorithm, exePath); err != nil {
+					b.Fatalf("HashFileWith failed: %v", err)
+				}
+			}
+		})
+	}
+}
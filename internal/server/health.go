@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+// healthSentinelName is the reserved name the storage self-test writes under.
+// The embedded NUL keeps it out of the namespace any sane client uses, and the
+// sentinel only ever touches the storage backend — it is never entered into
+// the server's secret map, so it cannot collide with or count against real
+// secrets.
+const healthSentinelName = "burnafter-internal\x00health-probe"
+
+// storageProbeTimeout bounds the storage self-test. A wedged backend (e.g. a
+// stuck keyring worker thread) blocks instead of erroring, so without a
+// deadline the probe would hang right along with it.
+const storageProbeTimeout = 2 * time.Second
+
+// storageSelfTest round-trips a sentinel payload through the storage backend
+// to prove it can still store, retrieve and delete. It runs the probe in its
+// own goroutine so a backend that has wedged entirely shows up as a timeout
+// instead of hanging the health RPC.
+func (s *Server) storageSelfTest(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- s.runStorageProbe(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(storageProbeTimeout):
+		return fmt.Errorf("storage probe timed out after %s", storageProbeTimeout)
+	}
+}
+
+// runStorageProbe performs the actual sentinel store+get+delete cycle.
+func (s *Server) runStorageProbe(ctx context.Context) error {
+	sentinel := []byte("burnafter-health-probe")
+
+	if err := s.storage.Store(ctx, healthSentinelName, &secrets.Payload{
+		EncryptedData: sentinel,
+	}); err != nil {
+		return fmt.Errorf("storing health sentinel: %w", err)
+	}
+
+	stored, err := s.storage.Get(ctx, healthSentinelName)
+	if err != nil {
+		return fmt.Errorf("retrieving health sentinel: %w", err)
+	}
+	if stored == nil || !bytes.Equal(stored.EncryptedData, sentinel) {
+		return fmt.Errorf("health sentinel round-trip mismatch")
+	}
+
+	if err := s.storage.Delete(ctx, healthSentinelName); err != nil {
+		return fmt.Errorf("deleting health sentinel: %w", err)
+	}
+
+	return nil
+}
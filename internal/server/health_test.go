@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+// brokenStorage is a secrets.Storage whose operations fail outright.
+type brokenStorage struct{}
+
+func (brokenStorage) Store(context.Context, string, *secrets.Payload) error {
+	return fmt.Errorf("keyring worker gone")
+}
+
+func (brokenStorage) Get(context.Context, string) (*secrets.Payload, error) {
+	return nil, fmt.Errorf("keyring worker gone")
+}
+
+func (brokenStorage) Delete(context.Context, string) error {
+	return fmt.Errorf("keyring worker gone")
+}
+
+func (brokenStorage) Backend() string { return "broken" }
+
+// wedgedStorage simulates a stuck keyring worker: operations block far past
+// the probe timeout instead of returning.
+type wedgedStorage struct{}
+
+func (wedgedStorage) Store(context.Context, string, *secrets.Payload) error {
+	time.Sleep(storageProbeTimeout + 5*time.Second)
+	return nil
+}
+
+func (wedgedStorage) Get(context.Context, string) (*secrets.Payload, error) {
+	time.Sleep(storageProbeTimeout + 5*time.Second)
+	return nil, nil
+}
+
+func (wedgedStorage) Delete(context.Context, string) error {
+	time.Sleep(storageProbeTimeout + 5*time.Second)
+	return nil
+}
+
+func (wedgedStorage) Backend() string { return "wedged" }
+
+func TestPingStorageHealthy(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := srv.Ping(context.Background(), &common.PingRequest{CheckStorage: true})
+	if err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+	if !resp.Alive {
+		t.Errorf("Expected the server to report alive")
+	}
+	if !resp.StorageHealthy {
+		t.Errorf("Expected a healthy backend, got error %q", resp.StorageError)
+	}
+	if resp.StorageError != "" {
+		t.Errorf("Expected no storage error, got %q", resp.StorageError)
+	}
+
+	// The probe cleans its sentinel up after itself
+	if payload, err := srv.storage.Get(context.Background(), healthSentinelName); err == nil && payload != nil {
+		t.Errorf("Expected the health sentinel to be deleted after the probe")
+	}
+}
+
+func TestPingStorageSkippedByDefault(t *testing.T) {
+	srv := newTestServer(t)
+	srv.storage = brokenStorage{}
+
+	// A plain ping only reports liveness; the broken backend is not probed
+	resp, err := srv.Ping(context.Background(), &common.PingRequest{})
+	if err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+	if !resp.Alive || resp.StorageHealthy || resp.StorageError != "" {
+		t.Errorf("Expected a plain liveness response, got %+v", resp)
+	}
+}
+
+func TestPingStorageBroken(t *testing.T) {
+	srv := newTestServer(t)
+	srv.storage = brokenStorage{}
+
+	resp, err := srv.Ping(context.Background(), &common.PingRequest{CheckStorage: true})
+	if err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+	if !resp.Alive {
+		t.Errorf("Expected the server to report alive even with a broken backend")
+	}
+	if resp.StorageHealthy {
+		t.Errorf("Expected the broken backend to fail the self-test")
+	}
+	if !strings.Contains(resp.StorageError, "storing health sentinel") {
+		t.Errorf("Expected a store failure in the storage error, got %q", resp.StorageError)
+	}
+}
+
+func TestPingStorageWedged(t *testing.T) {
+	srv := newTestServer(t)
+	srv.storage = wedgedStorage{}
+
+	start := time.Now()
+	resp, err := srv.Ping(context.Background(), &common.PingRequest{CheckStorage: true})
+	if err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+	if resp.StorageHealthy {
+		t.Errorf("Expected the wedged backend to fail the self-test")
+	}
+	if !strings.Contains(resp.StorageError, "timed out") {
+		t.Errorf("Expected a timeout in the storage error, got %q", resp.StorageError)
+	}
+	// The probe must give up at its own deadline, not wait out the backend
+	if elapsed := time.Since(start); elapsed > storageProbeTimeout+time.Second {
+		t.Errorf("Expected the probe to time out promptly, took %s", elapsed)
+	}
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+func TestCleanupSweepImmediateShutdownWhenEmpty(t *testing.T) {
+	srv := newTestServer(t)
+	srv.grpcServer = grpc.NewServer()
+
+	// Without an idle grace period an empty sweep shuts the server down,
+	// preserving the original behavior
+	if !srv.runCleanupSweep() {
+		t.Fatalf("Expected an empty sweep to request shutdown")
+	}
+}
+
+func TestIdleGracePeriodSurvivesQuickRefill(t *testing.T) {
+	srv := newTestServer(t)
+	srv.grpcServer = grpc.NewServer()
+	srv.options.IdleGracePeriod = time.Hour
+
+	// The first empty sweep only starts the grace window
+	if srv.runCleanupSweep() {
+		t.Fatalf("Expected the first empty sweep to start the grace window, not shut down")
+	}
+
+	// A secret arriving during the window cancels the pending shutdown
+	addTestSecret(t, srv, "refill", nil, time.Now())
+	if srv.runCleanupSweep() {
+		t.Fatalf("Expected a refilled server to keep running")
+	}
+	if !srv.emptySince.IsZero() {
+		t.Errorf("Expected the grace window to be cleared by the refill")
+	}
+
+	// Empty again: the window restarts from scratch instead of resuming
+	srv.secretsMu.Lock()
+	delete(srv.secrets, "refill")
+	srv.secretsMu.Unlock()
+	if srv.runCleanupSweep() {
+		t.Fatalf("Expected a fresh grace window after emptying again")
+	}
+}
+
+func TestIdleGracePeriodExpires(t *testing.T) {
+	srv := newTestServer(t)
+	srv.grpcServer = grpc.NewServer()
+	srv.options.IdleGracePeriod = time.Hour
+
+	if srv.runCleanupSweep() {
+		t.Fatalf("Expected the first empty sweep to start the grace window, not shut down")
+	}
+
+	// Still empty once the window has passed: now the shutdown happens
+	srv.secretsMu.Lock()
+	srv.emptySince = time.Now().Add(-2 * time.Hour)
+	srv.secretsMu.Unlock()
+	if !srv.runCleanupSweep() {
+		t.Fatalf("Expected a shutdown after the grace window passed while still empty")
+	}
+}
@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func TestConcurrencyInterceptorRejectsExcess(t *testing.T) {
+	opts := *options.DefaultServer
+	opts.MaxConcurrentRequests = 2
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+
+	// Handler that blocks until released, to keep requests in flight
+	release := make(chan struct{})
+	started := make(chan struct{}, opts.MaxConcurrentRequests)
+	handler := func(ctx context.Context, req any) (any, error) {
+		started <- struct{}{}
+		<-release
+		return nil, nil
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := srv.concurrencyInterceptor(context.Background(), nil, nil, handler)
+			errs <- err
+		}()
+	}
+
+	// Wait until the limit is saturated
+	for range 2 {
+		<-started
+	}
+
+	// Requests beyond the limit must be rejected with ResourceExhausted
+	for range 3 {
+		_, err := srv.concurrencyInterceptor(context.Background(), nil, nil, handler)
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Errorf("Expected ResourceExhausted, got %v", err)
+		}
+	}
+
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("In-limit request failed: %v", err)
+		}
+	}
+
+	// With the limit freed up, requests pass again
+	if _, err := srv.concurrencyInterceptor(context.Background(), nil, nil,
+		func(ctx context.Context, req any) (any, error) { return nil, nil }); err != nil {
+		t.Errorf("Expected request to pass after slots freed: %v", err)
+	}
+}
+
+func TestConcurrencyInterceptorUnlimited(t *testing.T) {
+	opts := *options.DefaultServer
+	opts.MaxConcurrentRequests = 0
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+
+	if _, err := srv.concurrencyInterceptor(context.Background(), nil, nil,
+		func(ctx context.Context, req any) (any, error) { return nil, nil }); err != nil {
+		t.Errorf("Expected unlimited interceptor to pass requests: %v", err)
+	}
+}
+
+// uidContext returns a peer context carrying the given UID.
+func uidContext(uid uint32) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: &peerAuthInfo{PID: 1234, UID: uid, GID: uid},
+	})
+}
+
+func TestUIDInterceptorAllowlist(t *testing.T) {
+	opts := *options.DefaultServer
+	opts.AllowedUIDs = []uint32{1000, 1001}
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+
+	// An allowed UID passes through
+	if _, err := srv.uidInterceptor(uidContext(1001), nil, nil, handler); err != nil {
+		t.Errorf("Expected allowed UID to pass: %v", err)
+	}
+
+	// Any other UID is rejected with PermissionDenied
+	if _, err := srv.uidInterceptor(uidContext(999), nil, nil, handler); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for disallowed UID, got %v", err)
+	}
+
+	// A peer without credentials cannot be verified, so it is rejected too
+	if _, err := srv.uidInterceptor(context.Background(), nil, nil, handler); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied without peer credentials, got %v", err)
+	}
+}
+
+func TestUIDInterceptorEmptyListAllowsAny(t *testing.T) {
+	srv, err := NewServer(context.Background(), options.DefaultServer)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+
+	if _, err := srv.uidInterceptor(uidContext(999), nil, nil, handler); err != nil {
+		t.Errorf("Expected any UID to pass with no allowlist: %v", err)
+	}
+}
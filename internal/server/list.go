@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"sort"
+
+	"github.com/chainguard-dev/clog"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+// List implements the List RPC. It inventories the secrets the server is
+// guarding — name, lifecycle state and the storage backend holding each
+// payload — without touching any payload or resetting inactivity timers.
+// The backend is uniform across the server today, but it is reported per
+// secret so the shape survives a future where backends differ (e.g. keyring
+// secrets spilling to memory on quota). Alias entries hold no payload of
+// their own and are skipped.
+func (s *Server) List(ctx context.Context, req *common.ListRequest) (*common.ListResponse, error) {
+	s.updateActivity()
+
+	clog.FromContext(ctx).Debug("List request")
+
+	backend := s.storage.Backend()
+	now := s.now()
+
+	s.secretsMu.RLock()
+	entries := make([]*common.SecretListEntry, 0, len(s.secrets))
+	for name, metadata := range s.secrets {
+		if metadata.AliasTarget != "" {
+			continue
+		}
+		entries = append(entries, &common.SecretListEntry{
+			Name:    name,
+			State:   metadata.State(now),
+			Backend: backend,
+		})
+	}
+	s.secretsMu.RUnlock()
+
+	// Map iteration order is random; a stable listing is friendlier to both
+	// humans and tests
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+
+	return &common.ListResponse{Success: true, Secrets: entries}, nil
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+func TestListReportsBackendPerSecret(t *testing.T) {
+	srv := newTestServer(t)
+	addTestSecret(t, srv, "list-b", nil, time.Now())
+	addTestSecret(t, srv, "list-a", nil, time.Now())
+
+	// Alias entries hold no payload and must not show up in the inventory
+	srv.secretsMu.Lock()
+	srv.secrets["list-alias"] = &secrets.Metadata{
+		Name:        "list-alias",
+		AliasTarget: "list-a",
+	}
+	srv.secretsMu.Unlock()
+
+	resp, err := srv.List(context.Background(), &common.ListRequest{})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("List was denied: %s", resp.Error)
+	}
+	if len(resp.Secrets) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(resp.Secrets))
+	}
+
+	// Entries come back sorted by name
+	if resp.Secrets[0].Name != "list-a" || resp.Secrets[1].Name != "list-b" {
+		t.Errorf("Expected a sorted listing, got %s, %s", resp.Secrets[0].Name, resp.Secrets[1].Name)
+	}
+
+	backend := srv.storage.Backend()
+	for _, entry := range resp.Secrets {
+		if entry.Backend != backend {
+			t.Errorf("Expected backend %q for secret %q, got %q", backend, entry.Name, entry.Backend)
+		}
+		if entry.State != secrets.StateLive {
+			t.Errorf("Expected a live state for secret %q, got %q", entry.Name, entry.State)
+		}
+	}
+}
+
+func TestListEmptyServer(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := srv.List(context.Background(), &common.ListRequest{})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("List was denied: %s", resp.Error)
+	}
+	if len(resp.Secrets) != 0 {
+		t.Errorf("Expected an empty inventory, got %d entries", len(resp.Secrets))
+	}
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/chainguard-dev/clog"
+)
+
+// listen creates the Unix domain socket listener the server accepts clients
+// on, applying the configured ownership and permission hardening.
+func (s *Server) listen(ctx context.Context) (net.Listener, error) {
+	// Prefer a listener inherited through systemd socket activation. When the
+	// service manager owns the socket it also owns its path, permissions and
+	// lifecycle, so we skip creating (and chmodding) our own.
+	listener, err := activationListener()
+	if err != nil {
+		return nil, fmt.Errorf("checking socket activation: %w", err)
+	}
+
+	if listener != nil {
+		clog.FromContext(ctx).Debugf("Using systemd-activated listener on %s", listener.Addr())
+		return listener, nil
+	}
+
+	// In strict mode, refuse to put the socket in a directory other local
+	// users could tamper with
+	if s.options.StrictSocketDir {
+		if err := CheckSocketDir(s.options.SocketPath); err != nil {
+			return nil, err
+		}
+	}
+
+	// Remove existing socket file if it already exists
+	if err := os.RemoveAll(s.options.SocketPath); err != nil {
+		return nil, fmt.Errorf("failed to remove existing socket: %w", err)
+	}
+
+	// Create Unix domain socket listener
+	lc := net.ListenConfig{}
+	listener, err = lc.Listen(ctx, "unix", s.options.SocketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on socket: %w", err)
+	}
+
+	// Set socket permissions to be restrictive (owner only), unless a
+	// socket group is configured, in which case hand the socket to that
+	// group and let its members in too.
+	socketMode := os.FileMode(0o600)
+	if s.options.SocketGroup > 0 {
+		if err := os.Chown(s.options.SocketPath, -1, s.options.SocketGroup); err != nil {
+			listener.Close() //nolint:errcheck,gosec
+			return nil, fmt.Errorf("failed to set socket group: %w", err)
+		}
+		socketMode = 0o660
+	}
+	if err := os.Chmod(s.options.SocketPath, socketMode); err != nil {
+		listener.Close() //nolint:errcheck,gosec
+		return nil, fmt.Errorf("failed to set socket permissions: %w", err)
+	}
+
+	clog.FromContext(ctx).Debugf("Server listening on %s", s.options.SocketPath)
+	return listener, nil
+}
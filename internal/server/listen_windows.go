@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/chainguard-dev/clog"
+)
+
+// listen creates the named pipe listener the server accepts clients on. The
+// socket path option carries a pipe name (\\.\pipe\burnafter-<hash>) on
+// Windows; the filesystem permission hardening of the Unix path does not
+// apply, as the pipe's default security descriptor already restricts access
+// to the creating user.
+func (s *Server) listen(ctx context.Context) (net.Listener, error) {
+	listener, err := ListenPipe(s.options.SocketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on pipe: %w", err)
+	}
+
+	clog.FromContext(ctx).Debugf("Server listening on %s", s.options.SocketPath)
+	return listener, nil
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+
+	"github.com/chainguard-dev/clog"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+// Locate implements the Locate RPC. It is a diagnostic call that reports
+// which storage backend is guarding a live secret, without touching the
+// secret's payload or resetting its inactivity timer.
+func (s *Server) Locate(ctx context.Context, req *common.LocateRequest) (*common.LocateResponse, error) {
+	s.updateActivity()
+
+	clog.FromContext(ctx).Debugf("Locate request for secret: %s", req.Name)
+
+	s.secretsMu.RLock()
+	metadata, exists := s.secrets[req.Name]
+	var state string
+	if exists {
+		state = metadata.State(s.now())
+	}
+	s.secretsMu.RUnlock()
+
+	if !exists {
+		return &common.LocateResponse{
+			Success: false,
+			Error:   "secret not found",
+		}, nil
+	}
+
+	// Report an expired secret as gone, but include its state so callers can
+	// see it is merely awaiting the next cleanup sweep.
+	if state != secrets.StateLive {
+		return &common.LocateResponse{
+			Success: false,
+			Error:   "secret not found",
+			State:   state,
+		}, nil
+	}
+
+	return &common.LocateResponse{
+		Success:  true,
+		Location: s.storage.Backend(),
+		State:    state,
+	}, nil
+}
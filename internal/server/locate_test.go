@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+func TestLocate(t *testing.T) {
+	srv := newTestServer(t)
+	ctx := testPeerContext()
+
+	addTestSecret(t, srv, "locate-me", nil, time.Now())
+
+	resp, err := srv.Locate(ctx, &common.LocateRequest{Name: "locate-me"})
+	if err != nil {
+		t.Fatalf("Locate failed: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("Locate error: %s", resp.Error)
+	}
+	if resp.Location != srv.storage.Backend() {
+		t.Errorf("Expected location %q, got %q", srv.storage.Backend(), resp.Location)
+	}
+}
+
+func TestLocateNotFound(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := srv.Locate(testPeerContext(), &common.LocateRequest{Name: "missing"})
+	if err != nil {
+		t.Fatalf("Locate failed: %v", err)
+	}
+	if resp.Success {
+		t.Errorf("Expected locate of an unknown secret to fail")
+	}
+}
+
+func TestLocateExpired(t *testing.T) {
+	srv := newTestServer(t)
+
+	// A secret past its inactivity TTL must be reported as gone
+	addTestSecret(t, srv, "stale", nil, time.Now().Add(-2*time.Hour))
+
+	resp, err := srv.Locate(testPeerContext(), &common.LocateRequest{Name: "stale"})
+	if err != nil {
+		t.Fatalf("Locate failed: %v", err)
+	}
+	if resp.Success {
+		t.Errorf("Expected locate of an expired secret to fail")
+	}
+	if resp.State != secrets.StateExpiredInactivity {
+		t.Errorf("Expected state %q, got %q", secrets.StateExpiredInactivity, resp.State)
+	}
+}
+
+func TestLocateExpiredAbsolute(t *testing.T) {
+	srv := newTestServer(t)
+
+	// A secret past its absolute deadline reports the absolute state even if
+	// it was accessed recently
+	addTestSecret(t, srv, "deadline", nil, time.Now())
+	past := time.Now().Add(-time.Minute)
+	srv.secretsMu.Lock()
+	srv.secrets["deadline"].AbsoluteExpiresAt = &past
+	srv.secretsMu.Unlock()
+
+	resp, err := srv.Locate(testPeerContext(), &common.LocateRequest{Name: "deadline"})
+	if err != nil {
+		t.Fatalf("Locate failed: %v", err)
+	}
+	if resp.Success {
+		t.Errorf("Expected locate of an expired secret to fail")
+	}
+	if resp.State != secrets.StateExpiredAbsolute {
+		t.Errorf("Expected state %q, got %q", secrets.StateExpiredAbsolute, resp.State)
+	}
+}
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// clientAdmissionWindow is how long an admitted client stays "established"
+// after its last request. Identities idle beyond the window free their slot
+// for new clients, so a daemon serving ephemeral processes does not fill up
+// with ghosts.
+const clientAdmissionWindow = time.Minute
+
+// clientLimitInterceptor is a grpc unary interceptor enforcing the MaxClients
+// option: a coarse admission control capping how many distinct client
+// processes a shared daemon serves at once. Like the rate limiter, clients
+// are keyed by their kernel-reported peer identity (UID and PID), which is
+// free to read per request. Established clients keep being served; new ones
+// beyond the cap are rejected until a slot frees up.
+func (s *Server) clientLimitInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := s.admitClientSlot(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// admitClientSlot performs the MaxClients admission check shared by the unary
+// and stream interceptors.
+func (s *Server) admitClientSlot(ctx context.Context) error {
+	if s.options.MaxClients <= 0 {
+		return nil
+	}
+
+	authInfo, err := GetPeerAuthInfo(ctx)
+	if err != nil {
+		return status.Errorf(codes.PermissionDenied, "cannot verify peer credentials: %v", err)
+	}
+	key := fmt.Sprintf("%d:%d", authInfo.UID, authInfo.PID)
+	now := time.Now()
+
+	s.activeClientsMu.Lock()
+	// Slide the window: identities not seen recently give up their slot
+	for k, seen := range s.activeClients {
+		if now.Sub(seen) > clientAdmissionWindow {
+			delete(s.activeClients, k)
+		}
+	}
+	_, established := s.activeClients[key]
+	if !established && len(s.activeClients) >= s.options.MaxClients {
+		s.activeClientsMu.Unlock()
+		return status.Errorf(codes.ResourceExhausted,
+			"server is already serving its maximum of %d clients", s.options.MaxClients)
+	}
+	s.activeClients[key] = now
+	s.activeClientsMu.Unlock()
+
+	return nil
+}
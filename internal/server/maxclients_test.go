@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func TestClientLimitInterceptorCapsDistinctClients(t *testing.T) {
+	opts := *options.DefaultServer
+	opts.MaxClients = 2
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+
+	// The first two distinct clients are admitted
+	for _, pid := range []int32{5001, 5002} {
+		if _, err := srv.clientLimitInterceptor(pidContext(pid), nil, nil, handler); err != nil {
+			t.Fatalf("Expected client %d to be admitted, got %v", pid, err)
+		}
+	}
+
+	// A third distinct client is over the cap
+	_, err = srv.clientLimitInterceptor(pidContext(5003), nil, nil, handler)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected ResourceExhausted for the third client, got %v", err)
+	}
+
+	// Established clients keep being served while the server is full
+	for _, pid := range []int32{5001, 5002} {
+		if _, err := srv.clientLimitInterceptor(pidContext(pid), nil, nil, handler); err != nil {
+			t.Errorf("Expected established client %d to keep passing, got %v", pid, err)
+		}
+	}
+}
+
+func TestClientLimitInterceptorSlidingWindow(t *testing.T) {
+	opts := *options.DefaultServer
+	opts.MaxClients = 1
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+
+	if _, err := srv.clientLimitInterceptor(pidContext(6001), nil, nil, handler); err != nil {
+		t.Fatalf("Expected the first client to be admitted, got %v", err)
+	}
+	if _, err := srv.clientLimitInterceptor(pidContext(6002), nil, nil, handler); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Expected the second client to be rejected, got %v", err)
+	}
+
+	// Age the first client past the admission window: its slot frees up
+	srv.activeClientsMu.Lock()
+	srv.activeClients["1000:6001"] = time.Now().Add(-clientAdmissionWindow - time.Second)
+	srv.activeClientsMu.Unlock()
+
+	if _, err := srv.clientLimitInterceptor(pidContext(6002), nil, nil, handler); err != nil {
+		t.Errorf("Expected the idle slot to admit a new client, got %v", err)
+	}
+}
+
+func TestClientLimitInterceptorUnlimitedByDefault(t *testing.T) {
+	srv := newTestServer(t)
+
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+	for pid := int32(7000); pid < 7050; pid++ {
+		if _, err := srv.clientLimitInterceptor(pidContext(pid), nil, nil, handler); err != nil {
+			t.Fatalf("Expected the unlimited interceptor to pass requests: %v", err)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+func TestGetMaxReadsBurnsSecret(t *testing.T) {
+	srv := newTestServer(t)
+	ctx := testPeerContext()
+
+	storeResp, err := srv.Store(ctx, &common.StoreRequest{
+		Name:        "budgeted",
+		Secret:      []byte("v"),
+		ClientNonce: "test-nonce",
+		MaxReads:    2,
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, storeResp.GetError())
+	}
+
+	// The first two reads succeed
+	for i := range 2 {
+		getResp, err := srv.Get(ctx, &common.GetRequest{Name: "budgeted", ClientNonce: "test-nonce"})
+		if err != nil {
+			t.Fatalf("Get #%d failed: %v", i+1, err)
+		}
+		if !getResp.Success {
+			t.Fatalf("Expected read #%d to succeed, got: %s", i+1, getResp.Error)
+		}
+	}
+
+	// The budget is spent: the secret is gone from metadata and storage
+	getResp, err := srv.Get(ctx, &common.GetRequest{Name: "budgeted", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if getResp.Success {
+		t.Fatalf("Expected the exhausted secret to be gone")
+	}
+	if !strings.Contains(getResp.Error, "not found") {
+		t.Errorf("Expected a not-found error, got: %s", getResp.Error)
+	}
+	srv.secretsMu.RLock()
+	_, tracked := srv.secrets["budgeted"]
+	srv.secretsMu.RUnlock()
+	if tracked {
+		t.Errorf("Expected the exhausted secret's metadata to be dropped")
+	}
+}
+
+func TestGetMaxReadsFailedReadDoesNotConsume(t *testing.T) {
+	srv := newTestServer(t)
+	ctx := testPeerContext()
+
+	storeResp, err := srv.Store(ctx, &common.StoreRequest{
+		Name:        "one-shot",
+		Secret:      []byte("v"),
+		ClientNonce: "test-nonce",
+		MaxReads:    1,
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, storeResp.GetError())
+	}
+
+	// A refused read (here: a still-sealed secret) must not spend the budget
+	srv.secretsMu.Lock()
+	sealed := time.Now().Add(time.Hour)
+	srv.secrets["one-shot"].NotBefore = &sealed
+	srv.secretsMu.Unlock()
+
+	getResp, err := srv.Get(ctx, &common.GetRequest{Name: "one-shot", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if getResp.Success {
+		t.Fatalf("Expected the sealed read to be refused")
+	}
+
+	// Once the seal opens, the single read is still available
+	srv.secretsMu.Lock()
+	srv.secrets["one-shot"].NotBefore = nil
+	srv.secretsMu.Unlock()
+
+	getResp, err = srv.Get(ctx, &common.GetRequest{Name: "one-shot", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !getResp.Success {
+		t.Errorf("Expected the one-shot secret to still be readable, got: %s", getResp.Error)
+	}
+}
+
+func TestStoreNegativeMaxReads(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:        "bad-budget",
+		Secret:      []byte("v"),
+		ClientNonce: "test-nonce",
+		MaxReads:    -1,
+	})
+	if err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if resp.Success {
+		t.Errorf("Expected a negative read budget to be rejected")
+	}
+}
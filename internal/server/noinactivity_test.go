@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+func TestStoreNoInactivityExpiry(t *testing.T) {
+	srv := newTestServer(t)
+	ctx := testPeerContext()
+
+	storeResp, err := srv.Store(ctx, &common.StoreRequest{
+		Name:                      "deadline-only",
+		Secret:                    []byte("value"),
+		ClientNonce:               "test-nonce",
+		TtlSeconds:                -1,
+		AbsoluteExpirationSeconds: 3600,
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, storeResp.GetError())
+	}
+
+	// Backdate the last access far past any plausible inactivity window: the
+	// secret must still be served
+	srv.secretsMu.Lock()
+	srv.secrets["deadline-only"].LastAccessed = time.Now().Add(-30 * 24 * time.Hour)
+	srv.secretsMu.Unlock()
+
+	getResp, err := srv.Get(ctx, &common.GetRequest{Name: "deadline-only", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !getResp.Success {
+		t.Fatalf("Expected the secret to survive inactivity, got: %s", getResp.Error)
+	}
+	if string(getResp.Secret) != "value" {
+		t.Errorf("Expected value, got %q", getResp.Secret)
+	}
+}
+
+func TestStoreNoInactivityExpiryDiesAtDeadline(t *testing.T) {
+	srv := newTestServer(t)
+	ctx := testPeerContext()
+
+	storeResp, err := srv.Store(ctx, &common.StoreRequest{
+		Name:                      "deadline-bound",
+		Secret:                    []byte("value"),
+		ClientNonce:               "test-nonce",
+		TtlSeconds:                -1,
+		AbsoluteExpirationSeconds: 3600,
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, storeResp.GetError())
+	}
+
+	// Move the deadline into the past (and backdate access, which must not
+	// matter): only the deadline may kill this secret, and it does
+	srv.secretsMu.Lock()
+	deadline := time.Now().Add(-time.Minute)
+	srv.secrets["deadline-bound"].AbsoluteExpiresAt = &deadline
+	srv.secrets["deadline-bound"].LastAccessed = time.Now().Add(-30 * 24 * time.Hour)
+	srv.secretsMu.Unlock()
+
+	getResp, err := srv.Get(ctx, &common.GetRequest{Name: "deadline-bound", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if getResp.Success {
+		t.Fatalf("Expected the secret to die at its absolute deadline")
+	}
+}
+
+func TestStoreNoInactivityExpiryRequiresDeadline(t *testing.T) {
+	srv := newTestServer(t)
+	ctx := testPeerContext()
+
+	storeResp, err := srv.Store(ctx, &common.StoreRequest{
+		Name:        "immortal",
+		Secret:      []byte("value"),
+		ClientNonce: "test-nonce",
+		TtlSeconds:  -1,
+	})
+	if err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if storeResp.Success {
+		t.Fatalf("Expected a store without any expiry to be rejected")
+	}
+}
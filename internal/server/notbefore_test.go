@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+func TestGetNotBeforeSealed(t *testing.T) {
+	srv := newTestServer(t)
+	ctx := testPeerContext()
+
+	storeResp, err := srv.Store(ctx, &common.StoreRequest{
+		Name:        "sealed",
+		Secret:      []byte("v"),
+		ClientNonce: "test-nonce",
+		NotBefore:   time.Now().Add(time.Hour).Unix(),
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, storeResp.GetError())
+	}
+
+	// Before the window opens reads are refused
+	getResp, err := srv.Get(ctx, &common.GetRequest{Name: "sealed", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if getResp.Success {
+		t.Fatalf("Expected a sealed secret to refuse reads")
+	}
+	if !strings.Contains(getResp.Error, "not yet valid") {
+		t.Errorf("Expected a not-yet-valid error, got: %s", getResp.Error)
+	}
+
+	// Once the seal time passes, the secret reads normally
+	srv.secretsMu.Lock()
+	past := time.Now().Add(-time.Minute)
+	srv.secrets["sealed"].NotBefore = &past
+	srv.secretsMu.Unlock()
+
+	getResp, err = srv.Get(ctx, &common.GetRequest{Name: "sealed", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !getResp.Success {
+		t.Fatalf("Expected the opened secret to read, got: %s", getResp.Error)
+	}
+	if string(getResp.Secret) != "v" {
+		t.Errorf("Expected v, got %q", getResp.Secret)
+	}
+}
+
+func TestGetNotBeforeComposesWithExpiry(t *testing.T) {
+	srv := newTestServer(t)
+	ctx := testPeerContext()
+
+	// A window that already opened and already closed: expiry wins
+	storeResp, err := srv.Store(ctx, &common.StoreRequest{
+		Name:        "window-closed",
+		Secret:      []byte("v"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, storeResp.GetError())
+	}
+	srv.secretsMu.Lock()
+	opened := time.Now().Add(-2 * time.Hour)
+	closed := time.Now().Add(-time.Hour)
+	srv.secrets["window-closed"].NotBefore = &opened
+	srv.secrets["window-closed"].AbsoluteExpiresAt = &closed
+	srv.secretsMu.Unlock()
+
+	getResp, err := srv.Get(ctx, &common.GetRequest{Name: "window-closed", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if getResp.Success {
+		t.Errorf("Expected an expired secret to refuse reads")
+	}
+}
+
+func TestStoreNotBeforePastDeadline(t *testing.T) {
+	srv := newTestServer(t)
+
+	// A seal at or past the absolute deadline leaves no readable window
+	resp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:                      "never-readable",
+		Secret:                    []byte("v"),
+		ClientNonce:               "test-nonce",
+		AbsoluteExpirationSeconds: 60,
+		NotBefore:                 time.Now().Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if resp.Success {
+		t.Errorf("Expected a seal past the deadline to be rejected")
+	}
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/peer"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	isecrets "github.com/carabiner-dev/burnafter/internal/secrets"
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// zeroPIDContext returns a peer context with a PID of 0, simulating
+// SO_PEERCRED failing to report the peer's process ID (as happens across
+// some PID namespace boundaries).
+func zeroPIDContext() context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: &peerAuthInfo{
+			PID: 0,
+			UID: uint32(os.Getuid()),
+			GID: uint32(os.Getgid()),
+		},
+	})
+}
+
+func TestZeroPIDRejectedByDefault(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := srv.Store(zeroPIDContext(), &common.StoreRequest{
+		Name:        "pidless",
+		Secret:      []byte("value"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if resp.Success {
+		t.Fatalf("Expected a PID-less peer to be rejected by default")
+	}
+	if !strings.Contains(resp.Error, common.ErrPeerPIDUnavailable.Error()) {
+		t.Errorf("Expected the peer PID error to surface, got %q", resp.Error)
+	}
+}
+
+func TestZeroPIDUIDOnlyVerification(t *testing.T) {
+	opts := *options.DefaultServer
+	opts.AllowUIDOnlyVerification = true
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	srv.storage = isecrets.NewMemoryStorage()
+
+	// With the option enabled, a PID-less peer can store and read back using
+	// keys bound to its UID instead of a binary hash
+	storeResp, err := srv.Store(zeroPIDContext(), &common.StoreRequest{
+		Name:        "uid-only",
+		Secret:      []byte("uid-only-value"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v %s", err, storeResp.GetError())
+	}
+
+	getResp, err := srv.Get(zeroPIDContext(), &common.GetRequest{
+		Name:        "uid-only",
+		ClientNonce: "test-nonce",
+	})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !getResp.Success {
+		t.Fatalf("Expected UID-only retrieval to succeed: %s", getResp.Error)
+	}
+	if string(getResp.Secret) != "uid-only-value" {
+		t.Errorf("Expected uid-only-value, got %q", getResp.Secret)
+	}
+}
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+// +build windows
+
+package server
+
+import (
+	"fmt"
+	"net"
+)
+
+// GetPeerCredentials is the Unix socket credential extractor. Windows clients
+// connect over named pipes instead, so a Unix connection here is unexpected.
+func GetPeerCredentials(_ *net.UnixConn) (pid int32, uid uint32, gid uint32, err error) {
+	return 0, 0, 0, fmt.Errorf("unix socket peer credentials are not supported on windows")
+}
+
+// pipeAuthInfo builds auth info for a named pipe connection. The kernel
+// records the client PID when it connects; Windows has no numeric UID/GID,
+// so those stay zero and peer identity rests on the binary hash check.
+func pipeAuthInfo(conn net.Conn) *peerAuthInfo {
+	pc, ok := conn.(*pipeConn)
+	if !ok {
+		return &peerAuthInfo{}
+	}
+	pid, err := pc.peerPID()
+	if err != nil {
+		return &peerAuthInfo{}
+	}
+	return &peerAuthInfo{PID: pid}
+}
@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"google.golang.org/grpc/peer"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	isecrets "github.com/carabiner-dev/burnafter/internal/secrets"
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// peerContext builds a peer context with explicit credentials, standing in
+// for peers the default helpers cannot express (e.g. a PID-less peer across
+// a namespace boundary, or a different UID).
+func peerContext(pid int32, uid uint32) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: &peerAuthInfo{
+			PID: pid,
+			UID: uid,
+			GID: uint32(os.Getgid()),
+		},
+	})
+}
+
+func newPeerVerificationTestServer(t *testing.T, mode string) *Server {
+	t.Helper()
+
+	opts := *options.DefaultServer
+	opts.PeerVerification = mode
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	srv.storage = isecrets.NewMemoryStorage()
+	return srv
+}
+
+func TestPeerVerificationUIDOnly(t *testing.T) {
+	srv := newPeerVerificationTestServer(t, "uid-only")
+	uid := uint32(os.Getuid())
+
+	storeResp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:        "sidecar-secret",
+		Secret:      []byte("sidecar-value"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v %s", err, storeResp.GetError())
+	}
+
+	// A different binary and even a PID-less peer read the secret back, as
+	// long as they run as the storing UID
+	for _, ctx := range []context.Context{otherBinaryContext(), peerContext(0, uid)} {
+		getResp, err := srv.Get(ctx, &common.GetRequest{
+			Name:        "sidecar-secret",
+			ClientNonce: "test-nonce",
+		})
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if !getResp.Success {
+			t.Fatalf("Expected uid-only mode to admit the peer: %s", getResp.Error)
+		}
+		if string(getResp.Secret) != "sidecar-value" {
+			t.Errorf("Expected sidecar-value, got %q", getResp.Secret)
+		}
+	}
+
+	// A different UID derives a different key and cannot decrypt
+	getResp, err := srv.Get(peerContext(1, uid+1), &common.GetRequest{
+		Name:        "sidecar-secret",
+		ClientNonce: "test-nonce",
+	})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if getResp.Success {
+		t.Errorf("Expected a different UID to be rejected in uid-only mode")
+	}
+}
+
+func TestPeerVerificationDisabled(t *testing.T) {
+	srv := newPeerVerificationTestServer(t, "disabled")
+
+	storeResp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:        "open-secret",
+		Secret:      []byte("open-value"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v %s", err, storeResp.GetError())
+	}
+
+	// Any peer on the socket reads it back: different binary, different UID,
+	// no PID at all
+	getResp, err := srv.Get(peerContext(0, uint32(os.Getuid())+1), &common.GetRequest{
+		Name:        "open-secret",
+		ClientNonce: "test-nonce",
+	})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !getResp.Success {
+		t.Fatalf("Expected disabled mode to admit any peer: %s", getResp.Error)
+	}
+	if string(getResp.Secret) != "open-value" {
+		t.Errorf("Expected open-value, got %q", getResp.Secret)
+	}
+}
+
+func TestPeerVerificationStrictKeepsBinaryGate(t *testing.T) {
+	srv := newPeerVerificationTestServer(t, "strict")
+
+	storeResp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:        "strict-secret",
+		Secret:      []byte("value"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v %s", err, storeResp.GetError())
+	}
+
+	// Spelling out "strict" behaves like the default: a different binary is
+	// still rejected
+	getResp, err := srv.Get(otherBinaryContext(), &common.GetRequest{
+		Name:        "strict-secret",
+		ClientNonce: "test-nonce",
+	})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if getResp.Success {
+		t.Errorf("Expected strict mode to keep the binary gate")
+	}
+}
+
+func TestPeerVerificationInvalidMode(t *testing.T) {
+	opts := *options.DefaultServer
+	opts.PeerVerification = "paranoid"
+	if _, err := NewServer(context.Background(), &opts); err == nil {
+		t.Errorf("Expected an unknown peer verification mode to fail startup")
+	}
+}
+
+func TestPeerVerificationConflictsWithDigestList(t *testing.T) {
+	opts := *options.DefaultServer
+	opts.PeerVerification = "uid-only"
+	opts.TrustedClientDigests = []string{"deadbeef"}
+	if _, err := NewServer(context.Background(), &opts); err == nil {
+		t.Errorf("Expected a trusted digest list to be rejected outside strict mode")
+	}
+}
@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+
+package server
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// The Windows transport speaks over named pipes (\\.\pipe\burnafter-<hash>)
+// instead of Unix domain sockets. Pipes are kernel objects rather than
+// filesystem entries: they vanish with their last handle, so there are no
+// stale socket files to reap, and the kernel reports the client process ID
+// directly through GetNamedPipeClientProcessId for peer verification.
+
+// pipeAddr satisfies net.Addr for named pipe endpoints.
+type pipeAddr string
+
+func (a pipeAddr) Network() string { return "pipe" }
+func (a pipeAddr) String() string  { return string(a) }
+
+// pipeConn wraps a named pipe handle as a net.Conn. I/O is synchronous;
+// deadlines are accepted but not enforced, which gRPC's HTTP/2 transport
+// tolerates (it uses them only to bound handshakes).
+type pipeConn struct {
+	handle windows.Handle
+	addr   pipeAddr
+	server bool
+}
+
+func (c *pipeConn) Read(p []byte) (int, error) {
+	var done uint32
+	err := windows.ReadFile(c.handle, p, &done, nil)
+	if err != nil {
+		// A closing peer surfaces as a broken pipe: report EOF like a
+		// socket would so the HTTP/2 transport shuts the stream down cleanly
+		if err == windows.ERROR_BROKEN_PIPE {
+			return int(done), io.EOF
+		}
+		return int(done), err
+	}
+	if done == 0 && len(p) > 0 {
+		return 0, io.EOF
+	}
+	return int(done), nil
+}
+
+func (c *pipeConn) Write(p []byte) (int, error) {
+	var done uint32
+	if err := windows.WriteFile(c.handle, p, &done, nil); err != nil {
+		return int(done), err
+	}
+	return int(done), nil
+}
+
+func (c *pipeConn) Close() error {
+	if c.server {
+		// Push buffered bytes to the client before tearing the instance down
+		windows.FlushFileBuffers(c.handle)    //nolint:errcheck,gosec
+		windows.DisconnectNamedPipe(c.handle) //nolint:errcheck,gosec
+	}
+	return windows.CloseHandle(c.handle)
+}
+
+func (c *pipeConn) LocalAddr() net.Addr              { return c.addr }
+func (c *pipeConn) RemoteAddr() net.Addr             { return c.addr }
+func (c *pipeConn) SetDeadline(time.Time) error      { return nil }
+func (c *pipeConn) SetReadDeadline(time.Time) error  { return nil }
+func (c *pipeConn) SetWriteDeadline(time.Time) error { return nil }
+
+// peerPID reports the process ID on the other end of the pipe, as recorded by
+// the kernel when the client connected.
+func (c *pipeConn) peerPID() (int32, error) {
+	var pid uint32
+	if err := windows.GetNamedPipeClientProcessId(c.handle, &pid); err != nil {
+		return 0, fmt.Errorf("getting pipe client process id: %w", err)
+	}
+	return int32(pid), nil //nolint:gosec // PIDs fit in int32
+}
+
+const pipeBufferSize = 64 * 1024
+
+// pipeListener serves successive instances of a named pipe as a net.Listener.
+type pipeListener struct {
+	path    *uint16
+	addr    pipeAddr
+	closed  chan struct{}
+	pending chan windows.Handle
+}
+
+// ListenPipe creates a named pipe listener at path (\\.\pipe\<name>). The
+// default pipe security descriptor limits access to the creating user, which
+// matches the 0600 socket mode used on Unix.
+func ListenPipe(path string) (net.Listener, error) {
+	path16, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pipe path: %w", err)
+	}
+
+	l := &pipeListener{
+		path:    path16,
+		addr:    pipeAddr(path),
+		closed:  make(chan struct{}),
+		pending: make(chan windows.Handle, 1),
+	}
+
+	// Create the first instance eagerly so a second server fails here, the
+	// way a Unix bind on a taken path would
+	first, err := l.newInstance(true)
+	if err != nil {
+		return nil, fmt.Errorf("creating pipe: %w", err)
+	}
+	l.pending <- first
+
+	return l, nil
+}
+
+// newInstance creates one pipe instance handle, ready to accept a client.
+func (l *pipeListener) newInstance(first bool) (windows.Handle, error) {
+	flags := uint32(windows.PIPE_ACCESS_DUPLEX)
+	if first {
+		flags |= windows.FILE_FLAG_FIRST_PIPE_INSTANCE
+	}
+	return windows.CreateNamedPipe(
+		l.path,
+		flags,
+		windows.PIPE_TYPE_BYTE|windows.PIPE_READMODE_BYTE|windows.PIPE_WAIT,
+		windows.PIPE_UNLIMITED_INSTANCES,
+		pipeBufferSize,
+		pipeBufferSize,
+		0,
+		nil,
+	)
+}
+
+func (l *pipeListener) Accept() (net.Conn, error) {
+	var handle windows.Handle
+	select {
+	case handle = <-l.pending:
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+
+	if err := windows.ConnectNamedPipe(handle, nil); err != nil &&
+		err != windows.ERROR_PIPE_CONNECTED {
+		windows.CloseHandle(handle) //nolint:errcheck,gosec
+		select {
+		case <-l.closed:
+			return nil, net.ErrClosed
+		default:
+		}
+		return nil, fmt.Errorf("accepting pipe connection: %w", err)
+	}
+
+	// Stage the next instance before handing this connection out, so a
+	// client dialing right behind this one finds an endpoint to open
+	next, err := l.newInstance(false)
+	if err != nil {
+		windows.CloseHandle(handle) //nolint:errcheck,gosec
+		return nil, fmt.Errorf("creating next pipe instance: %w", err)
+	}
+	l.pending <- next
+
+	return &pipeConn{handle: handle, addr: l.addr, server: true}, nil
+}
+
+func (l *pipeListener) Close() error {
+	select {
+	case <-l.closed:
+		return nil
+	default:
+	}
+	close(l.closed)
+	// Closing the staged instance unblocks a pending ConnectNamedPipe
+	select {
+	case handle := <-l.pending:
+		windows.CloseHandle(handle) //nolint:errcheck,gosec
+	default:
+	}
+	return nil
+}
+
+func (l *pipeListener) Addr() net.Addr { return l.addr }
+
+// DialPipe opens a client connection to a named pipe, retrying briefly while
+// all instances are busy. Busy windows are short because the listener stages
+// the next instance before handing a connection out, so a bounded retry is
+// enough.
+func DialPipe(path string) (net.Conn, error) {
+	path16, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pipe path: %w", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		handle, err := windows.CreateFile(
+			path16,
+			windows.GENERIC_READ|windows.GENERIC_WRITE,
+			0,
+			nil,
+			windows.OPEN_EXISTING,
+			0,
+			0,
+		)
+		if err == nil {
+			return &pipeConn{handle: handle, addr: pipeAddr(path)}, nil
+		}
+		if err != windows.ERROR_PIPE_BUSY || time.Now().After(deadline) {
+			return nil, fmt.Errorf("dialing pipe: %w", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,14 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package server
+
+import "net"
+
+// pipeAuthInfo builds auth info for non-socket connections. Named pipes only
+// exist on Windows, so there are no credentials to extract here.
+func pipeAuthInfo(net.Conn) *peerAuthInfo {
+	return &peerAuthInfo{}
+}
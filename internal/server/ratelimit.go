@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// bucketIdleExpiry is how long a client's bucket may sit untouched before it
+// is discarded. A bucket refills completely after one second, so anything
+// older carries no throttling state worth keeping.
+const bucketIdleExpiry = 2 * time.Second
+
+// tokenBucket is a minimal token bucket: capacity tokens, refilled at rate
+// tokens per second. Not safe for concurrent use; the owner locks around it.
+type tokenBucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+// take refills the bucket for the time elapsed since the last call and
+// consumes one token, reporting whether one was available.
+func (b *tokenBucket) take(rate float64, now time.Time) bool {
+	b.tokens += now.Sub(b.lastRefill).Seconds() * rate
+	if b.tokens > rate {
+		b.tokens = rate // Cap the burst at one second's worth
+	}
+	b.lastRefill = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// rateLimitInterceptor is a grpc unary interceptor that throttles each client
+// to RequestsPerSecondPerClient, so one misbehaving process cannot monopolize
+// the daemon (or the single locked keyring worker thread). Clients are keyed
+// by their kernel-reported peer identity (UID and PID), which is free to read
+// per request — deliberately not the binary hash, since recomputing that hash
+// is exactly the expensive work the limiter protects.
+func (s *Server) rateLimitInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := s.admitRequestRate(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// admitRequestRate performs the per-client rate limit check shared by the
+// unary and stream interceptors. A stream counts as one request regardless of
+// how many chunks it carries, consistent with the limiter's purpose of capping
+// the per-request setup cost.
+func (s *Server) admitRequestRate(ctx context.Context) error {
+	if s.options.RequestsPerSecondPerClient <= 0 {
+		return nil
+	}
+
+	authInfo, err := GetPeerAuthInfo(ctx)
+	if err != nil {
+		return status.Errorf(codes.PermissionDenied, "cannot verify peer credentials: %v", err)
+	}
+	key := fmt.Sprintf("%d:%d", authInfo.UID, authInfo.PID)
+	rate := float64(s.options.RequestsPerSecondPerClient)
+	now := time.Now()
+
+	s.rateLimitersMu.Lock()
+	// Drop buckets idle long enough to be fully refilled: they are
+	// indistinguishable from fresh ones, and without the sweep a daemon
+	// serving ephemeral processes accumulates a bucket per dead PID forever
+	for k, b := range s.rateLimiters {
+		if now.Sub(b.lastRefill) > bucketIdleExpiry {
+			delete(s.rateLimiters, k)
+		}
+	}
+	bucket, ok := s.rateLimiters[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: rate, lastRefill: now}
+		s.rateLimiters[key] = bucket
+	}
+	allowed := bucket.take(rate, now)
+	s.rateLimitersMu.Unlock()
+
+	if !allowed {
+		return status.Errorf(codes.ResourceExhausted,
+			"client exceeded %d requests per second", s.options.RequestsPerSecondPerClient)
+	}
+
+	return nil
+}
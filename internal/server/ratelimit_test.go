@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// pidContext returns a peer context for a simulated client process.
+func pidContext(pid int32) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: &peerAuthInfo{PID: pid, UID: 1000, GID: 1000},
+	})
+}
+
+func TestRateLimitInterceptorThrottlesBurst(t *testing.T) {
+	opts := *options.DefaultServer
+	opts.RequestsPerSecondPerClient = 5
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+
+	// A burst from one client: the first rate's worth passes, the rest are
+	// throttled with ResourceExhausted.
+	var passed, throttled int
+	for range 20 {
+		_, err := srv.rateLimitInterceptor(pidContext(4242), nil, nil, handler)
+		switch status.Code(err) {
+		case codes.OK:
+			passed++
+		case codes.ResourceExhausted:
+			throttled++
+		default:
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if passed == 0 || passed > opts.RequestsPerSecondPerClient+1 {
+		t.Errorf("Expected roughly %d requests to pass, got %d", opts.RequestsPerSecondPerClient, passed)
+	}
+	if throttled == 0 {
+		t.Errorf("Expected the burst to be throttled")
+	}
+
+	// A different client must be unaffected by the first one's burst
+	if _, err := srv.rateLimitInterceptor(pidContext(4243), nil, nil, handler); err != nil {
+		t.Errorf("Expected a second client to pass, got %v", err)
+	}
+}
+
+func TestRateLimitPrunesIdleBuckets(t *testing.T) {
+	opts := *options.DefaultServer
+	opts.RequestsPerSecondPerClient = 5
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+
+	// A crowd of one-shot clients, then age their buckets past the idle
+	// expiry: the next request's sweep must clear the dead entries
+	for pid := range int32(50) {
+		if _, err := srv.rateLimitInterceptor(pidContext(5000+pid), nil, nil, handler); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	srv.rateLimitersMu.Lock()
+	for _, bucket := range srv.rateLimiters {
+		bucket.lastRefill = bucket.lastRefill.Add(-2 * bucketIdleExpiry)
+	}
+	srv.rateLimitersMu.Unlock()
+
+	if _, err := srv.rateLimitInterceptor(pidContext(4242), nil, nil, handler); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	srv.rateLimitersMu.Lock()
+	remaining := len(srv.rateLimiters)
+	srv.rateLimitersMu.Unlock()
+	if remaining != 1 {
+		t.Errorf("Expected only the live client's bucket to remain, got %d", remaining)
+	}
+}
+
+func TestRateLimitInterceptorUnlimitedByDefault(t *testing.T) {
+	srv := newTestServer(t)
+
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+	for range 100 {
+		// No peer info in the context: with the limiter disabled the request
+		// must still pass straight through.
+		if _, err := srv.rateLimitInterceptor(context.Background(), nil, nil, handler); err != nil {
+			t.Fatalf("Expected unlimited interceptor to pass requests: %v", err)
+		}
+	}
+}
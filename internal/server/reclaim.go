@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chainguard-dev/clog"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+// Reclaim implements the Reclaim RPC. After a legitimate binary upgrade the
+// caller's hash no longer matches the one its secrets were stored under, so
+// every Get is denied and the secrets are orphaned. Reclaim re-encrypts a
+// secret under the caller's current hash — but only when the server opts into
+// reclaims and the peer's UID matches the original storer's, so a different
+// user's (or a planted) binary cannot walk off with the secret. The deny
+// behavior of Get itself is unchanged.
+func (s *Server) Reclaim(ctx context.Context, req *common.ReclaimRequest) (*common.ReclaimResponse, error) {
+	s.updateActivity()
+
+	clog.FromContext(ctx).Debugf("Reclaim request for secret: %s", req.Name)
+
+	if !s.options.AllowReclaim {
+		return &common.ReclaimResponse{
+			Success: false,
+			Error:   "reclaim is disabled on this server",
+		}, nil
+	}
+
+	// Get client PID and verify binary
+	authInfo, err := GetPeerAuthInfo(ctx)
+	if err != nil {
+		return &common.ReclaimResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get client credentials: %v", err),
+		}, nil
+	}
+
+	clientHash, err := s.clientKeyHash(authInfo)
+	if err != nil {
+		return &common.ReclaimResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to verify client binary: %v", err),
+		}, nil
+	}
+
+	s.secretsMu.Lock()
+	defer s.secretsMu.Unlock()
+
+	now := s.now()
+	name, metadata, aliasErr := s.resolveAliasLocked(req.Name)
+	if aliasErr != nil || metadata.State(now) != secrets.StateLive {
+		return &common.ReclaimResponse{
+			Success: false,
+			Error:   "secret not found",
+		}, nil
+	}
+
+	// The UID gate: only the user that stored the secret may re-bind it
+	if authInfo.UID != metadata.OwnerUID {
+		clog.FromContext(ctx).Warnf(
+			"AUDIT: denied reclaim of secret '%s' by uid %d (stored by uid %d)",
+			name, authInfo.UID, metadata.OwnerUID,
+		)
+		return &common.ReclaimResponse{
+			Success: false,
+			Error:   "reclaim denied - unauthorized",
+		}, nil
+	}
+
+	payload, err := s.storage.Get(ctx, name)
+	if err != nil {
+		return &common.ReclaimResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to retrieve secret from storage: %v", err),
+		}, nil
+	}
+
+	if payload.ClientBinaryHash == clientHash {
+		// Nothing to re-bind; the caller already owns the secret
+		return &common.ReclaimResponse{Success: true}, nil
+	}
+
+	// Decrypt under the hash the secret was stored with
+	oldKey, err := common.DeriveKey(payload.ClientBinaryHash, req.ClientNonce, s.sessionID, name, payload.Salt)
+	if err != nil {
+		return &common.ReclaimResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to derive key: %v", err),
+		}, nil
+	}
+	plaintext, err := decryptStoredString(payload, oldKey)
+	common.ZeroBytes(oldKey)
+	if err != nil {
+		return &common.ReclaimResponse{
+			Success: false,
+			Error:   "failed to decrypt secret (client verification failed)",
+		}, nil
+	}
+
+	// Re-encrypt under the caller's current hash with a fresh salt
+	salt, err := common.GenerateSaltFrom(s.deps.Reader())
+	if err != nil {
+		return &common.ReclaimResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to generate salt: %v", err),
+		}, nil
+	}
+	key, err := common.DeriveKey(clientHash, req.ClientNonce, s.sessionID, name, salt)
+	if err != nil {
+		return &common.ReclaimResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to derive key: %v", err),
+		}, nil
+	}
+	// Wipe out the key from memory when we are done. This is deliberately a
+	// defer so the key is also wiped when a later step panics.
+	defer common.ZeroBytes(key)
+	observeDerivedKey(key)
+
+	encrypted, err := common.Encrypt(plaintext, key)
+	if err != nil {
+		return &common.ReclaimResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to encrypt secret: %v", err),
+		}, nil
+	}
+
+	oldHash := payload.ClientBinaryHash
+	if err := s.storage.Store(ctx, name, &secrets.Payload{
+		EncryptedData:    encrypted,
+		Salt:             salt,
+		ClientBinaryHash: clientHash,
+	}); err != nil {
+		return &common.ReclaimResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to store secret in backend: %v", err),
+		}, nil
+	}
+	metadata.LastAccessed = now
+
+	// Re-binding a secret to a new binary must leave a loud trail
+	clog.FromContext(ctx).Warnf(
+		"AUDIT: secret '%s' reclaimed by uid %d, re-bound from binary hash %s to %s",
+		name, authInfo.UID, oldHash, clientHash,
+	)
+
+	return &common.ReclaimResponse{Success: true}, nil
+}
@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	isecrets "github.com/carabiner-dev/burnafter/internal/secrets"
+	"github.com/carabiner-dev/burnafter/options"
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+// rebindToFakeHash re-encrypts a stored secret under a made-up binary hash,
+// simulating a secret stored by a previous version of the test binary.
+func rebindToFakeHash(t *testing.T, srv *Server, name, nonce, fakeHash string) {
+	t.Helper()
+	ctx := context.Background()
+
+	payload, err := srv.storage.Get(ctx, name)
+	if err != nil {
+		t.Fatalf("reading payload: %v", err)
+	}
+	oldKey, err := common.DeriveKey(payload.ClientBinaryHash, nonce, srv.sessionID, name, payload.Salt)
+	if err != nil {
+		t.Fatalf("deriving old key: %v", err)
+	}
+	plaintext, err := common.Decrypt(payload.EncryptedData, oldKey)
+	if err != nil {
+		t.Fatalf("decrypting payload: %v", err)
+	}
+
+	newKey, err := common.DeriveKey(fakeHash, nonce, srv.sessionID, name, payload.Salt)
+	if err != nil {
+		t.Fatalf("deriving fake key: %v", err)
+	}
+	encrypted, err := common.Encrypt(plaintext, newKey)
+	if err != nil {
+		t.Fatalf("encrypting payload: %v", err)
+	}
+
+	if err := srv.storage.Store(ctx, name, &secrets.Payload{
+		EncryptedData:    encrypted,
+		Salt:             payload.Salt,
+		ClientBinaryHash: fakeHash,
+	}); err != nil {
+		t.Fatalf("storing rebound payload: %v", err)
+	}
+}
+
+func newReclaimTestServer(t *testing.T) *Server {
+	t.Helper()
+
+	opts := *options.DefaultServer
+	opts.AllowReclaim = true
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	srv.storage = isecrets.NewMemoryStorage()
+	return srv
+}
+
+func TestGetDeniedAfterHashChange(t *testing.T) {
+	srv := newReclaimTestServer(t)
+	ctx := testPeerContext()
+
+	storeResp, err := srv.Store(ctx, &common.StoreRequest{
+		Name: "orphaned", Secret: []byte("value"), ClientNonce: "test-nonce",
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, storeResp.GetError())
+	}
+	rebindToFakeHash(t, srv, "orphaned", "test-nonce", "previous-binary-hash")
+
+	// The mismatching binary is denied, reclaim option or not
+	getResp, err := srv.Get(ctx, &common.GetRequest{Name: "orphaned", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if getResp.Success {
+		t.Fatalf("Expected Get to be denied after the hash changed")
+	}
+}
+
+func TestReclaimMatchingUID(t *testing.T) {
+	srv := newReclaimTestServer(t)
+	ctx := testPeerContext()
+
+	storeResp, err := srv.Store(ctx, &common.StoreRequest{
+		Name: "upgraded", Secret: []byte("survives-upgrade"), ClientNonce: "test-nonce",
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, storeResp.GetError())
+	}
+	rebindToFakeHash(t, srv, "upgraded", "test-nonce", "previous-binary-hash")
+
+	// The storing UID (ours) may re-bind the secret to the current hash
+	reclaimResp, err := srv.Reclaim(ctx, &common.ReclaimRequest{Name: "upgraded", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Reclaim failed: %v", err)
+	}
+	if !reclaimResp.Success {
+		t.Fatalf("Expected the reclaim to succeed, got: %s", reclaimResp.Error)
+	}
+
+	getResp, err := srv.Get(ctx, &common.GetRequest{Name: "upgraded", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !getResp.Success {
+		t.Fatalf("Expected Get to work after the reclaim, got: %s", getResp.Error)
+	}
+	if string(getResp.Secret) != "survives-upgrade" {
+		t.Errorf("Expected survives-upgrade, got %q", getResp.Secret)
+	}
+}
+
+func TestReclaimMismatchingUID(t *testing.T) {
+	srv := newReclaimTestServer(t)
+	ctx := testPeerContext()
+
+	storeResp, err := srv.Store(ctx, &common.StoreRequest{
+		Name: "not-yours", Secret: []byte("value"), ClientNonce: "test-nonce",
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, storeResp.GetError())
+	}
+	rebindToFakeHash(t, srv, "not-yours", "test-nonce", "previous-binary-hash")
+
+	// Pretend another user stored the secret: the reclaim must be denied
+	srv.secretsMu.Lock()
+	srv.secrets["not-yours"].OwnerUID++
+	srv.secretsMu.Unlock()
+
+	reclaimResp, err := srv.Reclaim(ctx, &common.ReclaimRequest{Name: "not-yours", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Reclaim failed: %v", err)
+	}
+	if reclaimResp.Success {
+		t.Fatalf("Expected the reclaim to be denied for a different UID")
+	}
+}
+
+func TestReclaimDisabledByDefault(t *testing.T) {
+	srv := newTestServer(t)
+	ctx := testPeerContext()
+
+	resp, err := srv.Reclaim(ctx, &common.ReclaimRequest{Name: "anything", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Reclaim failed: %v", err)
+	}
+	if resp.Success {
+		t.Fatalf("Expected reclaim to be disabled by default")
+	}
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+
+	"github.com/chainguard-dev/clog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// keyDerived, when non-nil, observes derived encryption keys right after
+// derivation. It exists only so tests can assert that keys are wiped even
+// when a handler panics mid-request; production never sets it.
+var keyDerived func(key []byte)
+
+// observeDerivedKey passes a freshly derived key to the test hook, if set.
+func observeDerivedKey(key []byte) {
+	if keyDerived != nil {
+		keyDerived(key)
+	}
+}
+
+// recoveryInterceptor is a grpc unary interceptor that converts handler panics
+// (e.g. a storage backend blowing up) into Internal errors instead of letting
+// them crash the daemon and take every held secret down with it. The handlers'
+// deferred key and buffer wipes still run while the panic unwinds, so no key
+// material is left behind by the recovered request.
+func (s *Server) recoveryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			clog.FromContext(s.ctx).Errorf("Recovered from panic in handler: %v", r)
+			resp = nil
+			err = status.Errorf(codes.Internal, "internal server error")
+		}
+	}()
+
+	return handler(ctx, req)
+}
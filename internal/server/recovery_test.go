@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+// panicStorage is a storage backend that panics on demand, simulating a
+// backend blowing up mid-request.
+type panicStorage struct {
+	inner        secrets.Storage
+	panicOnStore bool
+	panicOnGet   bool
+}
+
+func (p *panicStorage) Store(ctx context.Context, name string, payload *secrets.Payload) error {
+	if p.panicOnStore {
+		panic("storage backend exploded on store")
+	}
+	return p.inner.Store(ctx, name, payload)
+}
+
+func (p *panicStorage) Get(ctx context.Context, name string) (*secrets.Payload, error) {
+	if p.panicOnGet {
+		panic("storage backend exploded on get")
+	}
+	return p.inner.Get(ctx, name)
+}
+
+func (p *panicStorage) Delete(ctx context.Context, name string) error {
+	return p.inner.Delete(ctx, name)
+}
+
+func (p *panicStorage) Backend() string { return p.inner.Backend() }
+
+func TestRecoveryInterceptorWipesKeyOnPanic(t *testing.T) {
+	srv := newTestServer(t)
+	storage := &panicStorage{inner: srv.storage, panicOnStore: true}
+	srv.storage = storage
+
+	// Capture the key the handler derives, so we can check it was wiped while
+	// the panic unwound through the handler's deferred ZeroBytes.
+	var derivedKey []byte
+	keyDerived = func(key []byte) { derivedKey = key }
+	t.Cleanup(func() { keyDerived = nil })
+
+	ctx := testPeerContext()
+	_, err := srv.recoveryInterceptor(ctx, &common.StoreRequest{Name: "boom", Secret: []byte("value")}, nil,
+		func(ctx context.Context, req any) (any, error) {
+			return srv.Store(ctx, req.(*common.StoreRequest)) //nolint:forcetypeassert
+		})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("Expected Internal error from recovered panic, got %v", err)
+	}
+
+	if derivedKey == nil {
+		t.Fatalf("Expected the handler to derive a key before panicking")
+	}
+	for _, b := range derivedKey {
+		if b != 0 {
+			t.Errorf("Expected the derived key to be wiped during panic unwinding")
+			break
+		}
+	}
+
+	// The server must survive the panic: with the backend healthy again, the
+	// same request succeeds.
+	storage.panicOnStore = false
+	resp, err := srv.Store(ctx, &common.StoreRequest{Name: "boom", Secret: []byte("value")})
+	if err != nil {
+		t.Fatalf("Store after recovery failed: %v", err)
+	}
+	if !resp.Success {
+		t.Errorf("Expected store to succeed after recovery, got: %s", resp.Error)
+	}
+}
+
+func TestRecoveryInterceptorGetPanic(t *testing.T) {
+	srv := newTestServer(t)
+	storage := &panicStorage{inner: srv.storage}
+	srv.storage = storage
+
+	ctx := testPeerContext()
+
+	// Store a secret, then make the backend panic on retrieval
+	if resp, err := srv.Store(ctx, &common.StoreRequest{Name: "get-boom", Secret: []byte("value")}); err != nil || !resp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, resp.GetError())
+	}
+	storage.panicOnGet = true
+
+	_, err := srv.recoveryInterceptor(ctx, &common.GetRequest{Name: "get-boom"}, nil,
+		func(ctx context.Context, req any) (any, error) {
+			return srv.Get(ctx, req.(*common.GetRequest)) //nolint:forcetypeassert
+		})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("Expected Internal error from recovered panic, got %v", err)
+	}
+
+	// The secret is still there once the backend recovers
+	storage.panicOnGet = false
+	resp, err := srv.Get(ctx, &common.GetRequest{Name: "get-boom"})
+	if err != nil {
+		t.Fatalf("Get after recovery failed: %v", err)
+	}
+	if !resp.Success || string(resp.Secret) != "value" {
+		t.Errorf("Expected the secret to survive the panic, got: %s / %q", resp.Error, resp.Secret)
+	}
+}
@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chainguard-dev/clog"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+// Rotate implements the Rotate RPC. It replaces an existing secret's value in
+// place: the new payload is encrypted under a fresh salt and overwrites the
+// old one, and the metadata entry is updated under secretsMu — never deleted —
+// so there is no window in which a concurrent Get sees the secret missing.
+//
+// Lifecycle-wise, a rotation counts as an access: LastAccessed moves to now,
+// restarting the inactivity timer (and optionally changing it, when the
+// request carries a new TTL). The absolute deadline is a property of the
+// credential's lifetime, not of any one value, so it is preserved unless the
+// request explicitly sets a new one. The caller's binary hash must match the
+// one recorded at store time, the same rule Get and Delete apply.
+func (s *Server) Rotate(ctx context.Context, req *common.RotateRequest) (*common.RotateResponse, error) {
+	s.updateActivity()
+
+	clog.FromContext(ctx).Debugf("Rotate request for secret: %s", req.Name)
+
+	// Get client PID and verify binary
+	authInfo, err := GetPeerAuthInfo(ctx)
+	if err != nil {
+		s.authFailures.peerCredential.Add(1)
+		return &common.RotateResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get client credentials: %v", err),
+		}, nil
+	}
+
+	clientHash, err := s.clientKeyHash(authInfo)
+	if err != nil {
+		s.authFailures.peerCredential.Add(1)
+		return &common.RotateResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to verify client binary: %v", err),
+		}, nil
+	}
+
+	secretSize := int64(len(req.Secret))
+	if secretSize > s.options.MaxSecretSize {
+		return &common.RotateResponse{
+			Success: false,
+			Error:   fmt.Sprintf("secret size (%d bytes) exceeds maximum allowed size (%d bytes)", secretSize, s.options.MaxSecretSize),
+		}, nil
+	}
+
+	// Hold the lock across the whole check-encrypt-swap cycle so the rotation
+	// is atomic with respect to concurrent reads and other rotations
+	s.secretsMu.Lock()
+	defer s.secretsMu.Unlock()
+
+	now := s.now()
+	name, metadata, aliasErr := s.resolveAliasLocked(req.Name)
+	if aliasErr != nil || metadata.State(now) != secrets.StateLive {
+		return &common.RotateResponse{
+			Success: false,
+			Error:   "secret not found",
+		}, nil
+	}
+
+	payload, err := s.storage.Get(ctx, name)
+	if err != nil {
+		return &common.RotateResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to retrieve secret from storage: %v", err),
+		}, nil
+	}
+
+	// Only the binary that stored a credential may swap in a replacement
+	if payload.ClientBinaryHash != clientHash {
+		if authInfo.UID == metadata.OwnerUID {
+			s.authFailures.mismatchSameUID.Add(1)
+			clog.FromContext(ctx).Warnf(
+				"AUDIT: binary hash mismatch rotating secret '%s' from owner uid %d (likely a binary upgrade)",
+				name, authInfo.UID,
+			)
+		} else {
+			s.authFailures.mismatchOtherUID.Add(1)
+			clog.FromContext(ctx).Warnf(
+				"AUDIT: binary hash mismatch rotating secret '%s' from foreign uid %d (stored by uid %d)",
+				name, authInfo.UID, metadata.OwnerUID,
+			)
+		}
+		return &common.RotateResponse{
+			Success: false,
+			Error:   "client binary hash mismatch - unauthorized",
+		}, nil
+	}
+
+	// Encrypt the replacement under a fresh salt (and therefore a fresh key)
+	salt, err := common.GenerateSaltFrom(s.deps.Reader())
+	if err != nil {
+		return &common.RotateResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to generate salt: %v", err),
+		}, nil
+	}
+	key, err := common.DeriveKey(clientHash, req.ClientNonce, s.sessionID, name, salt)
+	if err != nil {
+		return &common.RotateResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to derive key: %v", err),
+		}, nil
+	}
+	// Wipe out the key from memory when we are done. This is deliberately a
+	// defer so the key is also wiped when a later step panics.
+	defer common.ZeroBytes(key)
+	observeDerivedKey(key)
+
+	// Same as Store: no string hop, so no unwipeable plaintext copy lingers
+	encrypted, err := common.EncryptBytes(req.Secret, key)
+	if err != nil {
+		return &common.RotateResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to encrypt secret: %v", err),
+		}, nil
+	}
+	common.ZeroBytes(req.Secret)
+
+	// Overwrite the payload first: a reader that races the metadata update
+	// below still finds a decryptable secret, since the salt travels with the
+	// payload
+	if err := s.storage.Store(ctx, name, &secrets.Payload{
+		EncryptedData:    encrypted,
+		Salt:             salt,
+		ClientBinaryHash: clientHash,
+	}); err != nil {
+		return &common.RotateResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to store secret in backend: %v", err),
+		}, nil
+	}
+
+	metadata.LastAccessed = now
+	metadata.PlaintextLength = secretSize
+	if req.TtlSeconds > 0 {
+		metadata.InactivityTTL = time.Duration(req.TtlSeconds) * time.Second
+	}
+	if req.AbsoluteExpirationSeconds > 0 {
+		t := now.Add(time.Duration(req.AbsoluteExpirationSeconds) * time.Second)
+		metadata.AbsoluteExpiresAt = &t
+	}
+
+	clog.FromContext(ctx).Debugf("Rotated secret '%s'", name)
+
+	return &common.RotateResponse{Success: true}, nil
+}
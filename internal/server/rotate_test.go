@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+func TestRotateSwapsValueAndPreservesDeadline(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:                      "api-key",
+		Secret:                    []byte("old-credential"),
+		ClientNonce:               "test-nonce",
+		AbsoluteExpirationSeconds: 3600,
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, resp.GetError())
+	}
+
+	srv.secretsMu.RLock()
+	originalDeadline := *srv.secrets["api-key"].AbsoluteExpiresAt
+	originalAccess := srv.secrets["api-key"].LastAccessed
+	srv.secretsMu.RUnlock()
+
+	rotResp, err := srv.Rotate(testPeerContext(), &common.RotateRequest{
+		Name:        "api-key",
+		Secret:      []byte("new-credential"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil || !rotResp.Success {
+		t.Fatalf("Rotate failed: %v (%s)", err, rotResp.GetError())
+	}
+
+	// The new value reads back under the old name
+	getResp, err := srv.Get(testPeerContext(), &common.GetRequest{Name: "api-key", ClientNonce: "test-nonce"})
+	if err != nil || !getResp.Success {
+		t.Fatalf("Get after rotate failed: %v (%s)", err, getResp.GetError())
+	}
+	if string(getResp.Secret) != "new-credential" {
+		t.Errorf("Expected the rotated value, got %q", getResp.Secret)
+	}
+
+	// The absolute deadline is untouched; the inactivity timer restarted
+	srv.secretsMu.RLock()
+	metadata := srv.secrets["api-key"]
+	if !metadata.AbsoluteExpiresAt.Equal(originalDeadline) {
+		t.Errorf("Expected the absolute deadline to be preserved: got %v, want %v",
+			metadata.AbsoluteExpiresAt, originalDeadline)
+	}
+	if metadata.LastAccessed.Before(originalAccess) {
+		t.Errorf("Expected the rotation to count as an access")
+	}
+	if metadata.PlaintextLength != int64(len("new-credential")) {
+		t.Errorf("Expected the recorded length to track the new value, got %d", metadata.PlaintextLength)
+	}
+	srv.secretsMu.RUnlock()
+}
+
+func TestRotateOverridesDeadlineWhenAsked(t *testing.T) {
+	srv := newTestServer(t)
+	now := time.Unix(1700000000, 0)
+	srv.deps = &common.Deps{Clock: func() time.Time { return now }}
+
+	resp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:                      "api-key",
+		Secret:                    []byte("old"),
+		ClientNonce:               "test-nonce",
+		AbsoluteExpirationSeconds: 3600,
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, resp.GetError())
+	}
+
+	rotResp, err := srv.Rotate(testPeerContext(), &common.RotateRequest{
+		Name:                      "api-key",
+		Secret:                    []byte("new"),
+		ClientNonce:               "test-nonce",
+		AbsoluteExpirationSeconds: 7200,
+	})
+	if err != nil || !rotResp.Success {
+		t.Fatalf("Rotate failed: %v (%s)", err, rotResp.GetError())
+	}
+
+	srv.secretsMu.RLock()
+	deadline := *srv.secrets["api-key"].AbsoluteExpiresAt
+	srv.secretsMu.RUnlock()
+	if want := now.Add(2 * time.Hour); !deadline.Equal(want) {
+		t.Errorf("Expected the overridden deadline %v, got %v", want, deadline)
+	}
+}
+
+func TestRotateMissingSecret(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := srv.Rotate(testPeerContext(), &common.RotateRequest{
+		Name:        "never-stored",
+		Secret:      []byte("v"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+	if resp.Success || resp.Error != "secret not found" {
+		t.Errorf("Expected a not-found error, got success=%v error=%q", resp.Success, resp.Error)
+	}
+}
+
+func TestRotateRejectsForeignBinary(t *testing.T) {
+	srv := newTestServer(t)
+
+	// The helper's payload carries no binary hash, standing in for a secret
+	// stored by a different binary
+	addTestSecret(t, srv, "not-ours", nil, time.Now())
+
+	resp, err := srv.Rotate(testPeerContext(), &common.RotateRequest{
+		Name:        "not-ours",
+		Secret:      []byte("hijacked"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+	if resp.Success || resp.Error != "client binary hash mismatch - unauthorized" {
+		t.Errorf("Expected an unauthorized error, got success=%v error=%q", resp.Success, resp.Error)
+	}
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+)
+
+// listenFdsStart is the first file descriptor passed by the service manager
+// (SD_LISTEN_FDS_START). It is a variable only so tests can point activation
+// at a descriptor they own.
+var listenFdsStart uintptr = 3
+
+// activationListener returns a listener inherited through systemd socket
+// activation, following the sd_listen_fds(3) protocol: LISTEN_PID names the
+// process the descriptors are meant for and LISTEN_FDS how many were passed,
+// starting at fd 3. It returns nil when the process was not socket-activated,
+// and an error when the environment advertises descriptors we cannot use.
+//
+// The LISTEN_* variables are cleared either way so they are not inherited by
+// anything the server spawns.
+func activationListener() (net.Listener, error) {
+	pidStr := os.Getenv("LISTEN_PID")
+	fdsStr := os.Getenv("LISTEN_FDS")
+
+	defer func() {
+		_ = os.Unsetenv("LISTEN_PID")     //nolint:errcheck
+		_ = os.Unsetenv("LISTEN_FDS")     //nolint:errcheck
+		_ = os.Unsetenv("LISTEN_FDNAMES") //nolint:errcheck
+	}()
+
+	if pidStr == "" || fdsStr == "" {
+		return nil, nil
+	}
+
+	// The descriptors are addressed to a specific process; ignore them if
+	// they were meant for someone else (e.g. inherited by accident).
+	pid, err := strconv.Atoi(pidStr)
+	if err != nil || pid != os.Getpid() {
+		return nil, nil
+	}
+
+	nfds, err := strconv.Atoi(fdsStr)
+	if err != nil || nfds < 1 {
+		return nil, fmt.Errorf("invalid LISTEN_FDS value %q", fdsStr)
+	}
+	if nfds > 1 {
+		return nil, fmt.Errorf("expected a single activated socket, got %d", nfds)
+	}
+
+	file := os.NewFile(listenFdsStart, "systemd-activation")
+	if file == nil {
+		return nil, fmt.Errorf("invalid activation file descriptor %d", listenFdsStart)
+	}
+	defer file.Close() //nolint:errcheck
+
+	listener, err := net.FileListener(file)
+	if err != nil {
+		return nil, fmt.Errorf("using activated socket: %w", err)
+	}
+
+	return listener, nil
+}
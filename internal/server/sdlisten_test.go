@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	isecrets "github.com/carabiner-dev/burnafter/internal/secrets"
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func TestActivationListenerNotActivated(t *testing.T) {
+	t.Setenv("LISTEN_PID", "")
+	t.Setenv("LISTEN_FDS", "")
+	os.Unsetenv("LISTEN_PID") //nolint:errcheck
+	os.Unsetenv("LISTEN_FDS") //nolint:errcheck
+
+	listener, err := activationListener()
+	if err != nil {
+		t.Fatalf("activationListener failed: %v", err)
+	}
+	if listener != nil {
+		t.Errorf("Expected no listener without activation env")
+	}
+}
+
+func TestActivationListenerWrongPID(t *testing.T) {
+	// Descriptors addressed to another process must be ignored
+	t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()+1))
+	t.Setenv("LISTEN_FDS", "1")
+
+	listener, err := activationListener()
+	if err != nil {
+		t.Fatalf("activationListener failed: %v", err)
+	}
+	if listener != nil {
+		t.Errorf("Expected descriptors for another PID to be ignored")
+	}
+
+	// The protocol requires the variables to be consumed
+	if os.Getenv("LISTEN_PID") != "" || os.Getenv("LISTEN_FDS") != "" {
+		t.Errorf("Expected LISTEN_* variables to be cleared")
+	}
+}
+
+func TestRunOnActivatedSocket(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "activated.sock")
+
+	// Pre-bind the socket as the service manager would
+	bound, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("pre-binding socket: %v", err)
+	}
+	defer bound.Close() //nolint:errcheck
+
+	file, err := bound.(*net.UnixListener).File()
+	if err != nil {
+		t.Fatalf("extracting listener fd: %v", err)
+	}
+	defer file.Close() //nolint:errcheck
+
+	// Point activation at the test-owned descriptor
+	oldStart := listenFdsStart
+	listenFdsStart = file.Fd()
+	defer func() { listenFdsStart = oldStart }()
+
+	t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()))
+	t.Setenv("LISTEN_FDS", "1")
+
+	opts := *options.DefaultServer
+	opts.SocketPath = filepath.Join(t.TempDir(), "never-created.sock")
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	srv.storage = isecrets.NewMemoryStorage()
+
+	done := make(chan error, 1)
+	go func() { done <- srv.Run(context.Background()) }()
+	defer func() {
+		if srv.grpcServer != nil {
+			srv.grpcServer.GracefulStop()
+		}
+	}()
+
+	// Give Run a moment to pick up the listener, surfacing early failures
+	select {
+	case err := <-done:
+		t.Fatalf("Run exited early: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// The server must not have created its own socket
+	if _, err := os.Stat(opts.SocketPath); !os.IsNotExist(err) {
+		t.Errorf("Expected the server to skip self-listening when activated")
+	}
+
+	// It must be serving on the inherited descriptor
+	dialer := func(ctx context.Context, addr string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "unix", socketPath)
+	}
+	conn, err := grpc.NewClient(
+		"passthrough:///unix",
+		grpc.WithTransportCredentials(NewPeerCredentials()),
+		grpc.WithContextDialer(dialer),
+	)
+	if err != nil {
+		t.Fatalf("dialing activated socket: %v", err)
+	}
+	defer conn.Close() //nolint:errcheck
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := common.NewBurnAfterClient(conn).Ping(ctx, &common.PingRequest{})
+	if err != nil {
+		t.Fatalf("Ping over activated socket failed: %v", err)
+	}
+	if !resp.Alive {
+		t.Errorf("Expected the server to report alive")
+	}
+
+	select {
+	case err := <-done:
+		t.Fatalf("Run exited early: %v", err)
+	default:
+	}
+}
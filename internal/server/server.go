@@ -5,8 +5,10 @@ package server
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
-	"net"
+	"io"
 	"os"
 	"runtime"
 	"sync"
@@ -14,6 +16,8 @@ import (
 
 	"github.com/chainguard-dev/clog"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/carabiner-dev/burnafter/internal/common"
 	isecrets "github.com/carabiner-dev/burnafter/internal/secrets"
@@ -21,6 +25,9 @@ import (
 	"github.com/carabiner-dev/burnafter/secrets"
 )
 
+// cleanupInterval is how often the cleanup goroutine sweeps expired secrets.
+const cleanupInterval = 1 * time.Minute
+
 // Server implements the BurnAfter gRPC service
 type Server struct {
 	common.UnimplementedBurnAfterServer
@@ -32,6 +39,10 @@ type Server struct {
 	secrets   map[string]*secrets.Metadata
 	secretsMu sync.RWMutex
 
+	// handles maps single-use retrieval tokens to secret names. Guarded by
+	// secretsMu; entries are removed on redemption and when their secret dies.
+	handles map[string]string
+
 	// storage is the backend that stores the actual encrypted secret data
 	storage secrets.Storage
 
@@ -44,7 +55,54 @@ type Server struct {
 
 	inactivityTimer *time.Timer
 	shutdownChan    chan struct{}
-	grpcServer      *grpc.Server
+
+	// grpcServer is published by Run once the server is constructed. Guarded
+	// by grpcServerMu, since Stop and the shutdown sweeps may race Run.
+	grpcServer   *grpc.Server
+	grpcServerMu sync.Mutex
+
+	// authFailures tallies failed client authorizations by class for security
+	// monitoring (see AuthFailureCounts).
+	authFailures authFailureCounters
+
+	// emptySince marks when a cleanup sweep first found the server holding no
+	// secrets, anchoring the IdleGracePeriod window. Zero while the server
+	// holds secrets. Guarded by secretsMu.
+	emptySince time.Time
+
+	// requestSlots is the semaphore capping in-flight RPCs when the
+	// MaxConcurrentRequests option is set (nil = unlimited).
+	requestSlots chan struct{}
+
+	// rateLimiters holds the per-client token buckets backing the
+	// RequestsPerSecondPerClient option. Guarded by rateLimitersMu.
+	rateLimiters   map[string]*tokenBucket
+	rateLimitersMu sync.Mutex
+
+	// activeClients maps each recently served peer identity to when it was
+	// last seen, backing the MaxClients admission control. Guarded by
+	// activeClientsMu.
+	activeClients   map[string]time.Time
+	activeClientsMu sync.Mutex
+
+	// logger is injected into every request context; SetDebug can swap it at
+	// runtime to toggle debug output without a restart. Guarded by logMu.
+	logger *clog.Logger
+	logMu  sync.RWMutex
+
+	// logWriter receives debug logs enabled at runtime via SetDebug. It is
+	// os.Stderr in production; tests substitute a buffer.
+	logWriter io.Writer
+
+	// auditSink receives a structured event per secret operation when the
+	// audit options are set; nil (the default) disables the trail entirely.
+	auditSink options.AuditSink
+
+	// deps holds the injected clock and randomness source governing secret
+	// lifecycle (expiry checks, salts). nil — the production default — means
+	// the real implementations; tests set it to expire secrets without
+	// sleeping and to make salts deterministic.
+	deps *common.Deps
 
 	// ctx holds the server's root context with logger
 	ctx context.Context //nolint:containedctx
@@ -52,39 +110,144 @@ type Server struct {
 
 // NewServer creates a new BurnAfter server with the supplied options
 func NewServer(ctx context.Context, opts *options.Server) (*Server, error) {
-	// Generat the random session ID
-	sessionID, err := common.GenerateSessionID()
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate session ID: %w", err)
+	// Use the embedder-supplied session ID when one is configured (it is key
+	// material; see the option's docs), otherwise generate a random one that
+	// dies with this instance.
+	sessionID := opts.SessionID
+	if sessionID == "" {
+		var err error
+		sessionID, err = common.GenerateSessionID()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate session ID: %w", err)
+		}
+	}
+
+	// Guard against a degenerate config delivered through the options JSON:
+	// a zero MaxSecretSize would reject every store and negative limits make
+	// no sense, so substitute the defaults and say so.
+	if opts.MaxSecrets <= 0 {
+		clog.FromContext(ctx).Debugf("Invalid MaxSecrets %d, applying default of %d",
+			opts.MaxSecrets, options.DefaultServer.MaxSecrets)
+		opts.MaxSecrets = options.DefaultServer.MaxSecrets
+	}
+	if opts.MaxSecretSize <= 0 {
+		clog.FromContext(ctx).Debugf("Invalid MaxSecretSize %d, applying default of %d",
+			opts.MaxSecretSize, options.DefaultServer.MaxSecretSize)
+		opts.MaxSecretSize = options.DefaultServer.MaxSecretSize
+	}
+	if opts.EvictionPolicy != "" && opts.EvictionPolicy != "reject" && opts.EvictionPolicy != "lru" {
+		clog.FromContext(ctx).Debugf("Unknown EvictionPolicy %q, applying default of reject",
+			opts.EvictionPolicy)
+		opts.EvictionPolicy = "reject"
+	}
+	// The fingerprint algorithm is key material: a typo silently defaulting
+	// would strand every secret, so an unknown value fails the startup instead.
+	switch opts.BinaryHashAlgorithm {
+	case "", common.HashAlgorithmSHA256, common.HashAlgorithmSHA512, common.HashAlgorithmBLAKE3:
+	default:
+		return nil, fmt.Errorf("unsupported binary hash algorithm: %q", opts.BinaryHashAlgorithm)
+	}
+	// The verification mode is key material too, and an unknown value must
+	// not silently land on a weaker (or stronger) mode than the one asked for.
+	switch opts.PeerVerification {
+	case "", "strict", "uid-only", "disabled":
+	default:
+		return nil, fmt.Errorf("unsupported peer verification mode: %q", opts.PeerVerification)
+	}
+	// The trusted digest list matches peers by their binary fingerprint, which
+	// the relaxed modes never compute: combining them would reject every RPC.
+	if opts.PeerVerification != "" && opts.PeerVerification != "strict" && len(opts.TrustedClientDigests) > 0 {
+		return nil, fmt.Errorf("a trusted client digest list requires strict peer verification")
 	}
 
 	// Initialize the storage driver
 	var storage secrets.Storage
+	var keyringErr error
+
+	// An embedder-supplied factory replaces the built-in selection entirely.
+	// It runs here, in the server process, so with the spawned embedded
+	// server binary (which only receives the serialized options) the hook is
+	// only reachable through a custom launcher running the server in-process.
+	if opts.StorageFactory != nil {
+		custom, err := opts.StorageFactory(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("custom storage factory: %w", err)
+		}
+		clog.FromContext(ctx).Debugf("Using custom %s storage for secrets", custom.Backend())
+		storage = custom
+	}
 
-	// In Linux, try to use the kernel keyring driver to store the encrypted secrets.
-	keyringStorage, err := isecrets.NewKeyringStorage(ctx)
-	if err == nil {
-		clog.FromContext(ctx).Debug("Using kernel keyring storage for secrets")
-		storage = keyringStorage
+	// In Linux, try to use the kernel keyring driver to store the encrypted
+	// secrets. The session ID scopes the key names, so two servers sharing
+	// this process keyring do not clobber each other's keys.
+	if storage == nil {
+		keyringStorage, err := isecrets.NewKeyringStorage(ctx, sessionID)
+		if err == nil {
+			clog.FromContext(ctx).Debug("Using kernel keyring storage for secrets")
+			storage = keyringStorage
+		}
+		keyringErr = err
 	}
 
-	// .. but fall back to memory storage if not available
+	// On macOS the system Keychain plays the keyring's role. The same session
+	// ID scopes the item names, so the shutdown flush only touches this
+	// server's items.
+	if storage == nil && runtime.GOOS == "darwin" {
+		keychainStorage, kcErr := isecrets.NewKeychainStorage(ctx, sessionID)
+		if kcErr == nil {
+			clog.FromContext(ctx).Debug("Using macOS Keychain storage for secrets")
+			storage = keychainStorage
+		} else {
+			clog.FromContext(ctx).Debugf("Keychain not available, using memory storage: %v", kcErr)
+		}
+	}
+
+	// .. but fall back to memory storage if not available, unless the options
+	// require the keyring, in which case fail closed.
 	if storage == nil {
+		if opts.RequireKeyring {
+			return nil, fmt.Errorf("kernel keyring storage required but unavailable: %w", keyringErr)
+		}
 		if runtime.GOOS == "linux" {
-			clog.FromContext(ctx).Debugf("Kernel keyring not available, using memory storage: %v", err)
+			clog.FromContext(ctx).Debugf("Kernel keyring not available, using memory storage: %v", keyringErr)
 		}
 		storage = isecrets.NewMemoryStorage()
 	}
 
+	// Resolve the audit sink: an embedder-supplied one wins; otherwise the
+	// audit log path gets the default JSON-lines sink. The file stays open
+	// for the server's lifetime, like a log file. An unopenable path fails
+	// the startup: a deployment asking for an audit trail must not run
+	// silently without one.
+	auditSink := opts.AuditSink
+	if auditSink == nil && opts.AuditLogPath != "" {
+		auditLog, err := os.OpenFile(opts.AuditLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("opening audit log: %w", err)
+		}
+		auditSink = options.NewJSONAuditSink(auditLog)
+	}
+
 	// Create the server
 	s := &Server{
-		secrets:      map[string]*secrets.Metadata{},
-		storage:      storage,
-		sessionID:    sessionID,
-		lastActivity: time.Now(),
-		options:      opts,
-		shutdownChan: make(chan struct{}),
-		ctx:          ctx,
+		secrets:       map[string]*secrets.Metadata{},
+		handles:       map[string]string{},
+		storage:       storage,
+		sessionID:     sessionID,
+		lastActivity:  time.Now(),
+		options:       opts,
+		shutdownChan:  make(chan struct{}),
+		rateLimiters:  map[string]*tokenBucket{},
+		activeClients: map[string]time.Time{},
+		logger:        clog.FromContext(ctx),
+		logWriter:     os.Stderr,
+		auditSink:     auditSink,
+		ctx:           ctx,
+	}
+
+	// Set up the concurrency limiter when configured
+	if opts.MaxConcurrentRequests > 0 {
+		s.requestSlots = make(chan struct{}, opts.MaxConcurrentRequests)
 	}
 
 	return s, nil
@@ -95,38 +258,114 @@ func NewServer(ctx context.Context, opts *options.Server) (*Server, error) {
 func (s *Server) loggerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
 	// Inject the logger from the server's context into the request context
 	//nolint:contextcheck // clog.WithLogger properly inherits from parent context
-	return handler(clog.WithLogger(ctx, clog.FromContext(s.ctx)), req)
+	return handler(clog.WithLogger(ctx, s.requestLogger()), req)
 }
 
-// Run starts the server and blocks until shutdown
-func (s *Server) Run(ctx context.Context) error {
-	// Remove existing socket file if it already exists
-	if err := os.RemoveAll(s.options.SocketPath); err != nil {
-		return fmt.Errorf("failed to remove existing socket: %w", err)
+// uidInterceptor is a grpc unary interceptor that rejects RPCs from peers
+// whose UID is not in the configured allowlist. An empty allowlist preserves
+// the default behavior of accepting any UID (the per-secret binary gate still
+// applies either way).
+func (s *Server) uidInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := s.admitPeerUID(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// admitPeerUID performs the AllowedUIDs check shared by the unary and stream
+// interceptors.
+func (s *Server) admitPeerUID(ctx context.Context) error {
+	if len(s.options.AllowedUIDs) == 0 {
+		return nil
 	}
 
-	// Create Unix domain socket listener
-	lc := net.ListenConfig{}
-	listener, err := lc.Listen(ctx, "unix", s.options.SocketPath)
+	authInfo, err := GetPeerAuthInfo(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to listen on socket: %w", err)
+		return status.Errorf(codes.PermissionDenied, "cannot verify peer credentials: %v", err)
+	}
+
+	for _, uid := range s.options.AllowedUIDs {
+		if uid == authInfo.UID {
+			return nil
+		}
+	}
+
+	return status.Errorf(codes.PermissionDenied, "uid %d is not authorized on this server", authInfo.UID)
+}
+
+// concurrencyInterceptor is a grpc unary interceptor that caps the number of
+// in-flight requests. A single client hammering the daemon with concurrent
+// requests would otherwise trigger an expensive binary rehash per request
+// (and, with the keyring backend, serialize on the single locked worker
+// thread), so excess requests are rejected with ResourceExhausted rather than
+// queued without bound.
+func (s *Server) concurrencyInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	release, err := s.acquireRequestSlot()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return handler(ctx, req)
+}
+
+// acquireRequestSlot claims one in-flight request slot, shared by the unary
+// and stream interceptors. The returned release function frees the slot and
+// must be called when the request finishes; with no limit configured it is a
+// no-op.
+func (s *Server) acquireRequestSlot() (release func(), err error) {
+	if s.requestSlots == nil {
+		return func() {}, nil
 	}
-	defer listener.Close() //nolint:errcheck
 
-	// Set socket permissions to be restrictive (owner only)
-	if err := os.Chmod(s.options.SocketPath, 0o600); err != nil {
-		return fmt.Errorf("failed to set socket permissions: %w", err)
+	select {
+	case s.requestSlots <- struct{}{}:
+		return func() { <-s.requestSlots }, nil
+	default:
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"too many concurrent requests (limit %d)", cap(s.requestSlots))
 	}
+}
 
-	clog.FromContext(ctx).Debugf("Server listening on %s", s.options.SocketPath)
+// Run starts the server and blocks until shutdown
+func (s *Server) Run(ctx context.Context) error {
+	// The transport is platform specific: Unix domain sockets on Unix-likes,
+	// named pipes on Windows
+	listener, err := s.listen(ctx)
+	if err != nil {
+		return err
+	}
+	defer listener.Close() //nolint:errcheck
 	clog.FromContext(ctx).Debugf("Session ID: %s", s.sessionID)
 
 	// Create gRPC server with custom credentials to extract peer info and logger interceptor
-	s.grpcServer = grpc.NewServer(
+	grpcServer := grpc.NewServer(
 		grpc.Creds(NewPeerCredentials()),
-		grpc.UnaryInterceptor(s.loggerInterceptor),
+		// Zero plaintext secrets out of responses once they are serialized
+		grpc.ForceServerCodecV2(newWipeCodec()),
+		grpc.ChainUnaryInterceptor(s.recoveryInterceptor, s.loggerInterceptor, s.uidInterceptor, s.digestInterceptor, s.clientLimitInterceptor, s.rateLimitInterceptor, s.concurrencyInterceptor),
+		// The streaming RPCs pass through the same gates
+		grpc.ChainStreamInterceptor(s.recoveryStreamInterceptor, s.loggerStreamInterceptor, s.uidStreamInterceptor, s.digestStreamInterceptor, s.clientLimitStreamInterceptor, s.rateLimitStreamInterceptor, s.concurrencyStreamInterceptor),
+		// Size the transport limits from MaxSecretSize so it is burnafter's
+		// own size check that rejects oversized secrets, not an opaque
+		// transport error when the limit is raised past gRPC's 4MB default
+		grpc.MaxRecvMsgSize(common.MaxMessageSize(s.options.MaxSecretSize)),
+		grpc.MaxSendMsgSize(common.MaxMessageSize(s.options.MaxSecretSize)),
 	)
-	common.RegisterBurnAfterServer(s.grpcServer, s)
+	common.RegisterBurnAfterServer(grpcServer, s)
+
+	// Publish the server so Stop and the shutdown paths see it; they may run
+	// concurrently with the rest of this function
+	s.grpcServerMu.Lock()
+	s.grpcServer = grpcServer
+	s.grpcServerMu.Unlock()
+
+	// Restore and keep persisting secrets when snapshotting is enabled
+	if s.options.SnapshotPath != "" {
+		if err := s.loadSnapshot(ctx); err != nil {
+			clog.FromContext(ctx).Debugf("Failed to load snapshot: %v", err)
+		}
+		go s.snapshotLoop()
+	}
 
 	// Start cleanup goroutine
 	go s.cleanupExpiredSecrets()
@@ -135,18 +374,110 @@ func (s *Server) Run(ctx context.Context) error {
 	if s.options.InactivityTimeout > 0 {
 		s.inactivityTimer = time.AfterFunc(s.options.InactivityTimeout, func() {
 			clog.FromContext(ctx).Info("Inactivity timeout reached, shutting down")
-			s.grpcServer.GracefulStop()
+			grpcServer.GracefulStop()
 			close(s.shutdownChan)
 		})
 	}
 
-	if err := s.grpcServer.Serve(listener); err != nil {
+	if err := grpcServer.Serve(listener); err != nil {
 		return fmt.Errorf("failed to serve: %w", err)
 	}
 
+	// Serve has returned: the server is going away (signal or idle timeout).
+	// When configured, capture the departing state in the passphrase-protected
+	// export bundle before the secrets are lost with this process.
+	if err := s.saveShutdownExport(ctx); err != nil {
+		clog.FromContext(ctx).Warnf("Failed to write shutdown export bundle: %v", err)
+	}
+
+	// With the export (if any) written, the payloads are no longer needed:
+	// remove them from the storage backend rather than relying on process-exit
+	// cleanup.
+	s.flushStorage(ctx)
+
 	return nil
 }
 
+// Stop gracefully stops the gRPC server, letting in-flight requests finish.
+// It is safe to call on a server that was never started.
+func (s *Server) Stop() {
+	if srv := s.gRPCServer(); srv != nil {
+		srv.GracefulStop()
+	}
+}
+
+// gRPCServer returns the server Run published, nil before Run reached that
+// point.
+func (s *Server) gRPCServer() *grpc.Server {
+	s.grpcServerMu.Lock()
+	defer s.grpcServerMu.Unlock()
+	return s.grpcServer
+}
+
+// clientKeyHash returns the hash bound into a secret's encryption key for the
+// calling client. Normally this is the caller's binary hash, which gates
+// retrieval to the exact binary that stored the secret. When a shared key is
+// configured, cooperating binaries agree on its digest instead, deliberately
+// relaxing the binary gate so a suite of different binaries can share secrets.
+// The PeerVerification modes relax the hashing wholesale ("uid-only" binds
+// keys to the peer's UID, "disabled" to a fixed digest) for deployments where
+// the server cannot see the client's PID namespace. Peers whose PID the
+// kernel did not report cannot be resolved to a binary; those are rejected
+// unless the options allow falling back to a digest of the peer's UID.
+func (s *Server) clientKeyHash(authInfo *peerAuthInfo) (string, error) {
+	if s.options.SharedKey != "" {
+		sum := sha256.Sum256([]byte(s.options.SharedKey))
+		return hex.EncodeToString(sum[:]), nil
+	}
+
+	// The relaxed modes never touch /proc/<pid>/exe, so they also work when
+	// the kernel reports a PID that resolves to the wrong binary (a peer in
+	// another PID namespace whose PID collides with a local process).
+	switch s.options.PeerVerification {
+	case "uid-only":
+		return uidKeyHash(authInfo.UID), nil
+	case "disabled":
+		sum := sha256.Sum256([]byte("burnafter-peer-verification-disabled"))
+		return hex.EncodeToString(sum[:]), nil
+	}
+
+	if authInfo.PID <= 0 {
+		if s.options.AllowUIDOnlyVerification {
+			return uidKeyHash(authInfo.UID), nil
+		}
+		return "", fmt.Errorf("cannot verify client binary: %w", common.ErrPeerPIDUnavailable)
+	}
+
+	_, clientHash, err := common.GetClientBinaryInfoWith(s.options.BinaryHashAlgorithm, authInfo.PID)
+	return clientHash, err
+}
+
+// uidKeyHash is the binary-hash stand-in for peers identified only by their
+// UID. The PID-less fallback and the "uid-only" verification mode share it,
+// so the same caller derives the same keys whichever path admitted it.
+func uidKeyHash(uid uint32) string {
+	sum := sha256.Sum256(fmt.Appendf(nil, "burnafter-uid:%d", uid))
+	return hex.EncodeToString(sum[:])
+}
+
+// dropHandleLocked removes a dying secret's retrieval handle, if it has one.
+// Callers must hold secretsMu.
+func (s *Server) dropHandleLocked(metadata *secrets.Metadata) {
+	if metadata.Handle != "" {
+		delete(s.handles, metadata.Handle)
+		metadata.Handle = ""
+	}
+}
+
+// now returns the current time according to the server's clock: time.Now
+// unless tests injected a fixed one through deps. Secret lifecycle decisions
+// (expiry checks, LastAccessed stamps, the cleanup sweep) all go through it;
+// service plumbing like rate-limit windows deliberately stays on the real
+// clock.
+func (s *Server) now() time.Time {
+	return s.deps.Now()
+}
+
 // updateActivity updates the last activity timestamp of the server.
 func (s *Server) updateActivity() {
 	s.activityMu.Lock()
@@ -160,58 +491,36 @@ func (s *Server) updateActivity() {
 	}
 }
 
-// Ping implements the Ping RPC
+// Ping implements the Ping RPC. Plain pings only report process liveness;
+// when the request asks for it, the storage backend is also self-tested with
+// a sentinel round trip and its health reported separately, since a live
+// process can still be sitting on a wedged backend.
 func (s *Server) Ping(ctx context.Context, req *common.PingRequest) (*common.PingResponse, error) {
 	s.updateActivity()
-	return &common.PingResponse{Alive: true}, nil
+
+	resp := &common.PingResponse{Alive: true}
+	if req.CheckStorage {
+		if err := s.storageSelfTest(ctx); err != nil {
+			resp.StorageError = err.Error()
+		} else {
+			resp.StorageHealthy = true
+		}
+	}
+	return resp, nil
 }
 
 // cleanupExpiredSecrets runs as a go routine and it periodically removes
 // any expired secrets from memory.
 func (s *Server) cleanupExpiredSecrets() {
-	ticker := time.NewTicker(1 * time.Minute)
+	ticker := time.NewTicker(cleanupInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
-			s.secretsMu.Lock()
-			now := time.Now()
-			for name, secret := range s.secrets {
-				expired := false
-				var reason string
-
-				// Check the secret's inactivity expiration time
-				if time.Since(secret.LastAccessed) > secret.InactivityTTL {
-					expired = true
-					reason = "inactivity timeout"
-				}
-
-				// Check the absolute expiration date, this will wipe
-				// the secret regardless if it has been accesses or not
-				// (this absolute date is optional)
-				if secret.AbsoluteExpiresAt != nil && now.After(*secret.AbsoluteExpiresAt) {
-					expired = true
-					reason = "absolute deadline reached"
-				}
-
-				// Remove the secret if it's expired.
-				if expired {
-					clog.FromContext(s.ctx).Debugf("Removing expired secret '%s' (reason: %s)", name, reason)
-					delete(s.secrets, name)
-					// Also delete from the storage backend
-					_ = s.storage.Delete(s.ctx, name) //nolint:errcheck
-				}
-			}
-
-			// Check if all secrets have been removed
-			secretCount := len(s.secrets)
-			s.secretsMu.Unlock()
-
-			// If no secrets remain, shutdown the server
-			if secretCount == 0 && s.grpcServer != nil {
+			if s.runCleanupSweep() {
 				clog.FromContext(s.ctx).Debug("No secrets remaining, shutting down server")
-				s.grpcServer.GracefulStop()
+				s.gRPCServer().GracefulStop()
 				close(s.shutdownChan)
 				return
 			}
@@ -220,3 +529,91 @@ func (s *Server) cleanupExpiredSecrets() {
 		}
 	}
 }
+
+// runCleanupSweep removes expired secrets and dangling aliases, and reports
+// whether the server should shut down because it has been empty for longer
+// than the idle grace period (or at all, when no grace period is configured).
+func (s *Server) runCleanupSweep() (shutdown bool) {
+	s.secretsMu.Lock()
+	now := s.now()
+	// Expired secrets survive the sweep for the configured grace
+	// period (zero by default), during which a late Get still works.
+	grace := s.options.GracePeriod
+	for name, secret := range s.secrets {
+		// An alias lives and dies with its target, not with the expiry
+		// snapshot copied at creation (ExtendDeadline moves the target's
+		// deadline without touching the copies): the dangling-alias pass
+		// below reaps it once the target is gone.
+		if secret.AliasTarget != "" {
+			continue
+		}
+
+		expired := false
+		var reason string
+
+		// Check the secret's inactivity expiration time (negative TTL
+		// means the secret only dies at its absolute deadline)
+		if secret.InactivityTTL >= 0 && now.Sub(secret.LastAccessed) > secret.InactivityTTL+grace {
+			expired = true
+			reason = "inactivity timeout"
+		}
+
+		// Check the absolute expiration date, this will wipe
+		// the secret regardless if it has been accesses or not
+		// (this absolute date is optional)
+		if secret.AbsoluteExpiresAt != nil && now.After(secret.AbsoluteExpiresAt.Add(grace)) {
+			expired = true
+			reason = "absolute deadline reached"
+		}
+
+		// Remove the secret if it's expired.
+		if expired {
+			clog.FromContext(s.ctx).Debugf("Removing expired secret '%s' (reason: %s)", name, reason)
+			delete(s.secrets, name)
+			s.dropHandleLocked(secret)
+			// Also delete from the storage backend
+			_ = s.storage.Delete(s.ctx, name) //nolint:errcheck
+			s.auditExpiry(name)
+		}
+	}
+
+	// Reap aliases whose chain no longer resolves (the target was
+	// deleted or expired, possibly in this very sweep)
+	for name, secret := range s.secrets {
+		if secret.AliasTarget == "" {
+			continue
+		}
+		if _, _, err := s.resolveAliasLocked(name); err != nil {
+			clog.FromContext(s.ctx).Debugf("Removing dangling alias '%s': %v", name, err)
+			delete(s.secrets, name)
+			s.dropHandleLocked(secret)
+		}
+	}
+
+	defer s.secretsMu.Unlock()
+
+	// A non-empty server is never idle: clear any running grace window
+	if len(s.secrets) > 0 {
+		s.emptySince = time.Time{}
+		return false
+	}
+
+	if s.gRPCServer() == nil {
+		return false
+	}
+
+	// The server is empty. Without an idle grace period it shuts down right
+	// away; with one, the first empty sweep only starts the clock, and the
+	// shutdown happens when a later sweep finds the server still empty after
+	// the window — a quick refill in between cancels it.
+	idleGrace := s.options.IdleGracePeriod
+	if idleGrace <= 0 {
+		return true
+	}
+	if s.emptySince.IsZero() {
+		s.emptySince = now
+		clog.FromContext(s.ctx).Debugf("No secrets remaining, shutting down if still empty in %v", idleGrace)
+		return false
+	}
+	return now.Sub(s.emptySince) >= idleGrace
+}
@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/peer"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	isecrets "github.com/carabiner-dev/burnafter/internal/secrets"
+	"github.com/carabiner-dev/burnafter/options"
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+// testPeerContext returns a context carrying the test process's own peer
+// credentials, so handlers that verify the caller's binary hash the test
+// binary itself.
+func testPeerContext() context.Context {
+	pid := os.Getpid()
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: &peerAuthInfo{
+			PID: int32(pid), //nolint:gosec // PIDs fit in int32
+			UID: uint32(os.Getuid()),
+			GID: uint32(os.Getgid()),
+		},
+	})
+}
+
+// newTestServer creates a server over memory storage for handler tests.
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+
+	srv, err := NewServer(context.Background(), options.DefaultServer)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	// Pin the backend to plain memory storage so tests don't depend on the
+	// host keyring state.
+	srv.storage = isecrets.NewMemoryStorage()
+	return srv
+}
+
+// addTestSecret registers a secret's metadata and a placeholder payload.
+func addTestSecret(t *testing.T, srv *Server, name string, labels map[string]string, createdAt time.Time) {
+	t.Helper()
+
+	if err := srv.storage.Store(context.Background(), name, &secrets.Payload{
+		EncryptedData: []byte("test-payload"),
+	}); err != nil {
+		t.Fatalf("storing test payload: %v", err)
+	}
+
+	srv.secretsMu.Lock()
+	srv.secrets[name] = &secrets.Metadata{
+		Name:          name,
+		InactivityTTL: time.Hour,
+		LastAccessed:  createdAt,
+		CreatedAt:     createdAt,
+		Labels:        labels,
+	}
+	srv.secretsMu.Unlock()
+}
+
+func TestNewServerRequireKeyring(t *testing.T) {
+	ctx := context.Background()
+
+	opts := *options.DefaultServer
+	opts.RequireKeyring = true
+
+	srv, err := NewServer(ctx, &opts)
+
+	// Whether the keyring is available depends on the platform and the
+	// environment (e.g. sandboxes without keyctl), so assert both sides of
+	// the contract against what NewKeyringStorage reports here.
+	if _, keyringErr := isecrets.NewKeyringStorage(ctx, ""); keyringErr != nil {
+		if err == nil {
+			t.Fatalf("Expected NewServer to fail closed when the keyring is unavailable")
+		}
+	} else {
+		if err != nil {
+			t.Fatalf("NewServer failed with a working keyring: %v", err)
+		}
+		if srv == nil {
+			t.Fatalf("Expected a server instance")
+		}
+	}
+}
+
+func TestNewServerSanitizesLimits(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		secrets    int
+		secretSize int64
+	}{
+		{"zero", 0, 0},
+		{"negative", -5, -1024},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := *options.DefaultServer
+			opts.MaxSecrets = tc.secrets
+			opts.MaxSecretSize = tc.secretSize
+
+			srv, err := NewServer(context.Background(), &opts)
+			if err != nil {
+				t.Fatalf("NewServer failed: %v", err)
+			}
+
+			if srv.options.MaxSecrets != options.DefaultServer.MaxSecrets {
+				t.Errorf("Expected MaxSecrets default %d, got %d",
+					options.DefaultServer.MaxSecrets, srv.options.MaxSecrets)
+			}
+			if srv.options.MaxSecretSize != options.DefaultServer.MaxSecretSize {
+				t.Errorf("Expected MaxSecretSize default %d, got %d",
+					options.DefaultServer.MaxSecretSize, srv.options.MaxSecretSize)
+			}
+		})
+	}
+}
+
+func TestNewServerDefaultsToMemory(t *testing.T) {
+	ctx := context.Background()
+
+	// Without RequireKeyring, NewServer must always succeed, falling back to
+	// memory storage when the keyring is unavailable.
+	srv, err := NewServer(ctx, options.DefaultServer)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	if srv.storage == nil {
+		t.Fatalf("Expected a storage backend to be selected")
+	}
+}
+
+func TestNewServerSuppliedSessionID(t *testing.T) {
+	ctx := context.Background()
+
+	sessionID, err := common.GenerateSessionID()
+	if err != nil {
+		t.Fatalf("GenerateSessionID failed: %v", err)
+	}
+
+	opts := *options.DefaultServer
+	opts.SessionID = sessionID
+
+	newServer := func() *Server {
+		srv, err := NewServer(ctx, &opts)
+		if err != nil {
+			t.Fatalf("NewServer failed: %v", err)
+		}
+		srv.storage = isecrets.NewMemoryStorage()
+		return srv
+	}
+
+	// Store a secret on one instance
+	first := newServer()
+	peerCtx := testPeerContext()
+	resp, err := first.Store(peerCtx, &common.StoreRequest{
+		Name: "survivor", Secret: []byte("survives-restart"), ClientNonce: "test-nonce",
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, resp.GetError())
+	}
+
+	// Hand the payload and metadata to a second instance sharing the session
+	// ID, as the snapshot restore path would
+	second := newServer()
+	payload, err := first.storage.Get(ctx, "survivor")
+	if err != nil {
+		t.Fatalf("Reading stored payload: %v", err)
+	}
+	if err := second.storage.Store(ctx, "survivor", payload); err != nil {
+		t.Fatalf("Storing payload on the second server: %v", err)
+	}
+	first.secretsMu.RLock()
+	metadata := *first.secrets["survivor"]
+	first.secretsMu.RUnlock()
+	second.secretsMu.Lock()
+	second.secrets["survivor"] = &metadata
+	second.secretsMu.Unlock()
+
+	// The same session ID re-derives the same key for the same client
+	getResp, err := second.Get(peerCtx, &common.GetRequest{Name: "survivor", ClientNonce: "test-nonce"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !getResp.Success {
+		t.Fatalf("Expected the second server to decrypt the payload, got: %s", getResp.Error)
+	}
+	if string(getResp.Secret) != "survives-restart" {
+		t.Errorf("Expected survives-restart, got %q", getResp.Secret)
+	}
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	isecrets "github.com/carabiner-dev/burnafter/internal/secrets"
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// The SocketGroup option only exists for Unix domain sockets; the Windows
+// transport is a named pipe with no file to chown.
+func TestRunSocketGroup(t *testing.T) {
+	opts := *options.DefaultServer
+	opts.SocketPath = filepath.Join(t.TempDir(), "group.sock")
+	// Chowning to our own primary group is always permitted. GID 0 means the
+	// option is unset, so when running as root (who may chown to any group)
+	// use another one.
+	opts.SocketGroup = os.Getgid()
+	if opts.SocketGroup == 0 {
+		opts.SocketGroup = 1
+	}
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	srv.storage = isecrets.NewMemoryStorage()
+
+	done := make(chan error, 1)
+	go func() { done <- srv.Run(context.Background()) }()
+	defer srv.Stop()
+
+	// Give Run a moment to bind the socket, surfacing early failures
+	select {
+	case err := <-done:
+		t.Fatalf("Run exited early: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	info, err := os.Stat(opts.SocketPath)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if mode := info.Mode().Perm(); mode != 0o660 {
+		t.Errorf("Expected socket mode 0660, got %o", mode)
+	}
+
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		t.Fatalf("Expected a syscall.Stat_t from Stat")
+	}
+	if int(stat.Gid) != opts.SocketGroup {
+		t.Errorf("Expected socket group %d, got %d", opts.SocketGroup, stat.Gid)
+	}
+}
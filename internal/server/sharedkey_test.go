@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"google.golang.org/grpc/peer"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	isecrets "github.com/carabiner-dev/burnafter/internal/secrets"
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// otherBinaryContext returns a peer context pointing at PID 1, whose binary
+// is guaranteed to differ from the test binary. It simulates a second,
+// different binary of a cooperating suite calling the daemon.
+func otherBinaryContext() context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: &peerAuthInfo{
+			PID: 1,
+			UID: uint32(os.Getuid()),
+			GID: uint32(os.Getgid()),
+		},
+	})
+}
+
+func newSharedKeyTestServer(t *testing.T, sharedKey string) *Server {
+	t.Helper()
+
+	opts := *options.DefaultServer
+	opts.SharedKey = sharedKey
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	srv.storage = isecrets.NewMemoryStorage()
+	return srv
+}
+
+func TestSharedKeyCrossBinaryAccess(t *testing.T) {
+	srv := newSharedKeyTestServer(t, "suite-shared-secret")
+
+	// One binary of the suite stores the secret...
+	storeResp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:        "shared-secret",
+		Secret:      []byte("shared-value"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v %s", err, storeResp.GetError())
+	}
+
+	// ...and a different binary (different hash) with the same shared key and
+	// nonce can read it back
+	getResp, err := srv.Get(otherBinaryContext(), &common.GetRequest{
+		Name:        "shared-secret",
+		ClientNonce: "test-nonce",
+	})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !getResp.Success {
+		t.Fatalf("Expected cross-binary access with a shared key: %s", getResp.Error)
+	}
+	if string(getResp.Secret) != "shared-value" {
+		t.Errorf("Expected shared-value, got %q", getResp.Secret)
+	}
+}
+
+func TestSharedKeyStillRequiresNonce(t *testing.T) {
+	srv := newSharedKeyTestServer(t, "suite-shared-secret")
+
+	storeResp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:        "nonce-gated",
+		Secret:      []byte("value"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v %s", err, storeResp.GetError())
+	}
+
+	// The shared key does not waive the client nonce: a wrong nonce derives a
+	// different key and decryption fails
+	getResp, err := srv.Get(otherBinaryContext(), &common.GetRequest{
+		Name:        "nonce-gated",
+		ClientNonce: "wrong-nonce",
+	})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if getResp.Success {
+		t.Errorf("Expected retrieval with a wrong nonce to fail")
+	}
+}
+
+func TestNoSharedKeyKeepsBinaryGate(t *testing.T) {
+	srv := newTestServer(t)
+
+	storeResp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:        "binary-gated",
+		Secret:      []byte("value"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v %s", err, storeResp.GetError())
+	}
+
+	// Without a shared key, a different binary must be rejected
+	getResp, err := srv.Get(otherBinaryContext(), &common.GetRequest{
+		Name:        "binary-gated",
+		ClientNonce: "test-nonce",
+	})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if getResp.Success {
+		t.Errorf("Expected a different binary to be rejected without a shared key")
+	}
+}
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chainguard-dev/clog"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+// Shutdown implements the Shutdown RPC. It wipes every stored secret and then
+// stops the gRPC server, for clients that are exiting cleanly and don't want
+// to leave the daemon lingering until the inactivity timeout. The caller must
+// own at least one stored secret — its key hash must match a stored payload —
+// so an unrelated local process cannot burn everyone's secrets as a denial of
+// service; an empty server has nothing to protect and obeys any peer. The
+// response is sent before the server actually stops (GracefulStop waits for
+// in-flight requests, so it runs after this handler returns).
+func (s *Server) Shutdown(ctx context.Context, _ *common.ShutdownRequest) (*common.ShutdownResponse, error) {
+	s.updateActivity()
+
+	clog.FromContext(ctx).Debugf("Shutdown request received")
+
+	// Get client PID and verify binary
+	authInfo, err := GetPeerAuthInfo(ctx)
+	if err != nil {
+		s.authFailures.peerCredential.Add(1)
+		return &common.ShutdownResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get client credentials: %v", err),
+		}, nil
+	}
+
+	clientHash, err := s.clientKeyHash(authInfo)
+	if err != nil {
+		s.authFailures.peerCredential.Add(1)
+		return &common.ShutdownResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to verify client binary: %v", err),
+		}, nil
+	}
+
+	// Snapshot the non-alias secret names to check ownership against
+	s.secretsMu.RLock()
+	names := make([]string, 0, len(s.secrets))
+	for name, metadata := range s.secrets {
+		if metadata.AliasTarget == "" {
+			names = append(names, name)
+		}
+	}
+	s.secretsMu.RUnlock()
+
+	authorized := len(names) == 0
+	for _, name := range names {
+		stored, err := s.storage.Get(ctx, name)
+		if err != nil {
+			continue
+		}
+		match := stored.ClientBinaryHash == clientHash
+		common.ZeroBytes(stored.EncryptedData)
+		common.ZeroBytes(stored.Salt)
+		if match {
+			authorized = true
+			break
+		}
+	}
+	if !authorized {
+		s.authFailures.mismatchOtherUID.Add(1)
+		clog.FromContext(ctx).Warnf(
+			"AUDIT: shutdown refused for uid %d: no stored secret matches the caller's binary hash",
+			authInfo.UID,
+		)
+		return &common.ShutdownResponse{
+			Success: false,
+			Error:   "client binary hash matches no stored secret - unauthorized",
+		}, nil
+	}
+
+	// Wipe everything: the payloads from the storage backend, then the
+	// lifecycle metadata and any outstanding retrieval handles
+	s.flushStorage(ctx)
+	s.secretsMu.Lock()
+	s.handles = make(map[string]string)
+	s.secretsMu.Unlock()
+
+	clog.FromContext(ctx).Warnf("AUDIT: shutting down on request from uid %d, all secrets wiped", authInfo.UID)
+
+	// Stop after this handler has returned its acknowledgement; GracefulStop
+	// (via Stop, which tolerates a server that never started listening)
+	// blocks until in-flight RPCs — including this one — complete
+	go s.Stop()
+
+	return &common.ShutdownResponse{Success: true}, nil
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+func TestShutdownWipesOwnedSecrets(t *testing.T) {
+	srv := newTestServer(t)
+
+	// Store through the real handler so the payload records this test
+	// binary's hash, making the caller an owner
+	resp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:        "mine",
+		Secret:      []byte("value"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, resp.GetError())
+	}
+
+	shutResp, err := srv.Shutdown(testPeerContext(), &common.ShutdownRequest{})
+	if err != nil || !shutResp.Success {
+		t.Fatalf("Shutdown failed: %v (%s)", err, shutResp.GetError())
+	}
+
+	// Everything must be wiped: metadata map and storage backend
+	srv.secretsMu.RLock()
+	remaining := len(srv.secrets)
+	srv.secretsMu.RUnlock()
+	if remaining != 0 {
+		t.Errorf("Expected no secrets after shutdown, found %d", remaining)
+	}
+	if _, err := srv.storage.Get(t.Context(), "mine"); err == nil {
+		t.Errorf("Expected the payload to be gone from the storage backend")
+	}
+}
+
+func TestShutdownRejectsForeignBinary(t *testing.T) {
+	srv := newTestServer(t)
+
+	// addTestSecret writes a payload with no binary hash, so the caller
+	// matches nothing it could claim ownership of
+	addTestSecret(t, srv, "not-yours", nil, time.Now())
+
+	resp, err := srv.Shutdown(testPeerContext(), &common.ShutdownRequest{})
+	if err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if resp.Success {
+		t.Errorf("Expected shutdown to be refused for a non-owner")
+	}
+
+	// The refused request must leave the secret intact
+	srv.secretsMu.RLock()
+	_, exists := srv.secrets["not-yours"]
+	srv.secretsMu.RUnlock()
+	if !exists {
+		t.Errorf("Expected the secret to survive a refused shutdown")
+	}
+}
+
+func TestShutdownOnEmptyServerObeysAnyPeer(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := srv.Shutdown(testPeerContext(), &common.ShutdownRequest{})
+	if err != nil || !resp.Success {
+		t.Fatalf("Expected an empty server to obey any peer, got: %v (%s)", err, resp.GetError())
+	}
+}
@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/chainguard-dev/clog"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+// Snapshot persistence lets a respawned server pick up the secrets a previous
+// instance was guarding, closing the gap where an idle shutdown silently loses
+// everything held by the memory backend.
+//
+// The snapshot holds the already encrypted payloads, their lifecycle metadata
+// and the session ID. The session ID must be persisted: it is one of the key
+// derivation inputs, so a server restarted with a fresh one could never hand
+// the old secrets back. That also makes the snapshot sensitive — together with
+// the client binary hash and the client nonce it is enough to re-derive the
+// secret keys. To raise the bar, the snapshot is itself encrypted with
+// AES-256-GCM under a key derived from the server's own binary hash and a
+// random salt kept in the file header, and the file is written 0600. An
+// attacker who can read the user's files and hash the user's binaries is
+// indistinguishable from the user, so this protects against leaked backups
+// and other-user reads, not against a compromise of the account itself.
+// Deployments that cannot accept a disk copy of the session ID should simply
+// leave SnapshotPath unset.
+
+// snapshotVersion identifies the snapshot file layout:
+// [version:1][salt:32][aes-gcm blob]
+const snapshotVersion = byte(1)
+
+// snapshotState is the gob-encoded content of a snapshot.
+type snapshotState struct {
+	SessionID string
+	Payloads  map[string]*secrets.Payload
+	Metadata  map[string]*secrets.Metadata
+}
+
+// snapshotKey derives the snapshot encryption key from the server's own
+// binary hash and the given salt.
+func snapshotKey(salt []byte) ([]byte, error) {
+	_, selfHash, err := common.GetClientBinaryInfo(int32(os.Getpid())) //nolint:gosec // PIDs fit in int32
+	if err != nil {
+		return nil, fmt.Errorf("hashing server binary: %w", err)
+	}
+	return common.DeriveKey(selfHash, "", "", "burnafter-snapshot", salt)
+}
+
+// saveSnapshot writes the current secrets, metadata and session ID to the
+// configured snapshot path. It is a no-op when persistence is disabled.
+func (s *Server) saveSnapshot(ctx context.Context) error {
+	path := s.options.SnapshotPath
+	if path == "" {
+		return nil
+	}
+
+	state := snapshotState{
+		SessionID: s.sessionID,
+		Payloads:  map[string]*secrets.Payload{},
+		Metadata:  map[string]*secrets.Metadata{},
+	}
+
+	s.secretsMu.RLock()
+	for name, metadata := range s.secrets {
+		payload, err := s.storage.Get(ctx, name)
+		if err != nil {
+			// A secret without a payload is already lost; skip it
+			continue
+		}
+		metadataCopy := *metadata
+		state.Metadata[name] = &metadataCopy
+		state.Payloads[name] = payload
+	}
+	s.secretsMu.RUnlock()
+
+	var encoded bytes.Buffer
+	if err := gob.NewEncoder(&encoded).Encode(state); err != nil {
+		return fmt.Errorf("encoding snapshot: %w", err)
+	}
+
+	salt, err := common.GenerateSaltFrom(s.deps.Reader())
+	if err != nil {
+		return fmt.Errorf("generating snapshot salt: %w", err)
+	}
+
+	key, err := snapshotKey(salt)
+	if err != nil {
+		return err
+	}
+	defer common.ZeroBytes(key)
+
+	ciphertext, err := common.Encrypt(encoded.String(), key)
+	if err != nil {
+		return fmt.Errorf("encrypting snapshot: %w", err)
+	}
+
+	data := make([]byte, 0, 1+len(salt)+len(ciphertext))
+	data = append(data, snapshotVersion)
+	data = append(data, salt...)
+	data = append(data, ciphertext...)
+
+	// Write atomically so a crash mid-write never clobbers the previous
+	// snapshot
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath) //nolint:errcheck,gosec
+		return fmt.Errorf("replacing snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// loadSnapshot restores a previous instance's secrets from the configured
+// snapshot path, adopting its session ID so the restored secrets remain
+// decryptable. Missing files and expired secrets are not errors.
+func (s *Server) loadSnapshot(ctx context.Context) error {
+	path := s.options.SnapshotPath
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("reading snapshot: %w", err)
+	}
+
+	if len(data) < 1+32 || data[0] != snapshotVersion {
+		return fmt.Errorf("invalid snapshot file format")
+	}
+	salt, ciphertext := data[1:33], data[33:]
+
+	key, err := snapshotKey(salt)
+	if err != nil {
+		return err
+	}
+	defer common.ZeroBytes(key)
+
+	plaintext, err := common.Decrypt(ciphertext, key)
+	if err != nil {
+		return fmt.Errorf("decrypting snapshot: %w", err)
+	}
+
+	var state snapshotState
+	if err := gob.NewDecoder(bytes.NewReader([]byte(plaintext))).Decode(&state); err != nil {
+		return fmt.Errorf("decoding snapshot: %w", err)
+	}
+
+	// Adopt the previous session ID: without it the restored payloads could
+	// never be decrypted again
+	s.sessionID = state.SessionID
+
+	now := s.now()
+	restored := 0
+	s.secretsMu.Lock()
+	for name, metadata := range state.Metadata {
+		payload, ok := state.Payloads[name]
+		if !ok {
+			continue
+		}
+		// Skip secrets that expired while no server was running
+		if metadata.InactivityTTL >= 0 && now.Sub(metadata.LastAccessed) > metadata.InactivityTTL {
+			continue
+		}
+		if metadata.AbsoluteExpiresAt != nil && now.After(*metadata.AbsoluteExpiresAt) {
+			continue
+		}
+		if err := s.storage.Store(ctx, name, payload); err != nil {
+			clog.FromContext(ctx).Debugf("Failed to restore secret '%s': %v", name, err)
+			continue
+		}
+		s.secrets[name] = metadata
+		if metadata.Handle != "" {
+			s.handles[metadata.Handle] = name
+		}
+		restored++
+	}
+	s.secretsMu.Unlock()
+
+	clog.FromContext(ctx).Debugf("Restored %d secret(s) from snapshot", restored)
+	return nil
+}
+
+// snapshotLoop periodically rewrites the snapshot file until shutdown, saving
+// one final time so the snapshot reflects the server's last state.
+func (s *Server) snapshotLoop() {
+	interval := s.options.SnapshotInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.saveSnapshot(s.ctx); err != nil {
+				clog.FromContext(s.ctx).Debugf("Failed to save snapshot: %v", err)
+			}
+		case <-s.shutdownChan:
+			if err := s.saveSnapshot(s.ctx); err != nil {
+				clog.FromContext(s.ctx).Debugf("Failed to save final snapshot: %v", err)
+			}
+			return
+		}
+	}
+}
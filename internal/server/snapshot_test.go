@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	isecrets "github.com/carabiner-dev/burnafter/internal/secrets"
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func newSnapshotTestServer(t *testing.T, snapshotPath string) *Server {
+	t.Helper()
+
+	opts := *options.DefaultServer
+	opts.SnapshotPath = snapshotPath
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	srv.storage = isecrets.NewMemoryStorage()
+	return srv
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	snapshotPath := filepath.Join(t.TempDir(), "burnafter.snapshot")
+
+	first := newSnapshotTestServer(t, snapshotPath)
+	addTestSecret(t, first, "persisted", map[string]string{"env": "test"}, time.Now())
+
+	if err := first.saveSnapshot(ctx); err != nil {
+		t.Fatalf("saveSnapshot failed: %v", err)
+	}
+
+	// The snapshot must not be readable by other users
+	info, err := os.Stat(snapshotPath)
+	if err != nil {
+		t.Fatalf("snapshot file missing: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected snapshot mode 0600, got %o", info.Mode().Perm())
+	}
+
+	// A respawned server restores the secrets and adopts the session ID
+	second := newSnapshotTestServer(t, snapshotPath)
+	if second.sessionID == first.sessionID {
+		t.Fatalf("Expected a fresh server to start with its own session ID")
+	}
+	if err := second.loadSnapshot(ctx); err != nil {
+		t.Fatalf("loadSnapshot failed: %v", err)
+	}
+
+	if second.sessionID != first.sessionID {
+		t.Errorf("Expected the restored server to adopt the snapshot's session ID")
+	}
+
+	second.secretsMu.RLock()
+	metadata, exists := second.secrets["persisted"]
+	second.secretsMu.RUnlock()
+	if !exists {
+		t.Fatalf("Expected the secret metadata to be restored")
+	}
+	if metadata.Labels["env"] != "test" {
+		t.Errorf("Expected labels to survive the round trip")
+	}
+
+	payload, err := second.storage.Get(ctx, "persisted")
+	if err != nil {
+		t.Fatalf("Expected the payload to be restored: %v", err)
+	}
+	if string(payload.EncryptedData) != "test-payload" {
+		t.Errorf("Payload mismatch after restore: %q", payload.EncryptedData)
+	}
+}
+
+func TestSnapshotSkipsExpired(t *testing.T) {
+	ctx := context.Background()
+	snapshotPath := filepath.Join(t.TempDir(), "burnafter.snapshot")
+
+	first := newSnapshotTestServer(t, snapshotPath)
+	addTestSecret(t, first, "stale", nil, time.Now().Add(-2*time.Hour))
+
+	if err := first.saveSnapshot(ctx); err != nil {
+		t.Fatalf("saveSnapshot failed: %v", err)
+	}
+
+	second := newSnapshotTestServer(t, snapshotPath)
+	if err := second.loadSnapshot(ctx); err != nil {
+		t.Fatalf("loadSnapshot failed: %v", err)
+	}
+
+	second.secretsMu.RLock()
+	_, exists := second.secrets["stale"]
+	second.secretsMu.RUnlock()
+	if exists {
+		t.Errorf("Expected secrets expired while down to be dropped on restore")
+	}
+}
+
+func TestSnapshotMissingFile(t *testing.T) {
+	srv := newSnapshotTestServer(t, filepath.Join(t.TempDir(), "absent.snapshot"))
+
+	// A first boot with no snapshot yet is not an error
+	if err := srv.loadSnapshot(context.Background()); err != nil {
+		t.Fatalf("loadSnapshot failed on a missing file: %v", err)
+	}
+}
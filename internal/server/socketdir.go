@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// CheckSocketDir verifies that the directory that will hold the socket is not
+// world-writable. A world-writable directory lets any local user plant an
+// impostor socket (or, without the sticky bit, replace an existing one), so
+// hardened deployments refuse to use such a directory at all. Used by both
+// the server before listening and the client before dialing when the strict
+// socket directory option is set; a private directory such as
+// $XDG_RUNTIME_DIR is the recommended home for the socket in that mode.
+func CheckSocketDir(socketPath string) error {
+	dir := filepath.Dir(socketPath)
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("checking socket directory: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("socket directory %s is not a directory", dir)
+	}
+
+	if info.Mode().Perm()&0o002 != 0 {
+		return fmt.Errorf(
+			"socket directory %s is world-writable (mode %04o); use a private directory such as $XDG_RUNTIME_DIR",
+			dir, info.Mode().Perm(),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	isecrets "github.com/carabiner-dev/burnafter/internal/secrets"
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func TestCheckSocketDir(t *testing.T) {
+	restricted := filepath.Join(t.TempDir(), "private")
+	if err := os.Mkdir(restricted, 0o700); err != nil {
+		t.Fatalf("Mkdir failed: %v", err)
+	}
+	open := filepath.Join(t.TempDir(), "open")
+	if err := os.Mkdir(open, 0o777); err != nil {
+		t.Fatalf("Mkdir failed: %v", err)
+	}
+	// Umask may have narrowed the mode; force it world-writable
+	if err := os.Chmod(open, 0o777); err != nil {
+		t.Fatalf("Chmod failed: %v", err)
+	}
+
+	if err := CheckSocketDir(filepath.Join(restricted, "s.sock")); err != nil {
+		t.Errorf("Expected a restricted directory to pass, got: %v", err)
+	}
+
+	err := CheckSocketDir(filepath.Join(open, "s.sock"))
+	if err == nil {
+		t.Fatalf("Expected a world-writable directory to be rejected")
+	}
+	if !strings.Contains(err.Error(), "world-writable") {
+		t.Errorf("Expected a world-writable error, got: %v", err)
+	}
+
+	if err := CheckSocketDir(filepath.Join(restricted, "missing", "s.sock")); err == nil {
+		t.Errorf("Expected a missing directory to be rejected")
+	}
+}
+
+func TestRunStrictSocketDir(t *testing.T) {
+	open := filepath.Join(t.TempDir(), "open")
+	if err := os.Mkdir(open, 0o777); err != nil {
+		t.Fatalf("Mkdir failed: %v", err)
+	}
+	if err := os.Chmod(open, 0o777); err != nil {
+		t.Fatalf("Chmod failed: %v", err)
+	}
+
+	opts := *options.DefaultServer
+	opts.SocketPath = filepath.Join(open, "strict.sock")
+	opts.StrictSocketDir = true
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	srv.storage = isecrets.NewMemoryStorage()
+
+	if err := srv.Run(context.Background()); err == nil {
+		t.Fatalf("Expected Run to refuse a world-writable socket directory")
+	}
+}
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+
+	"github.com/chainguard-dev/clog"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+// Stat implements the Stat RPC. It answers from the metadata map alone: no
+// payload is fetched, nothing is decrypted, and — deliberately — neither
+// updateActivity nor the secret's LastAccessed is touched, so a monitoring
+// poll never keeps an otherwise idle server or a dying secret alive. Aliases
+// resolve to the secret they point at, whose lifecycle governs the chain.
+func (s *Server) Stat(ctx context.Context, req *common.StatRequest) (*common.StatResponse, error) {
+	clog.FromContext(ctx).Debugf("Stat request for secret: %s", req.Name)
+
+	now := s.now()
+
+	s.secretsMu.RLock()
+	_, metadata, aliasErr := s.resolveAliasLocked(req.Name)
+	if aliasErr != nil {
+		s.secretsMu.RUnlock()
+		return &common.StatResponse{
+			Success: false,
+			Error:   "secret not found",
+		}, nil
+	}
+
+	resp := &common.StatResponse{
+		Success:      true,
+		TtlSeconds:   int64(metadata.InactivityTTL.Seconds()),
+		LastAccessed: metadata.LastAccessed.Unix(),
+		CreatedAt:    metadata.CreatedAt.Unix(),
+		State:        metadata.State(now),
+	}
+	if metadata.AbsoluteExpiresAt != nil {
+		resp.AbsoluteExpiresAt = metadata.AbsoluteExpiresAt.Unix()
+	}
+	s.secretsMu.RUnlock()
+
+	return resp, nil
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+func TestStatReportsMetadataWithoutAccess(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:                      "watched",
+		Secret:                    []byte("value"),
+		ClientNonce:               "test-nonce",
+		TtlSeconds:                120,
+		AbsoluteExpirationSeconds: 3600,
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, resp.GetError())
+	}
+
+	srv.secretsMu.RLock()
+	lastAccessed := srv.secrets["watched"].LastAccessed
+	deadline := srv.secrets["watched"].AbsoluteExpiresAt.Unix()
+	srv.secretsMu.RUnlock()
+
+	statResp, err := srv.Stat(testPeerContext(), &common.StatRequest{Name: "watched"})
+	if err != nil || !statResp.Success {
+		t.Fatalf("Stat failed: %v (%s)", err, statResp.GetError())
+	}
+
+	if statResp.TtlSeconds != 120 {
+		t.Errorf("Expected a 120s TTL, got %d", statResp.TtlSeconds)
+	}
+	if statResp.AbsoluteExpiresAt != deadline {
+		t.Errorf("Expected deadline %d, got %d", deadline, statResp.AbsoluteExpiresAt)
+	}
+	if statResp.State != secrets.StateLive {
+		t.Errorf("Expected a live state, got %q", statResp.State)
+	}
+
+	// The whole point of Stat: the inactivity timer must not have moved
+	srv.secretsMu.RLock()
+	after := srv.secrets["watched"].LastAccessed
+	srv.secretsMu.RUnlock()
+	if !after.Equal(lastAccessed) {
+		t.Errorf("Expected Stat to leave LastAccessed untouched: %v became %v", lastAccessed, after)
+	}
+}
+
+func TestStatReportsExpiredState(t *testing.T) {
+	srv := newTestServer(t)
+	now := time.Unix(1700000000, 0)
+	srv.deps = &common.Deps{Clock: func() time.Time { return now }}
+
+	resp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:        "dying",
+		Secret:      []byte("value"),
+		ClientNonce: "test-nonce",
+		TtlSeconds:  60,
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, resp.GetError())
+	}
+
+	// Past the TTL the state flips without Stat itself renewing anything
+	now = now.Add(2 * time.Minute)
+	statResp, err := srv.Stat(testPeerContext(), &common.StatRequest{Name: "dying"})
+	if err != nil || !statResp.Success {
+		t.Fatalf("Stat failed: %v (%s)", err, statResp.GetError())
+	}
+	if statResp.State != secrets.StateExpiredInactivity {
+		t.Errorf("Expected an expired-inactivity state, got %q", statResp.State)
+	}
+}
+
+func TestStatMissingSecret(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := srv.Stat(testPeerContext(), &common.StatRequest{Name: "never-stored"})
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if resp.Success || resp.Error != "secret not found" {
+		t.Errorf("Expected a not-found error, got success=%v error=%q", resp.Success, resp.Error)
+	}
+}
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	isecrets "github.com/carabiner-dev/burnafter/internal/secrets"
+	"github.com/carabiner-dev/burnafter/options"
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+func TestStorageFactoryOverridesSelection(t *testing.T) {
+	custom := isecrets.NewMemoryStorage()
+
+	opts := *options.DefaultServer
+	opts.StorageFactory = func(context.Context) (secrets.Storage, error) {
+		return custom, nil
+	}
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	if srv.storage != custom {
+		t.Errorf("Expected the factory's backend, got %s", srv.storage.Backend())
+	}
+}
+
+func TestStorageFactoryErrorFailsStartup(t *testing.T) {
+	factoryErr := errors.New("vault is sealed")
+
+	opts := *options.DefaultServer
+	opts.StorageFactory = func(context.Context) (secrets.Storage, error) {
+		return nil, factoryErr
+	}
+
+	// A failing factory must abort the startup, not degrade to memory: the
+	// embedder asked for that backend specifically
+	if _, err := NewServer(context.Background(), &opts); !errors.Is(err, factoryErr) {
+		t.Fatalf("Expected the factory error, got: %v", err)
+	}
+}
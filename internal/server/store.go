@@ -18,24 +18,29 @@ import (
 // in the server's secret map. It handles getting the client finger print,
 // deriving the key, encrypting the secret and storing it along with the
 // required metadaata.
-func (s *Server) Store(ctx context.Context, req *common.StoreRequest) (*common.StoreResponse, error) {
+func (s *Server) Store(ctx context.Context, req *common.StoreRequest) (resp *common.StoreResponse, _ error) {
 	s.updateActivity()
 
 	clog.FromContext(ctx).Debugf("Store request for secret: %s", req.Name)
 
+	audit := s.beginAudit(ctx, "store", req.Name)
+	defer func() { audit.finish(resp.GetSuccess(), resp.GetError()) }()
+
 	// Get client PID and verify binary
 	authInfo, err := GetPeerAuthInfo(ctx)
 	if err != nil {
+		s.authFailures.peerCredential.Add(1)
 		return &common.StoreResponse{
 			Success: false,
 			Error:   fmt.Sprintf("failed to get client credentials: %v", err),
 		}, nil
 	}
 
-	// Read the client binary information. This includes the hash which will
-	// be used to derive the encryption key.
-	_, clientHash, err := common.GetClientBinaryInfo(authInfo.PID)
+	// Read the client key hash (the caller's binary hash, or the shared key
+	// digest when one is configured) used to derive the encryption key.
+	clientHash, err := s.clientKeyHash(authInfo)
 	if err != nil {
+		s.authFailures.peerCredential.Add(1)
 		return &common.StoreResponse{
 			Success: false,
 			Error:   fmt.Sprintf("failed to verify client binary: %v", err),
@@ -44,6 +49,7 @@ func (s *Server) Store(ctx context.Context, req *common.StoreRequest) (*common.S
 
 	// Debug the hash value
 	clog.FromContext(ctx).Debugf("Client binary hash: %s", clientHash)
+	audit.setBinaryHash(clientHash)
 
 	// Check secret size limit
 	secretSize := int64(len(req.Secret))
@@ -54,21 +60,15 @@ func (s *Server) Store(ctx context.Context, req *common.StoreRequest) (*common.S
 		}, nil
 	}
 
-	// Check maximum number of secrets limit (only if storing a new secret)
-	s.secretsMu.RLock()
-	_, exists := s.secrets[req.Name]
-	currentCount := len(s.secrets)
-	s.secretsMu.RUnlock()
-
-	if !exists && currentCount >= s.options.MaxSecrets {
+	if err := s.ensureStoreCapacity(ctx, req.Name); err != nil {
 		return &common.StoreResponse{
 			Success: false,
-			Error:   fmt.Sprintf("maximum number of secrets (%d) reached", s.options.MaxSecrets),
+			Error:   err.Error(),
 		}, nil
 	}
 
 	// Generate salt for this secret
-	salt, err := common.GenerateSalt()
+	salt, err := common.GenerateSaltFrom(s.deps.Reader())
 	if err != nil {
 		return &common.StoreResponse{
 			Success: false,
@@ -85,36 +85,121 @@ func (s *Server) Store(ctx context.Context, req *common.StoreRequest) (*common.S
 			Error:   fmt.Sprintf("failed to derive key: %v", err),
 		}, nil
 	}
-	// Wipe out the key from memory when we are done
+	// Wipe out the key from memory when we are done. This is deliberately a
+	// defer so the key is also wiped when a later step panics.
 	defer common.ZeroBytes(key)
+	observeDerivedKey(key)
 
-	// Encrypt the secret
-	encrypted, err := common.Encrypt(req.Secret, key)
+	// Encrypt the secret straight from the request buffer — a string hop here
+	// would leave an unwipeable plaintext copy behind
+	encrypted, err := common.EncryptBytes(req.Secret, key)
 	if err != nil {
 		return &common.StoreResponse{
 			Success: false,
 			Error:   fmt.Sprintf("failed to encrypt secret: %v", err),
 		}, nil
 	}
+	common.ZeroBytes(req.Secret)
+
+	return s.finishStore(ctx, req, authInfo, clientHash, salt, encrypted, false, secretSize), nil
+}
+
+// ensureStoreCapacity checks the maximum number of secrets limit before a
+// store creates a new one. At capacity the default policy rejects the store
+// with the returned error; the opt-in "lru" policy instead evicts the
+// least-recently-accessed secret to make room.
+func (s *Server) ensureStoreCapacity(ctx context.Context, name string) error {
+	s.secretsMu.Lock()
+	_, exists := s.secrets[name]
+	if exists || len(s.secrets) < s.options.MaxSecrets {
+		s.secretsMu.Unlock()
+		return nil
+	}
 
-	// Calculate inactivity TTL
+	if s.options.EvictionPolicy != "lru" {
+		s.secretsMu.Unlock()
+		return fmt.Errorf("maximum number of secrets (%d) reached", s.options.MaxSecrets)
+	}
+
+	var victim string
+	var oldest time.Time
+	for name, metadata := range s.secrets {
+		if victim == "" || metadata.LastAccessed.Before(oldest) {
+			victim, oldest = name, metadata.LastAccessed
+		}
+	}
+	evicted := s.secrets[victim]
+	delete(s.secrets, victim)
+	s.dropHandleLocked(evicted)
+	s.secretsMu.Unlock()
+	_ = s.storage.Delete(ctx, victim) //nolint:errcheck
+
+	// Burning a secret to store another must leave a loud trail
+	clog.FromContext(ctx).Warnf(
+		"AUDIT: evicted least-recently-accessed secret '%s' to make room for '%s' (lru eviction policy)",
+		victim, name,
+	)
+	return nil
+}
+
+// finishStore runs the store lifecycle shared by the unary and streaming
+// paths once the secret has been encrypted: resolve the expiry settings,
+// persist the payload and register the lifecycle metadata. The chunked flag
+// records whether encrypted is framed per-chunk (streamed) or one sealed blob.
+func (s *Server) finishStore(ctx context.Context, req *common.StoreRequest, authInfo *peerAuthInfo, clientHash string, salt, encrypted []byte, chunked bool, plaintextSize int64) *common.StoreResponse {
+	// Calculate inactivity TTL. A negative request value asks for no
+	// inactivity expiry at all: the secret then lives until its absolute
+	// deadline, which is mandatory in that case so it still dies eventually.
 	ttl := time.Duration(req.TtlSeconds) * time.Second
-	if ttl == 0 {
+	switch {
+	case req.TtlSeconds < 0:
+		if req.AbsoluteExpirationSeconds <= 0 {
+			return &common.StoreResponse{
+				Success: false,
+				Error:   "a secret without inactivity expiry requires an absolute expiration",
+			}
+		}
+		ttl = secrets.NoInactivityExpiry
+	case ttl == 0:
 		ttl = s.options.DefaultTTL
 	}
 
 	// Calculate optional absolute expiration
 	var absoluteExpiresAt *time.Time
 	if req.AbsoluteExpirationSeconds > 0 {
-		t := time.Now().Add(time.Duration(req.AbsoluteExpirationSeconds) * time.Second)
+		t := s.now().Add(time.Duration(req.AbsoluteExpirationSeconds) * time.Second)
 		absoluteExpiresAt = &t
 	}
 
+	// Optional not-before seal: the secret is stored now but reads are
+	// refused until this time passes. A seal past the absolute deadline would
+	// leave no window in which the secret is ever readable.
+	var notBefore *time.Time
+	if req.NotBefore > 0 {
+		t := time.Unix(req.NotBefore, 0)
+		if absoluteExpiresAt != nil && !t.Before(*absoluteExpiresAt) {
+			return &common.StoreResponse{
+				Success: false,
+				Error:   "not-before time is at or past the absolute expiration",
+			}
+		}
+		notBefore = &t
+	}
+
+	// A negative read budget is meaningless; zero means unlimited reads
+	if req.MaxReads < 0 {
+		return &common.StoreResponse{
+			Success: false,
+			Error:   fmt.Sprintf("invalid max reads: %d", req.MaxReads),
+		}
+	}
+
 	// Create the stored secret with encrypted data
 	stored := &secrets.Payload{
 		EncryptedData:    encrypted,
 		Salt:             salt,
 		ClientBinaryHash: clientHash,
+		Chunked:          chunked,
 	}
 
 	// Store the encrypted secret in the storage backend
@@ -122,17 +207,45 @@ func (s *Server) Store(ctx context.Context, req *common.StoreRequest) (*common.S
 		return &common.StoreResponse{
 			Success: false,
 			Error:   fmt.Sprintf("failed to store secret in backend: %v", err),
-		}, nil
+		}
+	}
+
+	// Generate the single-use retrieval handle when the client asked for one.
+	// The token is unguessable (crypto/rand) and dies with the secret.
+	var handle string
+	if req.ReturnHandle {
+		var err error
+		handle, err = common.GenerateSessionID()
+		if err != nil {
+			return &common.StoreResponse{
+				Success: false,
+				Error:   fmt.Sprintf("failed to generate handle: %v", err),
+			}
+		}
 	}
 
 	// Store only metadata in server memory for lifecycle management
-	now := time.Now()
+	now := s.now()
 	s.secretsMu.Lock()
+	// Replacing a secret invalidates any handle the old version had
+	if old, exists := s.secrets[req.Name]; exists {
+		s.dropHandleLocked(old)
+	}
 	s.secrets[req.Name] = &secrets.Metadata{
 		Name:              req.Name,
 		InactivityTTL:     ttl,
 		AbsoluteExpiresAt: absoluteExpiresAt,
+		NotBefore:         notBefore,
 		LastAccessed:      now,
+		CreatedAt:         now,
+		Labels:            req.Labels,
+		Handle:            handle,
+		OwnerUID:          authInfo.UID,
+		PlaintextLength:   plaintextSize,
+		MaxReads:          req.MaxReads,
+	}
+	if handle != "" {
+		s.handles[handle] = req.Name
 	}
 	s.secretsMu.Unlock()
 
@@ -144,5 +257,27 @@ func (s *Server) Store(ctx context.Context, req *common.StoreRequest) (*common.S
 			req.Name, ttl)
 	}
 
-	return &common.StoreResponse{Success: true}, nil
+	resp := &common.StoreResponse{
+		Success: true,
+		Handle:  handle,
+		// Report the lifecycle actually applied, after defaulting, so the
+		// client can plan a refresh without knowing this server's DefaultTTL
+		EffectiveTtlSeconds: int64(ttl.Seconds()),
+	}
+	if absoluteExpiresAt != nil {
+		resp.AbsoluteExpiresAt = absoluteExpiresAt.Unix()
+	}
+	// Echo the non-sensitive derivation parameters when asked, so auditable
+	// callers can check the binary hash bound into the key at store time
+	// instead of discovering a mismatch at the next Get
+	if req.EchoDerivation {
+		resp.Derivation = &common.DerivationInfo{
+			Kdf:              "hkdf-sha256",
+			Cipher:           "aes-256-gcm",
+			SaltLength:       int64(len(salt)),
+			ClientBinaryHash: clientHash,
+		}
+	}
+
+	return resp
 }
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+func TestStoreReportsDefaultedTTL(t *testing.T) {
+	srv := newTestServer(t)
+
+	// No TTL in the request: the response must reveal the default applied
+	resp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:        "defaulted",
+		Secret:      []byte("v"),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, resp.GetError())
+	}
+
+	want := int64(srv.options.DefaultTTL.Seconds())
+	if resp.EffectiveTtlSeconds != want {
+		t.Errorf("Expected the default TTL %d to be reported, got %d", want, resp.EffectiveTtlSeconds)
+	}
+	if resp.AbsoluteExpiresAt != 0 {
+		t.Errorf("Expected no absolute deadline, got %d", resp.AbsoluteExpiresAt)
+	}
+}
+
+func TestStoreReportsResolvedDeadline(t *testing.T) {
+	srv := newTestServer(t)
+	now := time.Unix(1700000000, 0)
+	srv.deps = &common.Deps{Clock: func() time.Time { return now }}
+
+	resp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:                      "bounded",
+		Secret:                    []byte("v"),
+		ClientNonce:               "test-nonce",
+		TtlSeconds:                120,
+		AbsoluteExpirationSeconds: 3600,
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("Store failed: %v (%s)", err, resp.GetError())
+	}
+
+	if resp.EffectiveTtlSeconds != 120 {
+		t.Errorf("Expected the requested TTL to be echoed, got %d", resp.EffectiveTtlSeconds)
+	}
+	if want := now.Add(time.Hour).Unix(); resp.AbsoluteExpiresAt != want {
+		t.Errorf("Expected the resolved deadline %d, got %d", want, resp.AbsoluteExpiresAt)
+	}
+}
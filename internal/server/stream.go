@@ -0,0 +1,257 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/chainguard-dev/clog"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+// StoreStream implements the StoreStream RPC, the client-streaming store for
+// secrets too large to buffer whole. The first frame carries the store
+// parameters, the following frames the plaintext in chunks; each chunk is
+// sealed into the chunk-framed payload format as it arrives, so the server
+// never holds more than one chunk of plaintext at a time. The accumulated
+// ciphertext still counts against MaxSecretSize like a unary store.
+func (s *Server) StoreStream(stream common.BurnAfter_StoreStreamServer) error {
+	s.updateActivity()
+
+	ctx := stream.Context()
+
+	fail := func(msg string) error {
+		return stream.SendAndClose(&common.StoreResponse{
+			Success: false,
+			Error:   msg,
+		})
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		return fmt.Errorf("receiving store parameters: %w", err)
+	}
+	info := first.GetInfo()
+	if info == nil {
+		return fail("first frame must carry the store parameters")
+	}
+	if len(info.Secret) > 0 {
+		return fail("the secret travels in chunk frames, not in the store parameters")
+	}
+
+	clog.FromContext(ctx).Debugf("StoreStream request for secret: %s", info.Name)
+
+	audit := s.beginAudit(ctx, "store", info.Name)
+	failAudited := func(msg string) error {
+		audit.finish(false, msg)
+		return fail(msg)
+	}
+
+	// Get client PID and verify binary
+	authInfo, err := GetPeerAuthInfo(ctx)
+	if err != nil {
+		s.authFailures.peerCredential.Add(1)
+		return failAudited(fmt.Sprintf("failed to get client credentials: %v", err))
+	}
+
+	clientHash, err := s.clientKeyHash(authInfo)
+	if err != nil {
+		s.authFailures.peerCredential.Add(1)
+		return failAudited(fmt.Sprintf("failed to verify client binary: %v", err))
+	}
+	audit.setBinaryHash(clientHash)
+
+	if err := s.ensureStoreCapacity(ctx, info.Name); err != nil {
+		return failAudited(err.Error())
+	}
+
+	// Generate salt for this secret
+	salt, err := common.GenerateSaltFrom(s.deps.Reader())
+	if err != nil {
+		return failAudited(fmt.Sprintf("failed to generate salt: %v", err))
+	}
+
+	// Derive the encryption key from the client hash, the client nonce, our
+	// session ID and the secret name (plus the salt)
+	key, err := common.DeriveKey(clientHash, info.ClientNonce, s.sessionID, info.Name, salt)
+	if err != nil {
+		return failAudited(fmt.Sprintf("failed to derive key: %v", err))
+	}
+	// Wipe out the key from memory when we are done. This is deliberately a
+	// defer so the key is also wiped when a later step panics.
+	defer common.ZeroBytes(key)
+	observeDerivedKey(key)
+
+	encrypter, err := common.NewChunkedEncrypter(key)
+	if err != nil {
+		return failAudited(fmt.Sprintf("failed to create encrypter: %v", err))
+	}
+
+	// Seal the chunks as they arrive. A chunk only turns out to be the last
+	// one when the next Recv reports the end of the stream, so each chunk is
+	// held pending until its successor (or the end) shows up. Every sealed
+	// plaintext chunk is wiped right away.
+	var encrypted []byte
+	var plaintextSize int64
+	var pending []byte
+	havePending := false
+	for {
+		frame, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			common.ZeroBytes(pending)
+			return fmt.Errorf("receiving secret chunk: %w", err)
+		}
+		if frame.GetInfo() != nil {
+			common.ZeroBytes(pending)
+			return failAudited("store parameters sent more than once")
+		}
+
+		chunk := frame.GetChunk()
+		plaintextSize += int64(len(chunk))
+		if plaintextSize > s.options.MaxSecretSize {
+			common.ZeroBytes(pending)
+			common.ZeroBytes(chunk)
+			return failAudited(fmt.Sprintf("secret size (%d bytes) exceeds maximum allowed size (%d bytes)",
+				plaintextSize, s.options.MaxSecretSize))
+		}
+
+		if havePending {
+			sealed, err := encrypter.Seal(pending, false)
+			common.ZeroBytes(pending)
+			if err != nil {
+				common.ZeroBytes(chunk)
+				return failAudited(fmt.Sprintf("failed to encrypt secret: %v", err))
+			}
+			encrypted = append(encrypted, sealed...)
+		}
+		pending = chunk
+		havePending = true
+	}
+
+	// Seal the final chunk; an empty one when the stream carried no chunks at
+	// all, so even an empty secret gets a complete, authenticated payload.
+	sealed, err := encrypter.Seal(pending, true)
+	common.ZeroBytes(pending)
+	if err != nil {
+		return failAudited(fmt.Sprintf("failed to encrypt secret: %v", err))
+	}
+	encrypted = append(encrypted, sealed...)
+
+	resp := s.finishStore(ctx, info, authInfo, clientHash, salt, encrypted, true, plaintextSize)
+	audit.finish(resp.GetSuccess(), resp.GetError())
+	return stream.SendAndClose(resp)
+}
+
+// GetStream implements the GetStream RPC, the server-streaming counterpart of
+// Get. The first frame reports the outcome and the lifecycle metadata; on
+// success the plaintext follows in chunks, each wiped once sent. A payload
+// written by the streaming store is decrypted chunk by chunk, so the whole
+// plaintext never sits in the server's memory; one written by a unary store
+// is decrypted whole and sliced.
+func (s *Server) GetStream(req *common.GetRequest, stream common.BurnAfter_GetStreamServer) error {
+	s.updateActivity()
+
+	ctx := stream.Context()
+
+	clog.FromContext(ctx).Debugf("GetStream request for secret: %s", req.Name)
+
+	audit := s.beginAudit(ctx, "get", req.Name)
+
+	lease, errResp := s.fetchForRead(ctx, req.Name, req.ClientNonce, audit)
+	if errResp != nil {
+		audit.finish(false, errResp.Error)
+		return stream.Send(&common.GetStreamResponse{
+			Success:   false,
+			Error:     errResp.Error,
+			ErrorCode: errResp.ErrorCode,
+		})
+	}
+	defer common.ZeroBytes(lease.key)
+
+	// The read is committed from here on: the lifecycle checks passed and
+	// the outcome frame goes out before any plaintext.
+	if err := stream.Send(&common.GetStreamResponse{
+		Success:           true,
+		TtlSeconds:        lease.ttlSeconds,
+		AbsoluteExpiresAt: lease.absoluteExpiresAt,
+	}); err != nil {
+		return fmt.Errorf("sending response header: %w", err)
+	}
+
+	sendChunk := func(chunk []byte) error {
+		err := stream.Send(&common.GetStreamResponse{Chunk: chunk})
+		common.ZeroBytes(chunk)
+		if err != nil {
+			return fmt.Errorf("sending secret chunk: %w", err)
+		}
+		return nil
+	}
+
+	if lease.stored.Chunked {
+		decrypter, err := common.NewChunkedDecrypter(lease.stored.EncryptedData, lease.key)
+		if err != nil {
+			return fmt.Errorf("failed to create decrypter: %w", err)
+		}
+		for {
+			chunk, err := decrypter.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("failed to decrypt secret: %w", err)
+			}
+			if err := sendChunk(chunk); err != nil {
+				return err
+			}
+		}
+	} else {
+		plaintext, err := common.DecryptBytes(lease.stored.EncryptedData, lease.key)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt secret: %w", err)
+		}
+		for len(plaintext) > 0 {
+			n := min(len(plaintext), common.StreamChunkSize)
+			if err := sendChunk(plaintext[:n]); err != nil {
+				common.ZeroBytes(plaintext)
+				return err
+			}
+			plaintext = plaintext[n:]
+		}
+	}
+
+	s.finishRead(ctx, lease)
+	audit.finish(true, "")
+
+	clog.FromContext(ctx).Debugf("Streamed secret '%s'", lease.name)
+	return nil
+}
+
+// decryptStoredBytes opens a stored payload into a plaintext slice the caller
+// owns (and should wipe), transparently reassembling chunk-framed payloads
+// written by the streaming store.
+func decryptStoredBytes(payload *secrets.Payload, key []byte) ([]byte, error) {
+	if payload.Chunked {
+		return common.DecryptChunkedBytes(payload.EncryptedData, key)
+	}
+	return common.DecryptBytes(payload.EncryptedData, key)
+}
+
+// decryptStoredString is decryptStoredBytes for the handlers that rework the
+// value as a string (Reclaim); the string form cannot be wiped.
+func decryptStoredString(payload *secrets.Payload, key []byte) (string, error) {
+	plaintext, err := decryptStoredBytes(payload, key)
+	if err != nil {
+		return "", err
+	}
+	// The string conversion copies, so the intermediate buffer can go
+	defer common.ZeroBytes(plaintext)
+	return string(plaintext), nil
+}
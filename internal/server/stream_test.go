@@ -0,0 +1,278 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// fakeServerStream supplies the grpc.ServerStream plumbing the handler fakes
+// below need, carrying only the peer context.
+type fakeServerStream struct {
+	ctx context.Context
+}
+
+func (f *fakeServerStream) SetHeader(metadata.MD) error  { return nil }
+func (f *fakeServerStream) SendHeader(metadata.MD) error { return nil }
+func (f *fakeServerStream) SetTrailer(metadata.MD)       {}
+func (f *fakeServerStream) Context() context.Context     { return f.ctx }
+func (f *fakeServerStream) SendMsg(any) error            { return nil }
+func (f *fakeServerStream) RecvMsg(any) error            { return nil }
+
+// fakeStoreStream feeds a prepared frame sequence to StoreStream and captures
+// the response it closes with.
+type fakeStoreStream struct {
+	fakeServerStream
+	frames []*common.StoreStreamRequest
+	resp   *common.StoreResponse
+}
+
+func (f *fakeStoreStream) Recv() (*common.StoreStreamRequest, error) {
+	if len(f.frames) == 0 {
+		return nil, io.EOF
+	}
+	frame := f.frames[0]
+	f.frames = f.frames[1:]
+	return frame, nil
+}
+
+func (f *fakeStoreStream) SendAndClose(resp *common.StoreResponse) error {
+	f.resp = resp
+	return nil
+}
+
+// fakeGetStream captures the frames GetStream sends. The handler wipes each
+// chunk once sent, so the capture copies the bytes.
+type fakeGetStream struct {
+	fakeServerStream
+	sent []*common.GetStreamResponse
+}
+
+func (f *fakeGetStream) Send(resp *common.GetStreamResponse) error {
+	captured := &common.GetStreamResponse{
+		Success:           resp.Success,
+		Error:             resp.Error,
+		ErrorCode:         resp.ErrorCode,
+		TtlSeconds:        resp.TtlSeconds,
+		AbsoluteExpiresAt: resp.AbsoluteExpiresAt,
+		Chunk:             bytes.Clone(resp.Chunk),
+	}
+	f.sent = append(f.sent, captured)
+	return nil
+}
+
+// storeFrames builds the frame sequence for a streamed store: the parameter
+// frame followed by the plaintext sliced into chunks of the given size.
+func storeFrames(name string, plaintext []byte, chunkSize int) []*common.StoreStreamRequest {
+	frames := []*common.StoreStreamRequest{{
+		Frame: &common.StoreStreamRequest_Info{Info: &common.StoreRequest{
+			Name:        name,
+			ClientNonce: "test-nonce",
+		}},
+	}}
+	for len(plaintext) > 0 {
+		n := min(len(plaintext), chunkSize)
+		frames = append(frames, &common.StoreStreamRequest{
+			Frame: &common.StoreStreamRequest_Chunk{Chunk: bytes.Clone(plaintext[:n])},
+		})
+		plaintext = plaintext[n:]
+	}
+	return frames
+}
+
+func TestStoreStreamUnaryGetRoundTrip(t *testing.T) {
+	srv := newTestServer(t)
+
+	// Three full chunks plus a partial one, so the chunk framing exercises
+	// both boundaries
+	plaintext := bytes.Repeat([]byte("stream-me-"), (3*common.StreamChunkSize)/10+50)
+
+	stream := &fakeStoreStream{
+		fakeServerStream: fakeServerStream{ctx: testPeerContext()},
+		frames:           storeFrames("streamed", plaintext, common.StreamChunkSize),
+	}
+	if err := srv.StoreStream(stream); err != nil {
+		t.Fatalf("StoreStream failed: %v", err)
+	}
+	if stream.resp == nil || !stream.resp.Success {
+		t.Fatalf("StoreStream response not successful: %+v", stream.resp)
+	}
+
+	stored, err := srv.storage.Get(context.Background(), "streamed")
+	if err != nil {
+		t.Fatalf("reading stored payload: %v", err)
+	}
+	if !stored.Chunked {
+		t.Error("streamed payload not marked chunked")
+	}
+
+	// The unary Get must transparently reassemble the chunked payload
+	resp, err := srv.Get(testPeerContext(), &common.GetRequest{
+		Name:        "streamed",
+		ClientNonce: "test-nonce",
+	})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("Get not successful: %s", resp.Error)
+	}
+	if !bytes.Equal(resp.Secret, plaintext) {
+		t.Errorf("round-tripped secret does not match: got %d bytes, want %d", len(resp.Secret), len(plaintext))
+	}
+}
+
+func TestGetStreamOfUnaryStore(t *testing.T) {
+	srv := newTestServer(t)
+
+	// Larger than one stream chunk, so the blob gets sliced on the way out
+	plaintext := bytes.Repeat([]byte("blob-secret-"), common.StreamChunkSize/10)
+
+	storeResp, err := srv.Store(testPeerContext(), &common.StoreRequest{
+		Name:        "blob",
+		Secret:      bytes.Clone(plaintext),
+		ClientNonce: "test-nonce",
+	})
+	if err != nil || !storeResp.Success {
+		t.Fatalf("Store failed: %v / %+v", err, storeResp)
+	}
+
+	stream := &fakeGetStream{fakeServerStream: fakeServerStream{ctx: testPeerContext()}}
+	if err := srv.GetStream(&common.GetRequest{Name: "blob", ClientNonce: "test-nonce"}, stream); err != nil {
+		t.Fatalf("GetStream failed: %v", err)
+	}
+
+	if len(stream.sent) < 2 {
+		t.Fatalf("expected a header frame and at least one chunk, got %d frames", len(stream.sent))
+	}
+	header := stream.sent[0]
+	if !header.Success {
+		t.Fatalf("header frame not successful: %s", header.Error)
+	}
+	if header.TtlSeconds <= 0 {
+		t.Errorf("header frame missing TTL: %d", header.TtlSeconds)
+	}
+	if len(header.Chunk) != 0 {
+		t.Error("header frame should carry no plaintext")
+	}
+
+	var got []byte
+	for _, frame := range stream.sent[1:] {
+		got = append(got, frame.Chunk...)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Errorf("streamed secret does not match: got %d bytes, want %d", len(got), len(plaintext))
+	}
+}
+
+func TestGetStreamOfStreamedStore(t *testing.T) {
+	srv := newTestServer(t)
+
+	plaintext := bytes.Repeat([]byte("chunked-both-ways-"), common.StreamChunkSize/18+10)
+
+	store := &fakeStoreStream{
+		fakeServerStream: fakeServerStream{ctx: testPeerContext()},
+		frames:           storeFrames("both", plaintext, common.StreamChunkSize),
+	}
+	if err := srv.StoreStream(store); err != nil || !store.resp.GetSuccess() {
+		t.Fatalf("StoreStream failed: %v / %+v", err, store.resp)
+	}
+
+	get := &fakeGetStream{fakeServerStream: fakeServerStream{ctx: testPeerContext()}}
+	if err := srv.GetStream(&common.GetRequest{Name: "both", ClientNonce: "test-nonce"}, get); err != nil {
+		t.Fatalf("GetStream failed: %v", err)
+	}
+
+	var got []byte
+	for _, frame := range get.sent[1:] {
+		got = append(got, frame.Chunk...)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Errorf("streamed secret does not match: got %d bytes, want %d", len(got), len(plaintext))
+	}
+}
+
+func TestStoreStreamEmptySecret(t *testing.T) {
+	srv := newTestServer(t)
+
+	stream := &fakeStoreStream{
+		fakeServerStream: fakeServerStream{ctx: testPeerContext()},
+		frames:           storeFrames("empty", nil, common.StreamChunkSize),
+	}
+	if err := srv.StoreStream(stream); err != nil || !stream.resp.GetSuccess() {
+		t.Fatalf("StoreStream failed: %v / %+v", err, stream.resp)
+	}
+
+	resp, err := srv.Get(testPeerContext(), &common.GetRequest{Name: "empty", ClientNonce: "test-nonce"})
+	if err != nil || !resp.Success {
+		t.Fatalf("Get failed: %v / %+v", err, resp)
+	}
+	if len(resp.Secret) != 0 {
+		t.Errorf("expected empty secret, got %d bytes", len(resp.Secret))
+	}
+}
+
+func TestStoreStreamEnforcesMaxSecretSize(t *testing.T) {
+	opts := *options.DefaultServer
+	opts.MaxSecretSize = 128
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+
+	stream := &fakeStoreStream{
+		fakeServerStream: fakeServerStream{ctx: testPeerContext()},
+		frames:           storeFrames("too-big", bytes.Repeat([]byte("x"), 256), 64),
+	}
+	if err := srv.StoreStream(stream); err != nil {
+		t.Fatalf("StoreStream returned a transport error: %v", err)
+	}
+	if stream.resp == nil || stream.resp.Success {
+		t.Fatalf("expected the oversized stream to be rejected, got %+v", stream.resp)
+	}
+}
+
+func TestStoreStreamRequiresInfoFrame(t *testing.T) {
+	srv := newTestServer(t)
+
+	stream := &fakeStoreStream{
+		fakeServerStream: fakeServerStream{ctx: testPeerContext()},
+		frames: []*common.StoreStreamRequest{{
+			Frame: &common.StoreStreamRequest_Chunk{Chunk: []byte("no parameters")},
+		}},
+	}
+	if err := srv.StoreStream(stream); err != nil {
+		t.Fatalf("StoreStream returned a transport error: %v", err)
+	}
+	if stream.resp == nil || stream.resp.Success {
+		t.Fatalf("expected a chunk-first stream to be rejected, got %+v", stream.resp)
+	}
+}
+
+func TestGetStreamMissingSecret(t *testing.T) {
+	srv := newTestServer(t)
+
+	stream := &fakeGetStream{fakeServerStream: fakeServerStream{ctx: testPeerContext()}}
+	if err := srv.GetStream(&common.GetRequest{Name: "nope", ClientNonce: "test-nonce"}, stream); err != nil {
+		t.Fatalf("GetStream returned a transport error: %v", err)
+	}
+	if len(stream.sent) != 1 {
+		t.Fatalf("expected only the failure frame, got %d frames", len(stream.sent))
+	}
+	if stream.sent[0].Success {
+		t.Error("expected the failure frame to report no success")
+	}
+	if stream.sent[0].ErrorCode != common.ErrorCode_ERROR_NOT_FOUND {
+		t.Errorf("unexpected error code: %v", stream.sent[0].ErrorCode)
+	}
+}
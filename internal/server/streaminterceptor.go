@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+
+	"github.com/chainguard-dev/clog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The streaming RPCs (StoreStream, GetStream) pass through this chain, the
+// stream counterpart of the unary interceptor chain registered in Run. Each
+// interceptor delegates to the same admission helper as its unary twin, so
+// the AllowedUIDs allowlist, the trusted digest gate and the load limits
+// cannot be sidestepped by arriving on a stream instead of a unary call.
+
+// loggerServerStream wraps a server stream to substitute the context its
+// handler sees, the stream equivalent of rewriting ctx in a unary interceptor.
+type loggerServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (l *loggerServerStream) Context() context.Context { return l.ctx }
+
+// recoveryStreamInterceptor converts panics in streaming handlers into
+// Internal errors, like recoveryInterceptor does for the unary ones, so one
+// bad stream cannot crash the daemon and burn every held secret.
+func (s *Server) recoveryStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			clog.FromContext(s.ctx).Errorf("Recovered from panic in stream handler: %v", r)
+			err = status.Errorf(codes.Internal, "internal server error")
+		}
+	}()
+
+	return handler(srv, ss)
+}
+
+// loggerStreamInterceptor injects the server's logger into the stream context.
+func (s *Server) loggerStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	//nolint:contextcheck // clog.WithLogger properly inherits from parent context
+	return handler(srv, &loggerServerStream{
+		ServerStream: ss,
+		ctx:          clog.WithLogger(ss.Context(), s.requestLogger()),
+	})
+}
+
+// uidStreamInterceptor enforces the AllowedUIDs allowlist on streams.
+func (s *Server) uidStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.admitPeerUID(ss.Context()); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// digestStreamInterceptor enforces the trusted digest list on streams.
+func (s *Server) digestStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.admitClientDigest(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// clientLimitStreamInterceptor enforces the MaxClients admission on streams.
+func (s *Server) clientLimitStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.admitClientSlot(ss.Context()); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// rateLimitStreamInterceptor counts each stream against the client's request
+// rate budget.
+func (s *Server) rateLimitStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.admitRequestRate(ss.Context()); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// concurrencyStreamInterceptor holds one in-flight request slot for the whole
+// life of the stream.
+func (s *Server) concurrencyStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	release, err := s.acquireRequestSlot()
+	if err != nil {
+		return err
+	}
+	defer release()
+	return handler(srv, ss)
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func TestUIDStreamInterceptorAllowlist(t *testing.T) {
+	opts := *options.DefaultServer
+	opts.AllowedUIDs = []uint32{1000, 1001}
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+
+	handler := func(srv any, ss grpc.ServerStream) error { return nil }
+	info := &grpc.StreamServerInfo{FullMethod: "/burnafter.BurnAfter/StoreStream"}
+
+	// An allowed UID passes through
+	stream := &fakeServerStream{ctx: uidContext(1001)}
+	if err := srv.uidStreamInterceptor(nil, stream, info, handler); err != nil {
+		t.Errorf("Expected allowed UID to pass: %v", err)
+	}
+
+	// Any other UID is rejected with PermissionDenied
+	stream = &fakeServerStream{ctx: uidContext(999)}
+	if err := srv.uidStreamInterceptor(nil, stream, info, handler); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for disallowed UID, got %v", err)
+	}
+
+	// A peer without credentials cannot be verified, so it is rejected too
+	stream = &fakeServerStream{ctx: context.Background()}
+	if err := srv.uidStreamInterceptor(nil, stream, info, handler); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied without peer credentials, got %v", err)
+	}
+}
+
+func TestDigestStreamInterceptorAllowlist(t *testing.T) {
+	opts := *options.DefaultServer
+	// Only a digest no real binary hashes to: the test binary is not attested
+	opts.TrustedClientDigests = []string{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+	}
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+
+	handler := func(srv any, ss grpc.ServerStream) error { return nil }
+	info := &grpc.StreamServerInfo{FullMethod: "/burnafter.BurnAfter/GetStream"}
+
+	stream := &fakeServerStream{ctx: testPeerContext()}
+	if err := srv.digestStreamInterceptor(nil, stream, info, handler); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for an unlisted binary, got %v", err)
+	}
+
+	// Listing the test binary's own digest lets its streams through
+	opts.TrustedClientDigests = append(opts.TrustedClientDigests, selfDigest(t))
+	if err := srv.digestStreamInterceptor(nil, stream, info, handler); err != nil {
+		t.Errorf("Expected a trusted binary to pass: %v", err)
+	}
+}
+
+func TestRecoveryStreamInterceptor(t *testing.T) {
+	srv := newTestServer(t)
+
+	info := &grpc.StreamServerInfo{FullMethod: "/burnafter.BurnAfter/StoreStream"}
+	stream := &fakeServerStream{ctx: testPeerContext()}
+
+	err := srv.recoveryStreamInterceptor(nil, stream, info, func(srv any, ss grpc.ServerStream) error {
+		panic("stream handler blew up")
+	})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("Expected a recovered panic to surface as Internal, got %v", err)
+	}
+}
+
+func TestConcurrencyStreamInterceptorRejectsExcess(t *testing.T) {
+	opts := *options.DefaultServer
+	opts.MaxConcurrentRequests = 1
+
+	srv, err := NewServer(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+
+	info := &grpc.StreamServerInfo{FullMethod: "/burnafter.BurnAfter/GetStream"}
+	stream := &fakeServerStream{ctx: testPeerContext()}
+
+	// While one stream holds the only slot, a second one is rejected
+	err = srv.concurrencyStreamInterceptor(nil, stream, info, func(any, grpc.ServerStream) error {
+		err := srv.concurrencyStreamInterceptor(nil, stream, info,
+			func(any, grpc.ServerStream) error { return nil })
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Errorf("Expected ResourceExhausted for the second stream, got %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("In-limit stream failed: %v", err)
+	}
+
+	// With the stream done, the slot frees up again
+	if err := srv.concurrencyStreamInterceptor(nil, stream, info,
+		func(srv any, ss grpc.ServerStream) error { return nil }); err != nil {
+		t.Errorf("Expected stream to pass after the slot freed: %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"google.golang.org/grpc/encoding"
+	_ "google.golang.org/grpc/encoding/proto" // registers the base proto codec
+	"google.golang.org/grpc/mem"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+// wipeCodec wraps the standard proto codec to zero the plaintext secret in a
+// GetResponse as soon as it has been serialized for the wire. The handler
+// cannot wipe it itself — gRPC marshals the response after the handler
+// returns — so this is the earliest point the cleartext buffer is no longer
+// needed. The serialized copy the transport sends is unavoidable; everything
+// else follows the same wipe discipline as the store path.
+type wipeCodec struct {
+	encoding.CodecV2
+}
+
+// newWipeCodec builds the codec over the registered proto codec. Name() is
+// inherited, so the wire format advertised to clients stays "proto".
+func newWipeCodec() encoding.CodecV2 {
+	return wipeCodec{CodecV2: encoding.GetCodecV2("proto")}
+}
+
+// Marshal serializes v and then wipes any plaintext secret it carried.
+func (c wipeCodec) Marshal(v any) (mem.BufferSlice, error) {
+	data, err := c.CodecV2.Marshal(v)
+	if resp, ok := v.(*common.GetResponse); ok && len(resp.Secret) > 0 {
+		common.ZeroBytes(resp.Secret)
+	}
+	return data, err
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"bytes"
+	"testing"
+
+	"google.golang.org/grpc/mem"
+
+	"github.com/carabiner-dev/burnafter/internal/common"
+)
+
+func TestWipeCodecZeroesSecretAfterMarshal(t *testing.T) {
+	codec := newWipeCodec()
+	secret := []byte("cleartext-payload")
+
+	resp := &common.GetResponse{
+		Success: true,
+		Secret:  append([]byte(nil), secret...),
+	}
+
+	data, err := codec.Marshal(resp)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	// The serialized form must still carry the secret...
+	var decoded common.GetResponse
+	if err := codec.Unmarshal(mem.BufferSlice{mem.SliceBuffer(data.Materialize())}, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !bytes.Equal(decoded.Secret, secret) {
+		t.Errorf("Expected the wire form to carry the secret, got %q", decoded.Secret)
+	}
+
+	// ...while the handler's buffer must be zeroed
+	for i, b := range resp.Secret {
+		if b != 0 {
+			t.Fatalf("Expected the response buffer to be wiped, byte %d is %#x", i, b)
+		}
+	}
+}
+
+func TestWipeCodecLeavesOtherMessagesAlone(t *testing.T) {
+	codec := newWipeCodec()
+
+	req := &common.StoreRequest{Name: "some-secret", Secret: []byte("payload")}
+	if _, err := codec.Marshal(req); err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !bytes.Equal(req.Secret, []byte("payload")) {
+		t.Errorf("Expected non-GetResponse messages to be untouched, got %q", req.Secret)
+	}
+}
@@ -0,0 +1,212 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func TestFallbackKDFIterationsRecordedInFile(t *testing.T) {
+	opts := *options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = testNonce
+	opts.FallbackKDFIterations = 1000 // deliberately low to keep the test fast
+
+	client := NewClient(&opts)
+	ctx := context.Background()
+
+	if err := client.Store(ctx, "kdf-count", "value", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	t.Cleanup(func() { client.deleteFallbackSecret("kdf-count") }) //nolint:errcheck
+
+	filePath, err := client.getFallbackFilePath("kdf-count")
+	if err != nil {
+		t.Fatalf("getFallbackFilePath failed: %v", err)
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("Reading fallback file: %v", err)
+	}
+
+	if data[0] != kdfFallbackFileVersion {
+		t.Errorf("Expected version %d, got %d", kdfFallbackFileVersion, data[0])
+	}
+	countOff := 1 + nameHashSize + gcmNonceSize + 8
+	if got := binary.BigEndian.Uint32(data[countOff : countOff+4]); got != 1000 {
+		t.Errorf("Expected iteration count 1000 in the header, got %d", got)
+	}
+
+	if got, err := client.Get(ctx, "kdf-count"); err != nil || got != "value" {
+		t.Errorf("Expected round-trip, got %q, %v", got, err)
+	}
+}
+
+func TestFallbackReadsAcrossIterationSettings(t *testing.T) {
+	writerOpts := *options.DefaultClient
+	writerOpts.NoServer = true
+	writerOpts.Nonce = testNonce
+	writerOpts.FallbackKDFIterations = 2048
+	writer := NewClient(&writerOpts)
+
+	readerOpts := *options.DefaultClient
+	readerOpts.NoServer = true
+	readerOpts.Nonce = testNonce
+	readerOpts.FallbackKDFIterations = 4096
+	reader := NewClient(&readerOpts)
+
+	ctx := context.Background()
+	if err := writer.Store(ctx, "cross-count", "portable", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	t.Cleanup(func() { writer.deleteFallbackSecret("cross-count") }) //nolint:errcheck
+
+	// The reader is configured with a different count, but the file records
+	// the one it was written with, so the key still derives correctly
+	if got, err := reader.Get(ctx, "cross-count"); err != nil || got != "portable" {
+		t.Errorf("Expected cross-setting read to succeed, got %q, %v", got, err)
+	}
+}
+
+func TestFallbackLegacyV2File(t *testing.T) {
+	opts := *options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = testNonce
+
+	client := NewClient(&opts)
+	ctx := context.Background()
+
+	secretName := "legacy-v2-secret"
+	secretValue := "pre-bump-value"
+
+	// Hand-craft a version 2 file the way a pre-bump client wrote it: key
+	// derived at the historical count, header (without an iteration field)
+	// bound as associated data
+	key, err := client.deriveKeyParams(secretName, kdfParams{iterations: legacyPBKDF2Iterations})
+	if err != nil {
+		t.Fatalf("deriveKeyParams failed: %v", err)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		t.Fatalf("newGCM failed: %v", err)
+	}
+	nonce := make([]byte, gcmNonceSize)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		t.Fatalf("generating nonce: %v", err)
+	}
+	nameHash := sha256.Sum256([]byte(secretName))
+	expiry := time.Now().Add(time.Hour).Unix()
+	header := encodeFallbackHeader(fallbackFileVersion, nameHash[:], nonce, expiry, 0, kdfParams{}, 0)
+	contents := append(header, gcm.Seal(nil, nonce, []byte(secretValue), header)...)
+
+	filePath, err := client.getFallbackFilePath(secretName)
+	if err != nil {
+		t.Fatalf("getFallbackFilePath failed: %v", err)
+	}
+	if err := client.writeFallbackFile(filePath, contents); err != nil {
+		t.Fatalf("writeFallbackFile failed: %v", err)
+	}
+	t.Cleanup(func() { os.Remove(filePath) }) //nolint:errcheck
+
+	// Version 2 files must remain readable despite the raised default
+	if got, err := client.Get(ctx, secretName); err != nil || got != secretValue {
+		t.Errorf("Expected legacy v2 file to read back %q, got %q, %v", secretValue, got, err)
+	}
+}
+
+func TestFallbackArgon2idRoundTrip(t *testing.T) {
+	opts := *options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = testNonce
+	opts.FallbackKDF = "argon2id"
+
+	client := NewClient(&opts)
+	ctx := context.Background()
+
+	if err := client.Store(ctx, "argon2-secret", "memory-hard", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	t.Cleanup(func() { client.deleteFallbackSecret("argon2-secret") }) //nolint:errcheck
+
+	filePath, err := client.getFallbackFilePath("argon2-secret")
+	if err != nil {
+		t.Fatalf("getFallbackFilePath failed: %v", err)
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("Reading fallback file: %v", err)
+	}
+
+	if data[0] != argon2idFallbackFileVersion {
+		t.Errorf("Expected version %d, got %d", argon2idFallbackFileVersion, data[0])
+	}
+	// The header records the Argon2id cost parameters after the expiry
+	paramOff := 1 + nameHashSize + gcmNonceSize + 8
+	if m := binary.BigEndian.Uint32(data[paramOff : paramOff+4]); m != defaultArgon2Memory {
+		t.Errorf("Expected memory cost %d in the header, got %d", defaultArgon2Memory, m)
+	}
+	if p := data[paramOff+8]; p != defaultArgon2Threads {
+		t.Errorf("Expected %d lanes in the header, got %d", defaultArgon2Threads, p)
+	}
+
+	if got, err := client.Get(ctx, "argon2-secret"); err != nil || got != "memory-hard" {
+		t.Errorf("Expected round-trip, got %q, %v", got, err)
+	}
+}
+
+func TestFallbackCrossKDFReads(t *testing.T) {
+	pbkdf2Opts := *options.DefaultClient
+	pbkdf2Opts.NoServer = true
+	pbkdf2Opts.Nonce = testNonce
+	pbkdf2Opts.FallbackKDFIterations = 1000
+	pbkdf2Client := NewClient(&pbkdf2Opts)
+
+	argon2Opts := *options.DefaultClient
+	argon2Opts.NoServer = true
+	argon2Opts.Nonce = testNonce
+	argon2Opts.FallbackKDF = "argon2id"
+	argon2Client := NewClient(&argon2Opts)
+
+	ctx := context.Background()
+
+	// Each client writes under its own KDF into the same directory
+	if err := pbkdf2Client.Store(ctx, "from-pbkdf2", "classic", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store with PBKDF2 failed: %v", err)
+	}
+	t.Cleanup(func() { pbkdf2Client.deleteFallbackSecret("from-pbkdf2") }) //nolint:errcheck
+	if err := argon2Client.Store(ctx, "from-argon2", "modern", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store with Argon2id failed: %v", err)
+	}
+	t.Cleanup(func() { argon2Client.deleteFallbackSecret("from-argon2") }) //nolint:errcheck
+
+	// Each reads the other's file: the header says which KDF to run, so the
+	// client's own configuration only governs what it writes
+	if got, err := argon2Client.Get(ctx, "from-pbkdf2"); err != nil || got != "classic" {
+		t.Errorf("Expected Argon2id client to read the PBKDF2 file, got %q, %v", got, err)
+	}
+	if got, err := pbkdf2Client.Get(ctx, "from-argon2"); err != nil || got != "modern" {
+		t.Errorf("Expected PBKDF2 client to read the Argon2id file, got %q, %v", got, err)
+	}
+}
+
+func TestFallbackRejectsUnknownKDF(t *testing.T) {
+	opts := *options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = testNonce
+	opts.FallbackKDF = "scrypt"
+
+	client := NewClient(&opts)
+	if err := client.Store(context.Background(), "typo", "value", options.WithTTL(300)); err == nil {
+		t.Errorf("Expected an error for an unrecognized FallbackKDF")
+	}
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/carabiner-dev/burnafter/internal/common"
+)
+
+// SecretInfo describes one secret in a server inventory: its name, lifecycle
+// state and the storage backend holding its payload. The backend is uniform
+// across a server today but reported per secret, so the shape survives a
+// future where backends differ.
+type SecretInfo struct {
+	Name    string
+	State   string
+	Backend string
+}
+
+// List inventories the secrets the server is guarding, without reading any
+// payload or resetting inactivity timers. It is server-mode only: the
+// fallback files do not record their secret names (only hashes of them) and
+// the in-memory backends keep no listable index, so there is no inventory to
+// report in those modes.
+func (c *Client) List(ctx context.Context) ([]SecretInfo, error) {
+	if c.useMemory() || c.useFallback() {
+		return nil, fmt.Errorf("listing secrets requires server mode")
+	}
+
+	if c.client == nil {
+		return nil, ErrNotConnected
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.List(ctx, &pb.ListRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("listing secrets: %w", err)
+	}
+
+	if !resp.Success {
+		return nil, fmt.Errorf("server error: %s", resp.Error)
+	}
+
+	infos := make([]SecretInfo, 0, len(resp.Secrets))
+	for _, entry := range resp.Secrets {
+		infos = append(infos, SecretInfo{
+			Name:    entry.Name,
+			State:   entry.State,
+			Backend: entry.Backend,
+		})
+	}
+	return infos, nil
+}
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	pb "github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+// Locate reports where a secret is currently stored, as a diagnostic aid when
+// reasoning about the storage-tier fallbacks. It returns "keyring" or "memory"
+// for the ephemeral backends, the resolved file path in fallback mode, or the
+// server's backend identifier in server mode. The secret payload is never
+// read or decrypted.
+func (c *Client) Locate(ctx context.Context, name string) (string, error) {
+	// In-memory mode: report which ephemeral backend holds the secret
+	if c.useMemory() {
+		s, ok, err := c.mem.get(ctx, name)
+		if err != nil || !ok {
+			return "", fmt.Errorf("secret not found")
+		}
+		if c.now().Unix() > s.expiry {
+			return "", fmt.Errorf("secret expired")
+		}
+		if _, isKeyring := c.mem.(*keyringStore); isKeyring {
+			return "keyring", nil
+		}
+		return "memory", nil
+	}
+
+	// Fallback mode: the location is the encrypted file itself
+	if c.useFallback() {
+		filePath, err := c.getFallbackFilePath(name)
+		if err != nil {
+			return "", err
+		}
+		if _, err := os.Stat(filePath); err != nil {
+			return "", fmt.Errorf("secret not found")
+		}
+		file, err := c.readFallbackFile(filePath)
+		if err != nil {
+			return "", fmt.Errorf("reading fallback file: %w", err)
+		}
+		if c.now().Unix() > file.expiry {
+			return "", fmt.Errorf("secret expired")
+		}
+		return filePath, nil
+	}
+
+	// Server mode
+	if c.client == nil {
+		return "", ErrNotConnected
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.Locate(ctx, &pb.LocateRequest{Name: name})
+	if err != nil {
+		return "", fmt.Errorf("locating secret: %w", err)
+	}
+
+	if !resp.Success {
+		// An expired-but-unreaped secret reports its state so users can tell
+		// "gone" apart from "awaiting the next cleanup sweep"
+		if resp.State != "" && resp.State != secrets.StateLive {
+			return "", fmt.Errorf("server error: %s (state: %s)", resp.Error, resp.State)
+		}
+		return "", fmt.Errorf("server error: %s", resp.Error)
+	}
+
+	return resp.Location, nil
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func TestLocateFallback(t *testing.T) {
+	ctx := context.Background()
+	client := newFallbackTestClient(t, "test-nonce-locate-fallback")
+
+	secretName := "locate-fallback-test"
+	if err := client.Store(ctx, secretName, "value", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	defer client.Delete(ctx, secretName) //nolint:errcheck
+
+	location, err := client.Locate(ctx, secretName)
+	if err != nil {
+		t.Fatalf("Locate failed: %v", err)
+	}
+
+	// In fallback mode the location is the encrypted file itself
+	if _, err := os.Stat(location); err != nil {
+		t.Errorf("Expected location %q to be an existing file: %v", location, err)
+	}
+
+	// An unknown secret has no location
+	if _, err := client.Locate(ctx, "no-such-secret"); err == nil {
+		t.Errorf("Expected Locate to fail for an unknown secret")
+	}
+}
+
+func TestLocateInMemory(t *testing.T) {
+	ctx := context.Background()
+	c := newInMemoryClient()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+
+	if err := c.Store(ctx, "locate-mem", "v", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	defer c.Delete(ctx, "locate-mem") //nolint:errcheck
+
+	location, err := c.Locate(ctx, "locate-mem")
+	if err != nil {
+		t.Fatalf("Locate: %v", err)
+	}
+	if location != "keyring" && location != "memory" {
+		t.Errorf("Expected keyring or memory, got %q", location)
+	}
+
+	if _, err := c.Locate(ctx, "no-such-secret"); err == nil {
+		t.Errorf("Expected Locate to fail for an unknown secret")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	pb "github.com/carabiner-dev/burnafter/internal/common"
 	"github.com/carabiner-dev/burnafter/secrets"
 )
 
@@ -30,6 +31,10 @@ type secretStore interface {
 	put(ctx context.Context, name string, s memSecret) error
 	get(ctx context.Context, name string) (memSecret, bool, error)
 	del(ctx context.Context, name string)
+	// wipe zeroes whatever secret material the backend holds in process memory
+	// and drops the references. Backends whose bytes live outside the process
+	// (the kernel keyring) have nothing to scrub.
+	wipe()
 }
 
 // heapStore is an in-process, mutex-guarded map of encrypted secrets. It is the
@@ -62,6 +67,16 @@ func (h *heapStore) del(_ context.Context, name string) {
 	h.mu.Unlock()
 }
 
+func (h *heapStore) wipe() {
+	h.mu.Lock()
+	for _, s := range h.m {
+		pb.ZeroBytes(s.nonce)
+		pb.ZeroBytes(s.ciphertext)
+	}
+	h.m = make(map[string]memSecret)
+	h.mu.Unlock()
+}
+
 // keyringStore stores the (already encrypted) secret in an OS secure store via a
 // secrets.Storage backend — on Linux this is the kernel keyring, so the bytes
 // live in kernel memory rather than the process heap.
@@ -95,6 +110,11 @@ func (k *keyringStore) del(ctx context.Context, name string) {
 	_ = k.storage.Delete(ctx, name) //nolint:errcheck // best-effort
 }
 
+// wipe is a no-op for the keyring store: the encrypted bytes live in kernel
+// memory, not the process heap, and the session keyring may outlive (and be
+// shared beyond) this client.
+func (k *keyringStore) wipe() {}
+
 // marshalMemSecret encodes [nonce | expiry | ciphertext] as opaque bytes for
 // storage backends that hold a single blob per secret.
 func marshalMemSecret(s memSecret) []byte {
@@ -139,21 +159,47 @@ func (c *Client) storeInMemory(ctx context.Context, name string, secret []byte,
 // getFromMemory reads, expiry-checks, and decrypts a secret from the ephemeral
 // backend.
 func (c *Client) getFromMemory(ctx context.Context, name string) ([]byte, error) {
+	secret, _, err := c.getFromMemoryWithExpiry(ctx, name)
+	return secret, err
+}
+
+// getFromMemoryWithExpiry is getFromMemory, additionally reporting the
+// secret's expiry timestamp for callers interested in lifecycle metadata.
+func (c *Client) getFromMemoryWithExpiry(ctx context.Context, name string) ([]byte, int64, error) {
 	s, ok, err := c.mem.get(ctx, name)
 	if err != nil {
-		return nil, err
+		// A retrieval failure counts as a miss (the kernel keyring reports a
+		// missing key as an error), same as deleteFromMemoryStrict. The
+		// backend detail rides along for diagnostics.
+		return nil, 0, fmt.Errorf("%w (%v)", ErrSecretNotFound, err)
 	}
 	if !ok {
-		return nil, fmt.Errorf("secret not found")
+		return nil, 0, ErrSecretNotFound
 	}
-	if time.Now().Unix() > s.expiry {
+	if c.now().Unix() > s.expiry {
 		c.mem.del(ctx, name)
-		return nil, fmt.Errorf("secret expired")
+		return nil, 0, ErrSecretExpired
 	}
-	return c.open(name, s.nonce, s.ciphertext)
+	secret, err := c.open(name, s.nonce, s.ciphertext, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	return secret, s.expiry, nil
 }
 
 // deleteFromMemory removes a secret from the ephemeral backend.
 func (c *Client) deleteFromMemory(ctx context.Context, name string) {
 	c.mem.del(ctx, name)
 }
+
+// deleteFromMemoryStrict removes a secret from the ephemeral backend, reporting
+// ErrSecretNotFound when it was not there. A retrieval failure counts as a miss,
+// matching how reads treat backend errors as cache misses.
+func (c *Client) deleteFromMemoryStrict(ctx context.Context, name string) error {
+	_, ok, err := c.mem.get(ctx, name)
+	if err != nil || !ok {
+		return ErrSecretNotFound
+	}
+	c.mem.del(ctx, name)
+	return nil
+}
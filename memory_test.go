@@ -43,6 +43,8 @@ func (f *fakeStorage) Delete(_ context.Context, name string) error {
 	return nil
 }
 
+func (f *fakeStorage) Backend() string { return "fake" }
+
 func newInMemoryClient() *Client {
 	opts := *options.DefaultClient
 	opts.InMemory = true
@@ -89,10 +91,11 @@ func TestInMemory_Expiry(t *testing.T) {
 	ctx := context.Background()
 	c := newInMemoryClient()
 
-	// Store with an absolute expiry in the past: a get must report it gone.
-	past := time.Now().Add(-time.Minute).Unix()
-	if err := c.Store(ctx, "k", "v", options.WithAbsoluteExpiration(past)); err != nil {
-		t.Fatalf("Store: %v", err)
+	// A secret whose absolute expiry is in the past must be reported gone on
+	// read. Store rejects past deadlines up front, so plant it directly.
+	past := time.Now().Add(-time.Minute)
+	if err := c.storeInMemory(ctx, "k", []byte("v"), past); err != nil {
+		t.Fatalf("storeInMemory: %v", err)
 	}
 	if _, err := c.Get(ctx, "k"); err == nil {
 		t.Fatalf("expected expired secret to be reported as not found")
@@ -139,3 +142,44 @@ func TestInMemory_KeyringBackend(t *testing.T) {
 		t.Fatalf("expected miss after delete")
 	}
 }
+
+// TestCloseWipesInMemoryStore asserts that closing a client zeroes the heap
+// store's encrypted buffers and drops them, scrubbing the client-side secret
+// footprint.
+func TestCloseWipesInMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	c := newInMemoryClient() // Connect is skipped, so the heap backend stays
+
+	if err := c.Store(ctx, "scrubbed", "super-secret-value", options.WithTTL(3600)); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	hs, ok := c.mem.(*heapStore)
+	if !ok {
+		t.Fatalf("expected the heap backend, got %T", c.mem)
+	}
+	hs.mu.RLock()
+	held := hs.m["scrubbed"]
+	hs.mu.RUnlock()
+	if len(held.ciphertext) == 0 {
+		t.Fatalf("expected a stored ciphertext to scrub")
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	for _, buf := range [][]byte{held.nonce, held.ciphertext} {
+		for _, b := range buf {
+			if b != 0 {
+				t.Fatalf("expected the heap store's buffers to be zeroed on Close")
+			}
+		}
+	}
+	hs.mu.RLock()
+	remaining := len(hs.m)
+	hs.mu.RUnlock()
+	if remaining != 0 {
+		t.Errorf("expected the heap store to be emptied on Close, %d entries left", remaining)
+	}
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	pb "github.com/carabiner-dev/burnafter/internal/common"
+)
+
+// MigrateFallback re-encrypts fallback secrets written by a previous version
+// of the running binary. Fallback keys (and file paths) derive from the binary
+// hash, so upgrading the application orphans any secrets stored before the
+// upgrade. Given the previous binary's hash, this helper re-derives the old
+// keys, decrypts the orphaned files, and rewrites them under the current
+// binary's hash.
+//
+// The secret names must be supplied because the key derivation (and the
+// per-secret salt) is bound to the name — the fallback files only record a
+// truncated hash of it, so orphaned files cannot be decrypted by scanning the
+// directory alone. Names whose old file does not exist are skipped; expired
+// files are removed without migrating.
+func (c *Client) MigrateFallback(ctx context.Context, oldBinaryHash string, names ...string) error {
+	if len(oldBinaryHash) < 16 {
+		return fmt.Errorf("invalid binary hash: %q", oldBinaryHash)
+	}
+
+	newBinaryHash, err := pb.GetCurrentBinaryHash()
+	if err != nil {
+		return fmt.Errorf("failed to get binary hash: %w", err)
+	}
+
+	if oldBinaryHash == newBinaryHash {
+		return fmt.Errorf("old binary hash matches the current binary, nothing to migrate")
+	}
+
+	now := c.now().Unix()
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := c.migrateFallbackSecret(ctx, oldBinaryHash, name, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateFallbackSecret moves one named secret from the old binary hash to the
+// current one. A per-name function so the old key and the decrypted plaintext
+// are deferred-wiped after each secret, not accumulated until the whole
+// migration finishes.
+func (c *Client) migrateFallbackSecret(ctx context.Context, oldBinaryHash, name string, now int64) error {
+	oldPath := c.fallbackFilePathForHash(oldBinaryHash, name)
+	file, err := c.readFallbackFile(oldPath)
+	if err != nil {
+		// No file under the old hash, nothing to migrate for this name
+		if _, statErr := os.Stat(oldPath); os.IsNotExist(statErr) {
+			return nil
+		}
+		return fmt.Errorf("reading old fallback file for %q: %w", name, err)
+	}
+
+	// Expired secrets are reaped, not migrated
+	if now > file.expiry {
+		os.Remove(oldPath) //nolint:errcheck,gosec
+		return nil
+	}
+
+	// Decrypt with the key derived from the old binary hash, with the KDF
+	// parameters the old file was written under
+	oldKey := c.deriveKeyForHash(oldBinaryHash, name, file.kdfParams())
+	defer pb.ZeroBytes(oldKey)
+	plaintext, err := c.openWithKey(oldKey, file.nonce, file.ciphertext, file.aad())
+	if err != nil {
+		return fmt.Errorf("decrypting %q under the old binary hash: %w", name, err)
+	}
+	defer pb.ZeroBytes(plaintext)
+
+	// Re-encrypt under the current binary hash, preserving the expiry and
+	// any not-before seal
+	if err := c.encryptSecretNotBefore(ctx, name, plaintext, time.Unix(file.expiry, 0), file.notBefore, file.remainingReads); err != nil {
+		return fmt.Errorf("re-encrypting %q: %w", name, err)
+	}
+
+	// Remove the orphaned file
+	os.Remove(oldPath) //nolint:errcheck,gosec
+	return nil
+}
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// simulated hash of a previous binary version
+const oldTestBinaryHash = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+// writeFallbackForHash writes a fallback file as if it had been created by a
+// binary with the given hash.
+func writeFallbackForHash(t *testing.T, c *Client, binaryHash, name string, value []byte, expiry time.Time) string {
+	t.Helper()
+
+	key := c.deriveKeyForHash(binaryHash, name, c.kdfParams())
+	contents, err := c.sealFallbackFile(key, name, value, expiry)
+	if err != nil {
+		t.Fatalf("sealFallbackFile failed: %v", err)
+	}
+
+	path := c.fallbackFilePathForHash(binaryHash, name)
+	if err := c.writeFallbackFile(path, contents); err != nil {
+		t.Fatalf("writeFallbackFile failed: %v", err)
+	}
+	return path
+}
+
+func TestMigrateFallback(t *testing.T) {
+	opts := options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = "test-nonce-migrate"
+
+	client := NewClient(opts)
+	ctx := context.Background()
+
+	secretName := "migrate-test"
+	secretValue := []byte("pre-upgrade-value")
+	expiry := time.Now().Add(1 * time.Hour)
+
+	// Simulate a file left behind by the previous binary
+	oldPath := writeFallbackForHash(t, client, oldTestBinaryHash, secretName, secretValue, expiry)
+	defer os.Remove(oldPath) //nolint:errcheck
+
+	if err := client.MigrateFallback(ctx, oldTestBinaryHash, secretName); err != nil {
+		t.Fatalf("MigrateFallback failed: %v", err)
+	}
+
+	// The secret should now be readable under the current binary hash
+	retrieved, err := client.Get(ctx, secretName)
+	if err != nil {
+		t.Fatalf("Get after migration failed: %v", err)
+	}
+	if retrieved != string(secretValue) {
+		t.Errorf("Expected %q, got %q", secretValue, retrieved)
+	}
+
+	// The orphaned file should be gone
+	if _, err := os.Stat(oldPath); !os.IsNotExist(err) {
+		t.Errorf("Expected old fallback file to be removed")
+	}
+
+	// Cleanup
+	defer client.deleteFallbackSecret(secretName) //nolint:errcheck
+}
+
+func TestMigrateFallbackExpired(t *testing.T) {
+	opts := options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = "test-nonce-migrate-expired"
+
+	client := NewClient(opts)
+	ctx := context.Background()
+
+	secretName := "migrate-expired"
+
+	// Expired file under the old hash: should be reaped, not migrated
+	oldPath := writeFallbackForHash(
+		t, client, oldTestBinaryHash, secretName, []byte("stale"), time.Now().Add(-1*time.Hour),
+	)
+	defer os.Remove(oldPath) //nolint:errcheck
+
+	if err := client.MigrateFallback(ctx, oldTestBinaryHash, secretName); err != nil {
+		t.Fatalf("MigrateFallback failed: %v", err)
+	}
+
+	if _, err := os.Stat(oldPath); !os.IsNotExist(err) {
+		t.Errorf("Expected expired old file to be removed")
+	}
+
+	// No migrated file should exist
+	if _, err := client.Get(ctx, secretName); err == nil {
+		t.Errorf("Expected no secret after migrating an expired file")
+	}
+}
+
+func TestMigrateFallbackMissingFile(t *testing.T) {
+	opts := options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = "test-nonce-migrate-missing"
+
+	client := NewClient(opts)
+
+	// Names with no file under the old hash are skipped, not an error
+	if err := client.MigrateFallback(context.Background(), oldTestBinaryHash, "never-stored"); err != nil {
+		t.Errorf("Expected missing files to be skipped, got %v", err)
+	}
+}
+
+func TestMigrateFallbackInvalidHash(t *testing.T) {
+	opts := options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = "test-nonce-migrate-invalid"
+
+	client := NewClient(opts)
+
+	if err := client.MigrateFallback(context.Background(), "short", "anything"); err == nil {
+		t.Errorf("Expected error for invalid binary hash")
+	}
+}
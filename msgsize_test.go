@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func TestLargeSecretNotBlockedByTransport(t *testing.T) {
+	ctx := context.Background()
+
+	// Raise the secret size limit past gRPC's 4MB default: the transport
+	// limits must follow, so a secret near MaxSecretSize still goes through.
+	opts := *options.DefaultClient
+	opts.SocketPath = filepath.Join(t.TempDir(), "msgsize.sock")
+	opts.Nonce = "test-nonce-msgsize"
+	opts.NoFallbackMode = true
+	opts.MaxSecretSize = 6 * 1024 * 1024
+
+	launcher := &inProcessLauncher{}
+	client := NewClient(&opts, WithServerLauncher(launcher.launch))
+	defer launcher.kill()
+
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	secret := strings.Repeat("x", 5*1024*1024)
+	if err := client.Store(ctx, "large", secret, options.WithTTL(300)); err != nil {
+		t.Fatalf("Store of a near-limit secret failed: %v", err)
+	}
+
+	got, err := client.Get(ctx, "large")
+	if err != nil {
+		t.Fatalf("Get of a near-limit secret failed: %v", err)
+	}
+	if got != secret {
+		t.Errorf("Round-tripped secret does not match: got %d bytes, want %d", len(got), len(secret))
+	}
+}
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	pb "github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// newNamespacedTestClient builds a fallback-mode client in the given
+// namespace, sharing one nonce across namespaces so only the namespace
+// separates them.
+func newNamespacedTestClient(t *testing.T, namespace string) *Client {
+	t.Helper()
+
+	opts := *options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = "test-nonce-namespace"
+	opts.Namespace = namespace
+
+	client := NewClient(&opts)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	return client
+}
+
+func TestNamespaceIsolatesFallbackSecrets(t *testing.T) {
+	ctx := context.Background()
+	clientA := newNamespacedTestClient(t, "lib-a")
+	clientB := newNamespacedTestClient(t, "lib-b")
+	clientPlain := newNamespacedTestClient(t, "")
+
+	secretName := "shared-secret-name"
+
+	// The same name maps to a different file in each namespace
+	pathA, errA := clientA.getFallbackFilePath(secretName)
+	pathB, errB := clientB.getFallbackFilePath(secretName)
+	pathPlain, errPlain := clientPlain.getFallbackFilePath(secretName)
+	if errA != nil || errB != nil || errPlain != nil {
+		t.Fatalf("getFallbackFilePath failed: %v %v %v", errA, errB, errPlain)
+	}
+	if pathA == pathB || pathA == pathPlain || pathB == pathPlain {
+		t.Fatalf("Expected distinct paths per namespace, got %q %q %q", pathA, pathB, pathPlain)
+	}
+
+	if err := clientA.Store(ctx, secretName, "value-a", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store in namespace lib-a failed: %v", err)
+	}
+	defer os.Remove(pathA) //nolint:errcheck
+	if err := clientB.Store(ctx, secretName, "value-b", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store in namespace lib-b failed: %v", err)
+	}
+	defer os.Remove(pathB) //nolint:errcheck
+
+	// Each namespace reads its own value back
+	if got, err := clientA.Get(ctx, secretName); err != nil || got != "value-a" {
+		t.Errorf("Expected value-a in namespace lib-a, got %q (%v)", got, err)
+	}
+	if got, err := clientB.Get(ctx, secretName); err != nil || got != "value-b" {
+		t.Errorf("Expected value-b in namespace lib-b, got %q (%v)", got, err)
+	}
+
+	// The un-namespaced client sees neither
+	if _, err := clientPlain.Get(ctx, secretName); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("Expected ErrSecretNotFound outside the namespaces, got: %v", err)
+	}
+
+	// Deleting in one namespace leaves the other's secret intact
+	if err := clientB.Delete(ctx, secretName); err != nil {
+		t.Fatalf("Delete in namespace lib-b failed: %v", err)
+	}
+	if got, err := clientA.Get(ctx, secretName); err != nil || got != "value-a" {
+		t.Errorf("Expected namespace lib-a to survive lib-b's delete, got %q (%v)", got, err)
+	}
+}
+
+func TestNamespaceSocketPath(t *testing.T) {
+	hash, err := pb.GetCurrentBinaryHash()
+	if err != nil {
+		t.Fatalf("GetCurrentBinaryHash failed: %v", err)
+	}
+
+	// The empty namespace keeps the historical path byte-for-byte
+	if got, want := generateSocketPath("", ""), fmt.Sprintf("/tmp/burnafter-%s.sock", hash[:16]); got != want {
+		t.Errorf("Expected the legacy path %q for the empty namespace, got %q", want, got)
+	}
+
+	// A namespace yields its own stable endpoint
+	nsPath := generateSocketPath("", "lib-a")
+	if nsPath == generateSocketPath("", "") || nsPath == generateSocketPath("", "lib-b") {
+		t.Errorf("Expected a distinct socket path per namespace, got %q", nsPath)
+	}
+	if nsPath != generateSocketPath("", "lib-a") {
+		t.Errorf("Expected the namespaced socket path to be deterministic")
+	}
+}
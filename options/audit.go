@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package options
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// AuditEvent describes one secret operation for the audit trail. It carries
+// identifying metadata only — the secret value never appears in an event.
+type AuditEvent struct {
+	// Operation is the lifecycle step being audited: "store", "get",
+	// "delete" or "expire" (the cleanup sweep removing a dead secret).
+	Operation string `json:"operation"`
+	// SecretName is the name of the secret the operation targeted. For
+	// aliases and handles this is the resolved target, once known.
+	SecretName string `json:"secret_name"`
+	// PeerPID and PeerUID identify the calling process as reported by the
+	// transport. Both are zero for events without a caller, such as the
+	// expiry sweep.
+	PeerPID int32  `json:"peer_pid"`
+	PeerUID uint32 `json:"peer_uid"`
+	// BinaryHash is the caller's verified binary fingerprint (or its
+	// stand-in under a shared key or a relaxed peer verification mode).
+	// Empty when the operation failed before the caller was verified.
+	BinaryHash string `json:"binary_hash,omitempty"`
+	// Timestamp is when the operation completed, per the server's clock.
+	Timestamp time.Time `json:"timestamp"`
+	// Success reports the outcome; on failure Error carries the same
+	// message the client received.
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// AuditSink receives one event per audited secret operation. Record is called
+// synchronously from the request path, so slow sinks should buffer or hand
+// off internally; it must be safe for concurrent use.
+type AuditSink interface {
+	Record(ctx context.Context, event AuditEvent)
+}
+
+// JSONAuditSink is the default AuditSink: it writes each event as one JSON
+// line to a writer. It backs the AuditLogPath option.
+type JSONAuditSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJSONAuditSink returns a sink writing JSON-line events to w.
+func NewJSONAuditSink(w io.Writer) *JSONAuditSink {
+	return &JSONAuditSink{w: w}
+}
+
+// Record writes the event as a single JSON line. Write errors are swallowed:
+// the audit trail must not be able to fail secret operations.
+func (s *JSONAuditSink) Record(_ context.Context, event AuditEvent) {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, _ = s.w.Write(append(line, '\n')) //nolint:errcheck
+}
@@ -3,11 +3,30 @@
 
 package options
 
-import "time"
+import (
+	"context"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/secrets"
+)
 
 // Common options for client and server options
 type Common struct {
-	SocketPath        string        `json:"socket_path"`
+	SocketPath string `json:"socket_path"`
+	// SocketDir is the base directory for the generated socket path and for
+	// fallback secret files. On multi-user hosts /tmp is world-traversable;
+	// pointing this at a per-user 0700 directory such as $XDG_RUNTIME_DIR
+	// keeps both out of shared space. The directory must exist and be owned
+	// by the current user. Empty (the default) keeps the historical /tmp
+	// locations.
+	SocketDir string `json:"socket_dir"`
+	// Namespace partitions everything a client touches: the generated socket
+	// (or pipe) name, fallback file paths and the derived fallback keys. Two
+	// unrelated libraries embedded in the same binary can each set their own
+	// namespace to get their own server instance and isolated secrets, even
+	// when they use the same secret names. Empty (the default) keeps the
+	// historical un-namespaced paths and keys byte-for-byte.
+	Namespace         string        `json:"namespace"`
 	DefaultTTL        time.Duration `json:"default_ttl"`
 	InactivityTimeout time.Duration `json:"inactivity_timeout"`
 	Debug             bool          `json:"debug"`
@@ -15,11 +34,204 @@ type Common struct {
 	EnvVarDebug       string        `json:"envar_debug"`
 	MaxSecrets        int           `json:"max_secrets"`     // Maximum number of secrets that can be stored
 	MaxSecretSize     int64         `json:"max_secret_size"` // Maximum size of a single secret in bytes
+	// RequireKeyring makes the server fail to start instead of degrading to
+	// in-memory storage when the kernel keyring is unavailable. For deployments
+	// where secrets must be kernel-protected or not stored at all.
+	RequireKeyring bool `json:"require_keyring"`
+	// MaxConcurrentRequests caps the number of in-flight RPCs the server will
+	// process; excess requests are rejected instead of queueing without bound.
+	// Zero or negative means no limit.
+	MaxConcurrentRequests int `json:"max_concurrent_requests"`
+	// LogFormat selects the server's log output format: "text" (default) or
+	// "json" for machine-parseable log lines.
+	LogFormat string `json:"log_format"`
+	// AuditLogPath, when set, makes the server append one JSON line per
+	// secret operation (store, get, delete, expiry sweep) to this file:
+	// operation, secret name, caller credentials and outcome — never the
+	// secret value. It lives in the common options so a client spawning the
+	// embedded server carries it across the JSON handoff, which is the way
+	// to get an audit trail out of the separate server process. Empty (the
+	// default) disables the trail.
+	AuditLogPath string `json:"audit_log_path"`
+	// SocketGroup, when set to a non-zero GID, makes the server chown the
+	// socket file to that group and widen its mode to 0660, so members of
+	// the group (e.g. a shared deploy group) can connect to one daemon. The
+	// per-secret binary-hash gate still applies to every caller. Zero (the
+	// default) keeps the socket owner-only (0600).
+	SocketGroup int `json:"socket_group"`
+	// StrictSocketDir makes both the server (before listening) and the client
+	// (before dialing) refuse a socket whose directory is world-writable,
+	// since such a directory lets any local user plant an impostor socket.
+	// Off by default because the default socket home is /tmp; deployments
+	// enabling this should point SocketPath into a private directory such as
+	// $XDG_RUNTIME_DIR.
+	StrictSocketDir bool `json:"strict_socket_dir"`
+	// GracePeriod softens the hard edge of expiry: for this long after a
+	// secret expires (by inactivity or absolute deadline) a Get still
+	// succeeds, with a warning logged. A read within the window renews an
+	// inactivity-expired secret; an absolute-expired one is served once more
+	// and then deleted. Zero (the default) keeps immediate hard expiry.
+	GracePeriod time.Duration `json:"grace_period"`
+	// MaxAbsoluteLifetime caps how far a secret's absolute expiration deadline
+	// can be pushed by the ExtendDeadline RPC, measured from the secret's
+	// original store time. Extensions beyond the ceiling are rejected, so
+	// long-running jobs can keep a secret alive within bounds while operators
+	// keep the guarantee that it still dies eventually. Zero (the default)
+	// leaves extensions uncapped.
+	MaxAbsoluteLifetime time.Duration `json:"max_absolute_lifetime"`
+	// RequestsPerSecondPerClient throttles each connecting client process to
+	// this many requests per second (with an equal burst allowance), so one
+	// misbehaving client cannot monopolize the daemon. Zero (the default)
+	// means unlimited.
+	RequestsPerSecondPerClient int `json:"requests_per_second_per_client"`
+	// MaxClients caps how many distinct client processes a shared daemon
+	// serves at once, as coarse admission control. Clients it has served
+	// recently stay admitted; new ones beyond the cap are rejected until an
+	// established client falls idle. Zero (the default) means unlimited.
+	MaxClients int `json:"max_clients"`
+	// AllowedUIDs restricts the whole server to peers with one of these UIDs,
+	// e.g. a single service account on a shared daemon. Every RPC from any
+	// other UID is rejected. An empty list allows any UID (the default).
+	AllowedUIDs []uint32 `json:"allowed_uids"`
+	// TrustedClientDigests restricts the server to client binaries whose
+	// fingerprint appears in this list, e.g. digests collected from an
+	// attestation or provenance step. Every RPC from any other binary is
+	// rejected, turning the self-referential hash check into a policy gate:
+	// only attested binaries may use the daemon. Digests must be hex-encoded
+	// in the server's fingerprinting algorithm (SHA-256 unless
+	// BinaryHashAlgorithm says otherwise). An empty list preserves the
+	// default trust-on-first-store behavior.
+	TrustedClientDigests []string `json:"trusted_client_digests"`
+	// AllowUIDOnlyVerification lets the server serve peers whose process ID
+	// the kernel did not report (SO_PEERCRED returns pid 0 across some
+	// namespace boundaries, common in containerized setups). For such peers
+	// the encryption key is bound to a digest of the peer's UID instead of
+	// its binary hash, so any process running as that UID can read the
+	// secrets — a weaker guarantee, which is why it is off by default and
+	// PID-less peers are rejected instead.
+	AllowUIDOnlyVerification bool `json:"allow_uid_only_verification"`
+	// PeerVerification selects how peers are identified for key derivation:
+	// "strict" (the default) hashes the peer's binary through the kernel's
+	// view of /proc/<pid>/exe; "uid-only" skips binary resolution entirely
+	// and binds keys to a digest of the peer's UID, for deployments where
+	// client and server live in different PID namespaces (e.g. sidecar
+	// containers sharing a socket over a mounted volume) and the server
+	// would hash the wrong binary, or none at all; "disabled" binds every
+	// peer to one fixed digest.
+	//
+	// WARNING: anything other than "strict" weakens the guarantee that only
+	// the storing binary can read a secret. With "uid-only" any process
+	// running as the same UID can; with "disabled" any process that can
+	// reach the socket can. The session ID and client nonce still factor
+	// into every key either way. Unlike AllowUIDOnlyVerification, which
+	// only relaxes the check for peers whose PID the kernel withheld,
+	// these modes apply to every peer.
+	PeerVerification string `json:"peer_verification"`
+	// AllowRuntimeDebug enables the SetDebug RPC, which toggles the server's
+	// debug logging at runtime without a restart (a restart would discard the
+	// in-memory secrets). Off by default: debug logs expose secret names and
+	// operation patterns, so flipping them on remotely must be opted into.
+	AllowRuntimeDebug bool `json:"allow_runtime_debug"`
+	// AllowReclaim enables the Reclaim RPC, which re-binds a secret to the
+	// calling binary's current hash after a legitimate upgrade changed it —
+	// without it, an upgraded binary is locked out of its own secrets ("client
+	// binary hash mismatch"). Reclaims are only granted to a peer whose UID
+	// matches the original storer's, and every one is audit-logged. Gets from
+	// a mismatching binary still fail either way; off by default because it
+	// softens the exact-binary guarantee to a same-user guarantee during
+	// upgrades.
+	AllowReclaim bool `json:"allow_reclaim"`
+	// AllowEncryptedExport enables the GetEncrypted RPC, which hands out a
+	// secret's raw encrypted payload (ciphertext, salt and owner binary hash)
+	// so a trusted proxy can relay it without ever seeing plaintext. The blob
+	// cannot be decrypted without the client nonce and the server session ID,
+	// but exporting it is still sensitive, so it is off by default and every
+	// export is audit-logged.
+	AllowEncryptedExport bool `json:"allow_encrypted_export"`
+	// EvictionPolicy selects what Store does when the server already holds
+	// MaxSecrets secrets: "reject" (the default) refuses the new secret, while
+	// "lru" evicts the least-recently-accessed one to make room, with an audit
+	// log entry for the eviction. LRU is opt-in because silently burning a
+	// secret to store another is dangerous outside cache-like use cases.
+	EvictionPolicy string `json:"eviction_policy"`
+	// SharedKey lets a suite of cooperating binaries share one daemon and read
+	// each other's secrets. When set, key derivation and the access gate use
+	// the digest of this shared secret instead of each caller's binary hash.
+	//
+	// WARNING: this deliberately weakens the binary-verification guarantee:
+	// any process that knows the shared key (and the client nonce) can read
+	// the secrets. Combine it with an explicit, stable SocketPath so all
+	// cooperating binaries find the same daemon.
+	SharedKey string `json:"shared_key"`
 }
 
 // Server options set
 type Server struct {
 	Common
+	// SnapshotPath, when set, makes the server periodically persist its
+	// (already encrypted) secrets, their lifecycle metadata and the session ID
+	// to this file so a respawned server can restore them. Empty disables
+	// persistence; see the server snapshot code for the security tradeoffs.
+	SnapshotPath string `json:"snapshot_path"`
+	// SnapshotInterval is how often the snapshot file is rewritten when
+	// SnapshotPath is set. Zero means a one minute default.
+	SnapshotInterval time.Duration `json:"snapshot_interval"`
+	// IdleGracePeriod delays the empty-server auto-shutdown: when the last
+	// secret goes away, the server keeps running for this long and only shuts
+	// down if it is still empty then. This stops bursty workloads — delete
+	// everything, re-store moments later — from tearing the daemon down (and
+	// losing its session ID) during a brief empty window. Zero (the default)
+	// keeps the immediate shutdown. The window is observed at the cleanup
+	// sweep's granularity.
+	IdleGracePeriod time.Duration `json:"idle_grace_period"`
+	// ShutdownExportPath, when set together with ShutdownExportPassphrase,
+	// makes the server write its encrypted payloads, lifecycle metadata and
+	// session ID to a passphrase-protected bundle at this path as its last act
+	// before shutting down (whether stopped by signal or by the inactivity
+	// timeout). This is the server-side complement to relaying secrets out
+	// through the encrypted-export RPC, useful for migration or for capturing
+	// state an unexpected idle shutdown would otherwise discard. No plaintext
+	// secret ever reaches the file. Empty (the default) disables the export.
+	ShutdownExportPath string `json:"shutdown_export_path"`
+	// ShutdownExportPassphrase protects the shutdown export bundle: the bundle
+	// is encrypted with a key derived from it, so it is only as strong as the
+	// passphrase. Required for the export to happen — without it nothing is
+	// written.
+	ShutdownExportPassphrase string `json:"shutdown_export_passphrase"`
+	// BinaryHashAlgorithm selects the hash used to fingerprint client
+	// binaries: "sha256" (the default), "sha512" (for alignment with
+	// provenance ecosystems publishing sha512 digests) or "blake3" (much
+	// faster on large binaries, where per-request hashing can be the
+	// bottleneck). The fingerprint is a key-derivation input, so changing the
+	// algorithm invalidates every secret stored under the previous one.
+	BinaryHashAlgorithm string `json:"binary_hash_algorithm"`
+	// SessionID, when set, is used instead of generating a random session ID
+	// at startup, so a respawned server can re-derive the keys of payloads
+	// persisted by a previous instance.
+	//
+	// WARNING: the session ID is key material — together with a client's
+	// binary hash and nonce it is enough to re-derive that client's secret
+	// keys. Embedders supplying one must generate it with the same entropy as
+	// the built-in one (32 random bytes) and persist it as securely as the
+	// secrets themselves. Empty (the default) keeps the per-run random ID,
+	// which deliberately renders payloads unrecoverable once the server dies.
+	SessionID string `json:"session_id"`
+	// StorageFactory, when non-nil, builds the storage backend the server
+	// keeps its encrypted payloads in, replacing the built-in selection
+	// (kernel keyring, Keychain, then memory). A factory error fails the
+	// startup instead of degrading to memory, since the embedder asked for
+	// that backend specifically.
+	//
+	// The factory runs in the server process. With the embedded server that
+	// is a separate spawned binary which only receives the JSON-serializable
+	// options, so this hook only takes effect when the embedder runs the
+	// server itself (e.g. a custom launcher calling NewServer in-process).
+	StorageFactory func(context.Context) (secrets.Storage, error) `json:"-"`
+	// AuditSink, when non-nil, receives a structured event for every secret
+	// operation, replacing the JSON-lines file AuditLogPath would write.
+	// Like StorageFactory it runs in the server process, so it only takes
+	// effect when the embedder runs the server in-process.
+	AuditSink AuditSink `json:"-"`
 }
 
 // Client options set
@@ -27,10 +239,73 @@ type Client struct {
 	Common
 	// Cliente nonce to complete key derivation
 	Nonce string
+	// NonceBytes is the wipeable alternative to Nonce: when set it takes
+	// precedence and is used directly as the key-derivation input, and
+	// Client.Close zeroes it. A string nonce is immutable and lingers in
+	// memory for the process lifetime; security-conscious embedders supply
+	// the nonce here instead. The same bytes yield the same derived keys as
+	// the equivalent string nonce. Note that server mode still copies the
+	// nonce into the (string-typed) RPC requests, so only the fallback and
+	// in-memory paths get the full wipeability benefit.
+	NonceBytes []byte
 	// Skip server startup and use encrypted file fallback
 	NoServer bool
 	// Prevent the client from using fallback mode
 	NoFallbackMode bool
+	// StartupTimeout bounds how long Connect waits for a freshly spawned
+	// server to answer on its socket before declaring the start failed. Zero
+	// keeps the historical one-second wait; loaded machines (CI) may need
+	// more. The wait also aborts early if the Connect context is cancelled.
+	StartupTimeout time.Duration
+	// CacheServerBinary keeps the extracted server binary in the per-user
+	// cache directory so repeated client invocations reuse it, instead of
+	// extracting to a fresh temp file and deleting it each time. Only
+	// relevant when the server cannot be executed straight from memory. The
+	// cached copy is integrity-checked against the embedded binary before
+	// reuse, but it does persist on disk, which is why this is opt-in.
+	CacheServerBinary bool
+	// NoTempBinaryExtraction makes the launcher fail instead of writing the
+	// server binary to a temp file when in-memory execution (memfd) is
+	// unavailable or blocked. For hardened deployments where dropping an
+	// executable on disk is itself a policy violation and a loud failure is
+	// preferable to a silent fallback. Does not affect CacheServerBinary,
+	// which is its own explicit opt-in to an on-disk copy.
+	NoTempBinaryExtraction bool
+	// FallbackKey, when set, is used directly as the AES-256 key for fallback
+	// file encryption, skipping the PBKDF2 derivation from nonce and binary
+	// hash. Intended for callers who already manage a high-entropy 32-byte key
+	// (e.g. from a KMS) and want to control its lifecycle themselves. Files
+	// written this way record that no KDF was used and cannot be read by
+	// derived-key clients (or vice versa). The key is wiped on Close.
+	FallbackKey []byte
+	// FallbackKDFIterations overrides the PBKDF2 iteration count used to derive
+	// fallback encryption keys. Zero means the library default. The count is
+	// recorded in each file's header, so files written with one setting remain
+	// readable by clients configured with another; raising it only slows the
+	// (rare) fallback encrypt/decrypt operations, not server-mode traffic.
+	FallbackKDFIterations int
+	// FallbackKDF selects the key derivation function for fallback file
+	// encryption: "pbkdf2" (the default) or "argon2id". Argon2id is
+	// memory-hard, which blunts GPU attacks on captured fallback files; its
+	// cost parameters are fixed at the library defaults and recorded in each
+	// file header, so files written under either KDF stay readable regardless
+	// of this setting. Ignored when FallbackKey supplies the key directly.
+	FallbackKDF string
+	// MaxFallbackFileAge is a backstop for fallback file cleanup: any fallback
+	// file whose on-disk modification time is older than this is deleted, even
+	// if its embedded expiry claims it is still valid. This does not depend on
+	// the (attacker-writable) file contents, so it also reaps files with a
+	// tampered or clock-rolled-back expiry. Zero disables the backstop.
+	MaxFallbackFileAge time.Duration
+	// GetCacheTTL, when positive, enables a client-side in-memory cache of
+	// decrypted secrets: repeated Gets of the same name within this window
+	// return the cached plaintext without a round trip to the backend. The
+	// cache is wiped on Close, dropped when this client stores or deletes the
+	// secret, and never outlives the secret's expiry as known at fetch time —
+	// but deletions made by other clients are not seen until the entry
+	// expires. Holding plaintext in process memory is a deliberate tradeoff
+	// for hot-loop readers, which is why this is off by default.
+	GetCacheTTL time.Duration
 	// InMemory keeps secrets encrypted in this process's memory only: no server
 	// is started and nothing is written to disk, so secrets do not persist
 	// across restarts. Intended for long-lived daemons that need an ephemeral
@@ -41,14 +316,15 @@ type Client struct {
 
 // defaultCommon default common options shared by default server and client sets
 var defaultCommon = Common{
-	SocketPath:        "", // Empty = auto-generate based on client binary hash
-	DefaultTTL:        4 * time.Hour,
-	InactivityTimeout: 0, // Inactivity time to shutdown the server when no more connections are detected
-	Debug:             false,
-	EnvVarSocket:      "BURNAFTER_SOCKET_PATH",
-	EnvVarDebug:       "BURNAFTER_DEBUG",
-	MaxSecrets:        100,         // Maximum 100 secrets
-	MaxSecretSize:     1024 * 1024, // 1 MB per secret
+	SocketPath:            "", // Empty = auto-generate based on client binary hash
+	DefaultTTL:            4 * time.Hour,
+	InactivityTimeout:     0, // Inactivity time to shutdown the server when no more connections are detected
+	Debug:                 false,
+	EnvVarSocket:          "BURNAFTER_SOCKET_PATH",
+	EnvVarDebug:           "BURNAFTER_DEBUG",
+	MaxSecrets:            100,         // Maximum 100 secrets
+	MaxSecretSize:         1024 * 1024, // 1 MB per secret
+	MaxConcurrentRequests: 64,          // Generous limit, only meant to stop unbounded queueing
 }
 
 // DefaultClient default client options
@@ -64,6 +340,9 @@ var DefaultServer = &Server{
 type Store struct {
 	TtlSeconds                int64
 	AbsoluteExpirationSeconds int64
+	NotBefore                 int64 // Unix timestamp before which the secret cannot be read (0 = readable immediately)
+	MaxReads                  int64 // Successful reads allowed before the secret is destroyed (0 = unlimited)
+	Labels                    map[string]string
 }
 
 type StoreOptsFn func(*Store) error
@@ -81,3 +360,53 @@ func WithAbsoluteExpiration(secs int64) StoreOptsFn {
 		return nil
 	}
 }
+
+// WithNoInactivityExpiry stores the secret without inactivity expiry: it
+// lives untouched for as long as its absolute deadline allows, however rarely
+// it is read. The server therefore requires WithAbsoluteExpiration alongside
+// it, so the secret still dies eventually. In the fallback and in-memory
+// modes, which only track an absolute expiry to begin with, this is a no-op.
+func WithNoInactivityExpiry() StoreOptsFn {
+	return func(s *Store) error {
+		s.TtlSeconds = -1
+		return nil
+	}
+}
+
+// WithNotBefore seals the secret until the given time: stores succeed
+// immediately but reads return an error until t passes. This is the
+// complement of expiration and composes with it, yielding a secret readable
+// only within a window (e.g. a rotated credential that must not take effect
+// yet).
+func WithNotBefore(t time.Time) StoreOptsFn {
+	return func(s *Store) error {
+		s.NotBefore = t.Unix()
+		return nil
+	}
+}
+
+// WithMaxReads caps how many times the secret can be successfully read
+// before it is destroyed: the n-th read still returns the value, and the
+// secret is gone before the call returns. n = 1 gives classic burn-after-
+// reading one-time-secret behavior. Failed attempts (wrong binary, sealed
+// secret) do not consume reads. Zero keeps today's unlimited reads.
+func WithMaxReads(n int64) StoreOptsFn {
+	return func(s *Store) error {
+		s.MaxReads = n
+		return nil
+	}
+}
+
+// WithLabels tags the secret with labels that can later be used to delete
+// whole cohorts of secrets at once (see Client.DeleteWhere).
+func WithLabels(labels map[string]string) StoreOptsFn {
+	return func(s *Store) error {
+		if s.Labels == nil {
+			s.Labels = map[string]string{}
+		}
+		for k, v := range labels {
+			s.Labels[k] = v
+		}
+		return nil
+	}
+}
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/carabiner-dev/burnafter/internal/common"
+)
+
+// Reclaim re-binds a secret to this binary's current hash after an upgrade
+// changed it, recovering secrets that would otherwise be orphaned behind a
+// "client binary hash mismatch". The server must have been started with the
+// reclaim option enabled and only grants the re-bind to the user that
+// originally stored the secret.
+//
+// Reclaims only exist in server mode, where the binary-hash gate lives.
+func (c *Client) Reclaim(ctx context.Context, name string) error {
+	if c.useMemory() || c.useFallback() {
+		return fmt.Errorf("Reclaim is only supported in server mode")
+	}
+
+	if c.client == nil {
+		return ErrNotConnected
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.Reclaim(ctx, &pb.ReclaimRequest{
+		Name:        name,
+		ClientNonce: c.options.Nonce,
+	})
+	if err != nil {
+		return fmt.Errorf("reclaiming secret: %w", err)
+	}
+
+	if !resp.Success {
+		return fmt.Errorf("server error: %s", resp.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/internal/server"
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// inProcessLauncher runs servers inside the test process instead of spawning
+// the embedded binary, and keeps track of them so tests can kill the current
+// one.
+type inProcessLauncher struct {
+	mu      sync.Mutex
+	current *server.Server
+}
+
+func (l *inProcessLauncher) launch(ctx context.Context, opts *options.Client) error {
+	sopts := *options.DefaultServer
+	sopts.Common = opts.Common
+
+	srv, err := server.NewServer(context.Background(), &sopts)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	l.current = srv
+	l.mu.Unlock()
+
+	go srv.Run(context.Background()) //nolint:errcheck
+	return nil
+}
+
+func (l *inProcessLauncher) kill() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.current != nil {
+		l.current.Stop()
+		l.current = nil
+	}
+}
+
+func TestReconnectAfterServerDeath(t *testing.T) {
+	ctx := context.Background()
+
+	opts := *options.DefaultClient
+	opts.SocketPath = filepath.Join(t.TempDir(), "reconnect.sock")
+	opts.Nonce = "test-nonce-reconnect"
+	opts.NoFallbackMode = true
+
+	launcher := &inProcessLauncher{}
+	client := NewClient(&opts, WithServerLauncher(launcher.launch))
+	defer launcher.kill()
+
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	if err := client.Store(ctx, "before-restart", "v1", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+
+	// Kill the server: the client's connection is now stale
+	launcher.kill()
+	for range 10 {
+		if !client.IsServerRunning(ctx) {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// Reconnect must respawn the server and yield a usable client again
+	if err := client.Reconnect(ctx); err != nil {
+		t.Fatalf("Reconnect failed: %v", err)
+	}
+	if err := client.Store(ctx, "after-restart", "v2", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store after Reconnect failed: %v", err)
+	}
+
+	secret, err := client.Get(ctx, "after-restart")
+	if err != nil {
+		t.Fatalf("Get after Reconnect failed: %v", err)
+	}
+	if secret != "v2" {
+		t.Errorf("Expected v2, got %q", secret)
+	}
+}
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// Rotate atomically replaces a secret's value, for credential rotation. Unlike
+// storing again under the same name, the secret's lifecycle is carried over:
+// the rotation counts as an access — the inactivity timer restarts, and a new
+// TTL can be set with options.WithTTL — while the absolute deadline (which
+// bounds the credential's lifetime, not any one value) is preserved unless
+// explicitly overridden with options.WithAbsoluteExpiration. In server mode
+// the swap happens in place on the server, with no window in which concurrent
+// readers see the secret missing. The fallback and in-memory modes track a
+// single expiry timestamp; there, that recorded deadline is what is preserved.
+func (c *Client) Rotate(ctx context.Context, name, newSecret string, funcs ...options.StoreOptsFn) error {
+	opts := &options.Store{}
+	for _, f := range funcs {
+		if err := f(opts); err != nil {
+			return err
+		}
+	}
+
+	if err := c.validateStoreExpiry(opts); err != nil {
+		return err
+	}
+
+	// The rotated-out value must not linger in the read cache
+	if c.cache != nil {
+		c.cache.invalidate(name)
+	}
+
+	// In-memory mode: confirm the secret exists (and recover its expiry),
+	// then seal the replacement over it
+	if c.useMemory() {
+		old, expiry, err := c.getFromMemoryWithExpiry(ctx, name)
+		if err != nil {
+			return err
+		}
+		pb.ZeroBytes(old)
+		return c.storeInMemory(ctx, name, []byte(newSecret), c.rotateExpiry(opts, expiry))
+	}
+
+	// Fallback mode: decrypting the old file proves this binary owns the
+	// secret before its value is replaced
+	if c.useFallback() {
+		old, expiry, err := c.decryptSecretWithExpiry(ctx, name)
+		if err != nil {
+			return err
+		}
+		pb.ZeroBytes(old)
+		if err := c.encryptSecretNotBefore(ctx, name, []byte(newSecret), c.rotateExpiry(opts, expiry), 0, 0); err != nil {
+			return fmt.Errorf("failed to store rotated secret in fallback: %w", err)
+		}
+		_ = c.cleanupExpiredFallbackFiles(ctx) //nolint:errcheck
+		return nil
+	}
+
+	// Server mode
+	if c.client == nil {
+		return ErrNotConnected
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.Rotate(ctx, &pb.RotateRequest{
+		Name:                      name,
+		Secret:                    []byte(newSecret),
+		ClientNonce:               c.nonce(),
+		TtlSeconds:                opts.TtlSeconds,
+		AbsoluteExpirationSeconds: opts.AbsoluteExpirationSeconds,
+	})
+	if err != nil {
+		return fmt.Errorf("rotating secret: %w", err)
+	}
+
+	if !resp.Success {
+		return serverGetError(resp.Error)
+	}
+
+	return nil
+}
+
+// rotateExpiry computes the expiry for a rotated secret in the single-expiry
+// modes: the store options when they specify one, otherwise the deadline the
+// old value already had.
+func (c *Client) rotateExpiry(opts *options.Store, oldExpiry int64) time.Time {
+	if opts.AbsoluteExpirationSeconds > 0 || opts.TtlSeconds > 0 {
+		return c.storeExpiry(opts)
+	}
+	return time.Unix(oldExpiry, 0)
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func TestRotateInMemoryPreservesExpiry(t *testing.T) {
+	c := newInMemoryClient()
+
+	deadline := time.Now().Add(time.Hour).Unix()
+	if err := c.Store(t.Context(), "api-key", "old", options.WithAbsoluteExpiration(deadline)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+
+	if err := c.Rotate(t.Context(), "api-key", "new"); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+
+	secret, expiry, err := c.getFromMemoryWithExpiry(t.Context(), "api-key")
+	if err != nil {
+		t.Fatalf("Reading rotated secret: %v", err)
+	}
+	if string(secret) != "new" {
+		t.Errorf("Expected the rotated value, got %q", secret)
+	}
+	if expiry != deadline {
+		t.Errorf("Expected the recorded deadline to be preserved: got %d, want %d", expiry, deadline)
+	}
+}
+
+func TestRotateFallbackSwapsValue(t *testing.T) {
+	opts := *options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = testNonce
+	c := NewClient(&opts)
+
+	if err := c.Store(t.Context(), "rotating-file", "old"); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	t.Cleanup(func() {
+		if path, err := c.getFallbackFilePath("rotating-file"); err == nil {
+			os.Remove(path) //nolint:errcheck,gosec
+		}
+	})
+
+	if err := c.Rotate(t.Context(), "rotating-file", "new"); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+
+	secret, err := c.Get(t.Context(), "rotating-file")
+	if err != nil || secret != "new" {
+		t.Errorf("Expected the rotated value, got %q, %v", secret, err)
+	}
+}
+
+func TestRotateMissingSecret(t *testing.T) {
+	c := newInMemoryClient()
+	if err := c.Rotate(t.Context(), "never-stored", "v"); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("Expected ErrSecretNotFound rotating a missing secret, got %v", err)
+	}
+}
@@ -18,16 +18,55 @@ type Payload struct {
 	EncryptedData    []byte // Encrypted secret data
 	Salt             []byte // Salt used for key derivation
 	ClientBinaryHash string // Hash of the client binary that stored it
+	Chunked          bool   // EncryptedData is framed as per-chunk AES-GCM records (streamed store) rather than one sealed blob
 }
 
 // Metadata represents the metadata about a secret that the server
 // keeps in memory for lifecycle management. This includes expiration information
 // and access timestamps.
+// NoInactivityExpiry as a Metadata.InactivityTTL (any negative duration
+// works) marks a secret that never expires by inactivity: only its absolute
+// deadline kills it. The server requires such secrets to carry an absolute
+// deadline, so they still die eventually.
+const NoInactivityExpiry = -1 * time.Second
+
 type Metadata struct {
-	Name              string        // Name of the secret
-	InactivityTTL     time.Duration // TTL for inactivity-based expiration
-	AbsoluteExpiresAt *time.Time    // Optional absolute expiration time (nil = no absolute expiration)
-	LastAccessed      time.Time     // Last time this secret was accessed
+	Name              string            // Name of the secret
+	InactivityTTL     time.Duration     // TTL for inactivity-based expiration (negative = never)
+	AbsoluteExpiresAt *time.Time        // Optional absolute expiration time (nil = no absolute expiration)
+	NotBefore         *time.Time        // Optional time before which the secret cannot be read (nil = readable immediately)
+	LastAccessed      time.Time         // Last time this secret was accessed
+	CreatedAt         time.Time         // Time the secret was stored
+	Labels            map[string]string // Optional labels to select secret cohorts
+	Handle            string            // Outstanding single-use retrieval handle ("" = none)
+	OwnerUID          uint32            // UID of the peer that stored the secret
+	AliasTarget       string            // Name of the secret this entry aliases ("" = regular secret)
+	PlaintextLength   int64             // Size of the plaintext in bytes, for aggregate size statistics
+	MaxReads          int64             // Successful reads allowed before the secret is destroyed (0 = unlimited)
+	ReadCount         int64             // Successful reads served so far (only tracked when MaxReads is set)
+}
+
+// Lifecycle states a secret can be in. Secrets that are logically expired may
+// linger in the server's map until the next cleanup sweep; the state makes
+// that window observable for diagnostics.
+const (
+	StateLive              = "live"
+	StateExpiredInactivity = "expired-inactivity"
+	StateExpiredAbsolute   = "expired-absolute"
+)
+
+// State reports the secret's logical lifecycle state at the given instant,
+// without mutating anything. A secret past its absolute deadline reports
+// StateExpiredAbsolute even if it also timed out by inactivity, since the
+// deadline is the stronger condition (it cannot be renewed by access).
+func (m *Metadata) State(now time.Time) string {
+	if m.AbsoluteExpiresAt != nil && now.After(*m.AbsoluteExpiresAt) {
+		return StateExpiredAbsolute
+	}
+	if m.InactivityTTL >= 0 && now.Sub(m.LastAccessed) > m.InactivityTTL {
+		return StateExpiredInactivity
+	}
+	return StateLive
 }
 
 // Storage defines the interface for storing and retrieving encrypted secrets.
@@ -40,8 +79,13 @@ type Storage interface {
 	// Get retrieves a secret
 	Get(context.Context, string) (*Payload, error)
 
-	// Delete removes a secret from storage
+	// Delete removes a secret from storage. Deletes are idempotent: removing
+	// a secret that does not exist is a success, not an error.
 	Delete(context.Context, string) error
+
+	// Backend returns a short identifier of the storage implementation
+	// (e.g. "keyring", "keychain", "memory") for diagnostics.
+	Backend() string
 }
 
 // TODO(puerco): Sooner or later we'll need a List()
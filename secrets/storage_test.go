@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package secrets
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMetadataState(t *testing.T) {
+	now := time.Now()
+	deadline := now.Add(30 * time.Minute)
+
+	for _, tc := range []struct {
+		name string
+		meta Metadata
+		at   time.Time
+		want string
+	}{
+		{
+			name: "live",
+			meta: Metadata{InactivityTTL: time.Hour, LastAccessed: now},
+			at:   now,
+			want: StateLive,
+		},
+		{
+			name: "exactly at inactivity TTL is still live",
+			meta: Metadata{InactivityTTL: time.Hour, LastAccessed: now},
+			at:   now.Add(time.Hour),
+			want: StateLive,
+		},
+		{
+			name: "just past inactivity TTL",
+			meta: Metadata{InactivityTTL: time.Hour, LastAccessed: now},
+			at:   now.Add(time.Hour + time.Nanosecond),
+			want: StateExpiredInactivity,
+		},
+		{
+			name: "exactly at absolute deadline is still live",
+			meta: Metadata{InactivityTTL: time.Hour, LastAccessed: now, AbsoluteExpiresAt: &deadline},
+			at:   deadline,
+			want: StateLive,
+		},
+		{
+			name: "just past absolute deadline",
+			meta: Metadata{InactivityTTL: time.Hour, LastAccessed: now, AbsoluteExpiresAt: &deadline},
+			at:   deadline.Add(time.Nanosecond),
+			want: StateExpiredAbsolute,
+		},
+		{
+			name: "absolute deadline wins over inactivity",
+			meta: Metadata{InactivityTTL: time.Minute, LastAccessed: now, AbsoluteExpiresAt: &deadline},
+			at:   deadline.Add(time.Hour),
+			want: StateExpiredAbsolute,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.meta.State(tc.at); got != tc.want {
+				t.Errorf("State() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/carabiner-dev/burnafter/internal/common"
+)
+
+// Shutdown tells the server to wipe every stored secret and exit now, for
+// processes tearing down cleanly that would otherwise leave the daemon (and
+// its secrets) lingering until the inactivity timeout. The server only obeys
+// a caller that owns at least one stored secret — or any caller when it holds
+// none — and acknowledges before it actually stops. Only meaningful in server
+// mode: the ephemeral and fallback stores have no daemon to stop.
+func (c *Client) Shutdown(ctx context.Context) error {
+	if c.useMemory() || c.useFallback() {
+		return fmt.Errorf("shutdown is only supported in server mode")
+	}
+
+	if c.client == nil {
+		return ErrNotConnected
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.Shutdown(ctx, &pb.ShutdownRequest{})
+	if err != nil {
+		return fmt.Errorf("requesting shutdown: %w", err)
+	}
+
+	if !resp.Success {
+		return fmt.Errorf("server error: %s", resp.Error)
+	}
+
+	return nil
+}
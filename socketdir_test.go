@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func TestGenerateSocketPathUsesSocketDir(t *testing.T) {
+	dir := t.TempDir()
+
+	opts := *options.DefaultClient
+	opts.SocketPath = "" // force path generation even if another test populated the shared default
+	opts.SocketDir = dir
+	NewClient(&opts)
+
+	if !strings.HasPrefix(opts.SocketPath, dir+string(os.PathSeparator)) {
+		t.Errorf("Expected the generated socket path under %s, got %s", dir, opts.SocketPath)
+	}
+}
+
+func TestSocketDirPlacesFallbackFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	opts := *options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = testNonce
+	opts.SocketDir = dir
+	c := NewClient(&opts)
+
+	if err := c.Connect(t.Context()); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	if err := c.Store(t.Context(), "relocated", "value"); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+
+	// The encrypted file must live under the configured directory, not /tmp
+	path, err := c.getFallbackFilePath("relocated")
+	if err != nil {
+		t.Fatalf("getFallbackFilePath failed: %v", err)
+	}
+	if filepath.Dir(path) != dir {
+		t.Errorf("Expected the fallback file under %s, got %s", dir, path)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("Expected the fallback file to exist: %v", err)
+	}
+
+	if secret, err := c.Get(t.Context(), "relocated"); err != nil || secret != "value" {
+		t.Errorf("Expected the secret to round-trip, got %q, %v", secret, err)
+	}
+}
+
+func TestConnectRejectsMissingSocketDir(t *testing.T) {
+	opts := *options.DefaultClient
+	opts.NoServer = true
+	opts.SocketDir = filepath.Join(t.TempDir(), "does-not-exist")
+	c := NewClient(&opts)
+
+	if err := c.Connect(t.Context()); err == nil {
+		t.Errorf("Expected Connect to reject a missing socket directory")
+	}
+}
+
+func TestValidateSocketDirRejectsFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(file, []byte("x"), 0o600); err != nil {
+		t.Fatalf("writing file: %v", err)
+	}
+
+	err := validateSocketDir(file)
+	if err == nil || !strings.Contains(err.Error(), "not a directory") {
+		t.Errorf("Expected a not-a-directory error, got %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package burnafter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func TestConnectRejectsForeignOwnedSocketDir(t *testing.T) {
+	// Running unprivileged we cannot chown a directory away, so shift the
+	// notion of "current user" instead, the same trick the fallback file
+	// ownership tests use
+	origUID := fallbackCurrentUID
+	fallbackCurrentUID = func() int { return origUID() + 1 }
+	t.Cleanup(func() { fallbackCurrentUID = origUID })
+
+	opts := *options.DefaultClient
+	opts.NoServer = true
+	opts.SocketDir = t.TempDir()
+	c := NewClient(&opts)
+
+	err := c.Connect(t.Context())
+	if err == nil || !strings.Contains(err.Error(), "owned by uid") {
+		t.Errorf("Expected an ownership error, got %v", err)
+	}
+}
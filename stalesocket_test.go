@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// plantStaleSocket leaves a socket file at path with no listener behind it,
+// the way a SIGKILLed server does.
+func plantStaleSocket(t *testing.T, path string) {
+	t.Helper()
+
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("creating socket: %v", err)
+	}
+	// Keep the file on disk when the listener goes away
+	l.(*net.UnixListener).SetUnlinkOnClose(false)
+	if err := l.Close(); err != nil {
+		t.Fatalf("closing listener: %v", err)
+	}
+}
+
+func TestConnectRecoversFromStaleSocket(t *testing.T) {
+	opts := *options.DefaultClient
+	opts.SocketPath = filepath.Join(t.TempDir(), "stale.sock")
+	opts.Nonce = testNonce
+	opts.NoServer = false // other tests flip this on the shared default
+	opts.NoFallbackMode = true
+
+	plantStaleSocket(t, opts.SocketPath)
+
+	launcher := &inProcessLauncher{}
+	client := NewClient(&opts, WithServerLauncher(launcher.launch))
+	defer launcher.kill()
+
+	ctx := context.Background()
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Expected Connect to recover from the stale socket, got: %v", err)
+	}
+
+	// The replacement server must be fully usable over the reclaimed path
+	if err := client.Store(ctx, "after-crash", "recovered", options.WithTTL(300)); err != nil {
+		t.Fatalf("Store after recovery failed: %v", err)
+	}
+	if got, err := client.Get(ctx, "after-crash"); err != nil || got != "recovered" {
+		t.Errorf("Expected recovered, got %q, %v", got, err)
+	}
+}
+
+func TestRemoveStaleSocketLeavesLiveListener(t *testing.T) {
+	opts := *options.DefaultClient
+	opts.SocketPath = filepath.Join(t.TempDir(), "live.sock")
+	opts.Nonce = testNonce
+
+	l, err := net.Listen("unix", opts.SocketPath)
+	if err != nil {
+		t.Fatalf("creating listener: %v", err)
+	}
+	defer l.Close() //nolint:errcheck
+
+	client := NewClient(&opts)
+	client.removeStaleSocket(context.Background())
+
+	// A socket with a live listener is not stale and must survive
+	if !client.IsServerRunning(context.Background()) {
+		t.Errorf("Expected the live socket to be left in place")
+	}
+}
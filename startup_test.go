@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// neverReadyLauncher "starts" a server that never answers on the socket,
+// forcing Connect into its startup wait loop.
+func neverReadyLauncher(context.Context, *options.Client) error { return nil }
+
+func TestConnectStartupTimeout(t *testing.T) {
+	opts := *options.DefaultClient
+	opts.SocketPath = filepath.Join(t.TempDir(), "never.sock")
+	opts.Nonce = testNonce
+	opts.NoServer = false // other tests flip this on the shared default
+	opts.NoFallbackMode = true
+	opts.StartupTimeout = 300 * time.Millisecond
+
+	client := NewClient(&opts, WithServerLauncher(neverReadyLauncher))
+
+	start := time.Now()
+	err := client.Connect(context.Background())
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, ErrStartupTimeout) {
+		t.Errorf("Expected ErrStartupTimeout, got %v", err)
+	}
+	if !errors.Is(err, ErrServerStartFailed) {
+		t.Errorf("Expected the timeout to also match ErrServerStartFailed, got %v", err)
+	}
+	// The configured timeout, not the historical one second, bounds the wait
+	if elapsed >= time.Second {
+		t.Errorf("Expected the wait to end near 300ms, took %v", elapsed)
+	}
+}
+
+func TestConnectStartupCancellation(t *testing.T) {
+	opts := *options.DefaultClient
+	opts.SocketPath = filepath.Join(t.TempDir(), "never.sock")
+	opts.Nonce = testNonce
+	opts.NoServer = false // other tests flip this on the shared default
+	opts.NoFallbackMode = true
+	opts.StartupTimeout = 10 * time.Second
+
+	client := NewClient(&opts, WithServerLauncher(neverReadyLauncher))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := client.Connect(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the context cancellation to surface, got %v", err)
+	}
+	if errors.Is(err, ErrStartupTimeout) {
+		t.Errorf("Expected cancellation to be distinct from the startup timeout")
+	}
+
+	// Cancellation must not poison later attempts the way a failed start does
+	if client.serverStartFailed {
+		t.Errorf("Expected a cancelled wait to leave serverStartFailed unset")
+	}
+}
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/secrets"
+)
+
+// Stat reports a secret's lifecycle metadata — its inactivity TTL, absolute
+// deadline and access timestamps — without reading or decrypting its value.
+// Unlike Get, statting is side-effect free: the inactivity timer is not reset,
+// so a dashboard can watch when a secret will expire without extending its
+// life. In fallback mode only the file header (version, nonce, expiry) is
+// parsed; the ciphertext stays sealed. The single-expiry modes (fallback and
+// in-memory) track no per-access timestamps, so there only the name and the
+// recorded deadline are populated.
+func (c *Client) Stat(ctx context.Context, name string) (*secrets.Metadata, error) {
+	// In-memory mode: the ephemeral backends record just the deadline
+	if c.useMemory() {
+		s, ok, err := c.mem.get(ctx, name)
+		if err != nil || !ok {
+			return nil, ErrSecretNotFound
+		}
+		expiry := time.Unix(s.expiry, 0)
+		return &secrets.Metadata{Name: name, AbsoluteExpiresAt: &expiry}, nil
+	}
+
+	// Fallback mode: parse the file header without touching the ciphertext
+	if c.useFallback() {
+		filePath, err := c.getFallbackFilePath(name)
+		if err != nil {
+			return nil, err
+		}
+		file, err := c.readFallbackFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		expiry := time.Unix(file.expiry, 0)
+		metadata := &secrets.Metadata{Name: name, AbsoluteExpiresAt: &expiry}
+		if file.notBefore > 0 {
+			notBefore := time.Unix(file.notBefore, 0)
+			metadata.NotBefore = &notBefore
+		}
+		return metadata, nil
+	}
+
+	// Server mode
+	if c.client == nil {
+		return nil, ErrNotConnected
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.Stat(ctx, &pb.StatRequest{Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("statting secret: %w", err)
+	}
+
+	if !resp.Success {
+		return nil, serverGetError(resp.Error)
+	}
+
+	metadata := &secrets.Metadata{
+		Name:          name,
+		InactivityTTL: time.Duration(resp.TtlSeconds) * time.Second,
+		LastAccessed:  time.Unix(resp.LastAccessed, 0),
+		CreatedAt:     time.Unix(resp.CreatedAt, 0),
+	}
+	if resp.AbsoluteExpiresAt > 0 {
+		deadline := time.Unix(resp.AbsoluteExpiresAt, 0)
+		metadata.AbsoluteExpiresAt = &deadline
+	}
+	return metadata, nil
+}
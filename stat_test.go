@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func TestStatInMemoryReportsDeadline(t *testing.T) {
+	c := newInMemoryClient()
+
+	deadline := time.Now().Add(time.Hour).Unix()
+	if err := c.Store(t.Context(), "watched", "value", options.WithAbsoluteExpiration(deadline)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+
+	metadata, err := c.Stat(t.Context(), "watched")
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if metadata.AbsoluteExpiresAt == nil || metadata.AbsoluteExpiresAt.Unix() != deadline {
+		t.Errorf("Expected the recorded deadline %d, got %v", deadline, metadata.AbsoluteExpiresAt)
+	}
+}
+
+func TestStatFallbackNeedsNoKey(t *testing.T) {
+	opts := *options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = testNonce
+	owner := NewClient(&opts)
+
+	deadline := time.Now().Add(time.Hour).Unix()
+	if err := owner.Store(t.Context(), "watched-file", "value",
+		options.WithAbsoluteExpiration(deadline)); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	t.Cleanup(func() {
+		if path, err := owner.getFallbackFilePath("watched-file"); err == nil {
+			os.Remove(path) //nolint:errcheck,gosec
+		}
+	})
+
+	// A client with the wrong nonce cannot decrypt the file, but Stat only
+	// parses the header: it must still report the expiry
+	otherOpts := *options.DefaultClient
+	otherOpts.NoServer = true
+	otherOpts.Nonce = "some-other-nonce"
+	other := NewClient(&otherOpts)
+
+	metadata, err := other.Stat(t.Context(), "watched-file")
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if metadata.AbsoluteExpiresAt == nil || metadata.AbsoluteExpiresAt.Unix() != deadline {
+		t.Errorf("Expected the recorded deadline %d, got %v", deadline, metadata.AbsoluteExpiresAt)
+	}
+}
+
+func TestStatMissingSecret(t *testing.T) {
+	c := newInMemoryClient()
+	if _, err := c.Stat(t.Context(), "never-stored"); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("Expected ErrSecretNotFound, got %v", err)
+	}
+}
@@ -5,6 +5,7 @@ package burnafter
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -12,6 +13,72 @@ import (
 	"github.com/carabiner-dev/burnafter/options"
 )
 
+// ErrConflictingExpiry is returned by Store when the requested inactivity TTL
+// and absolute expiration contradict each other (e.g. an absolute deadline in
+// the past, or a TTL that claims to extend past the deadline).
+var ErrConflictingExpiry = errors.New("conflicting expiration settings")
+
+// minExpiryGrace is the smallest useful lifetime for a secret: an absolute
+// deadline closer than this is treated as already expired at store time.
+const minExpiryGrace = time.Second
+
+// validateStoreExpiry rejects store options whose expiration settings are
+// nonsensical, instead of letting the absolute deadline silently win.
+func (c *Client) validateStoreExpiry(opts *options.Store) error {
+	if opts.AbsoluteExpirationSeconds <= 0 {
+		return nil
+	}
+
+	now := c.now()
+
+	// The fallback and in-memory paths interpret the absolute expiration as a
+	// unix timestamp; the server interprets it as seconds from now.
+	if c.useMemory() || c.useFallback() {
+		absolute := time.Unix(opts.AbsoluteExpirationSeconds, 0)
+		if !absolute.After(now.Add(minExpiryGrace)) {
+			return fmt.Errorf("%w: absolute expiration %s is not in the future",
+				ErrConflictingExpiry, absolute.Format(time.RFC3339))
+		}
+		if opts.TtlSeconds > 0 && now.Add(time.Duration(opts.TtlSeconds)*time.Second).After(absolute) {
+			return fmt.Errorf("%w: inactivity TTL extends past the absolute deadline", ErrConflictingExpiry)
+		}
+		return nil
+	}
+
+	if opts.TtlSeconds > 0 && opts.TtlSeconds > opts.AbsoluteExpirationSeconds {
+		return fmt.Errorf("%w: inactivity TTL extends past the absolute deadline", ErrConflictingExpiry)
+	}
+	return nil
+}
+
+// validateMaxReads rejects a read budget (the WithMaxReads option) that is
+// negative or that targets a mode unable to enforce it.
+func (c *Client) validateMaxReads(opts *options.Store) error {
+	if opts.MaxReads < 0 {
+		return fmt.Errorf("invalid max reads: %d", opts.MaxReads)
+	}
+	if opts.MaxReads > 0 && c.useMemory() {
+		return fmt.Errorf("read budgets are not supported in in-memory mode")
+	}
+	return nil
+}
+
+// validateNotBefore rejects a not-before seal (the WithNotBefore option) that
+// leaves no window in which the secret is ever readable, or that targets a
+// mode unable to enforce it.
+func (c *Client) validateNotBefore(opts *options.Store) error {
+	if opts.NotBefore <= 0 {
+		return nil
+	}
+	if c.useMemory() {
+		return fmt.Errorf("not-before seals are not supported in in-memory mode")
+	}
+	if c.useFallback() && !time.Unix(opts.NotBefore, 0).Before(c.storeExpiry(opts)) {
+		return fmt.Errorf("%w: not-before time is at or past the expiry", ErrConflictingExpiry)
+	}
+	return nil
+}
+
 // storeExpiry computes a secret's absolute expiry time from the store options,
 // falling back to the client's default TTL.
 func (c *Client) storeExpiry(opts *options.Store) time.Time {
@@ -19,42 +86,102 @@ func (c *Client) storeExpiry(opts *options.Store) time.Time {
 	case opts.AbsoluteExpirationSeconds > 0:
 		return time.Unix(opts.AbsoluteExpirationSeconds, 0)
 	case opts.TtlSeconds > 0:
-		return time.Now().Add(time.Duration(opts.TtlSeconds) * time.Second)
+		return c.now().Add(time.Duration(opts.TtlSeconds) * time.Second)
 	default:
-		return time.Now().Add(c.options.DefaultTTL)
+		return c.now().Add(c.options.DefaultTTL)
 	}
 }
 
 // Store stores a secret on the server or in fallback encrypted file storage
 func (c *Client) Store(ctx context.Context, name, secret string, funcs ...options.StoreOptsFn) error {
+	_, err := c.storeBytes(ctx, name, []byte(secret), funcs...)
+	return err
+}
+
+// StoreBytes stores a raw binary secret — a DER-encoded key, a random 32-byte
+// seed — that may not be valid UTF-8. The bytes travel unchanged through every
+// mode (the wire field is bytes, not string); the caller keeps ownership of
+// the slice and may wipe it after the call returns.
+func (c *Client) StoreBytes(ctx context.Context, name string, secret []byte, funcs ...options.StoreOptsFn) error {
+	_, err := c.storeBytes(ctx, name, secret, funcs...)
+	return err
+}
+
+// StoreResult reports the lifecycle a stored secret actually ended up with,
+// after any server-side defaulting.
+type StoreResult struct {
+	// EffectiveTTL is the inactivity TTL applied to the secret (negative =
+	// it only expires at its absolute deadline). The single-expiry modes
+	// (fallback and in-memory) collapse the TTL into the deadline and report
+	// zero here.
+	EffectiveTTL time.Duration
+	// AbsoluteExpiresAt is the resolved absolute expiration deadline, nil
+	// when the secret has none.
+	AbsoluteExpiresAt *time.Time
+}
+
+// StoreWithResult stores a secret like Store and additionally reports the
+// lifecycle the secret was given, with any defaults (e.g. the server's
+// DefaultTTL) already resolved — so a scheduler knows exactly when to refresh
+// without guessing the server's configuration.
+func (c *Client) StoreWithResult(ctx context.Context, name, secret string, funcs ...options.StoreOptsFn) (*StoreResult, error) {
+	return c.storeBytes(ctx, name, []byte(secret), funcs...)
+}
+
+// storeBytes implements Store for callers holding the plaintext as bytes they
+// intend to wipe (StoreFields). The memory and fallback modes encrypt the
+// slice directly; server mode hands it to the RPC layer, whose wire buffers
+// are outside the caller's control. The returned result carries the resolved
+// lifecycle for StoreWithResult.
+func (c *Client) storeBytes(ctx context.Context, name string, secret []byte, funcs ...options.StoreOptsFn) (*StoreResult, error) {
 	opts := &options.Store{}
 	for _, f := range funcs {
 		if err := f(opts); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
+	if err := c.validateStoreExpiry(opts); err != nil {
+		return nil, err
+	}
+	if err := c.validateNotBefore(opts); err != nil {
+		return nil, err
+	}
+	if err := c.validateMaxReads(opts); err != nil {
+		return nil, err
+	}
+
+	// An overwrite invalidates any cached copy of the old value
+	if c.cache != nil {
+		c.cache.invalidate(name)
+	}
+
 	// In-memory mode keeps the (encrypted) secret ephemeral.
 	if c.useMemory() {
-		return c.storeInMemory(ctx, name, []byte(secret), c.storeExpiry(opts))
+		expiry := c.storeExpiry(opts)
+		if err := c.storeInMemory(ctx, name, secret, expiry); err != nil {
+			return nil, err
+		}
+		return &StoreResult{AbsoluteExpiresAt: &expiry}, nil
 	}
 
 	// Use fallback storage if server is not available
 	if c.useFallback() {
 		// Encrypt and store to file
-		if err := c.encryptSecret(name, []byte(secret), c.storeExpiry(opts)); err != nil {
-			return fmt.Errorf("failed to store secret in fallback: %w", err)
+		expiry := c.storeExpiry(opts)
+		if err := c.encryptSecretNotBefore(ctx, name, secret, expiry, opts.NotBefore, opts.MaxReads); err != nil {
+			return nil, fmt.Errorf("failed to store secret in fallback: %w", err)
 		}
 
 		// Cleanup expired files
-		_ = c.cleanupExpiredFallbackFiles() //nolint:errcheck
+		_ = c.cleanupExpiredFallbackFiles(ctx) //nolint:errcheck
 
-		return nil
+		return &StoreResult{AbsoluteExpiresAt: &expiry}, nil
 	}
 
 	// Server mode
 	if c.client == nil {
-		return fmt.Errorf("not connected to server")
+		return nil, ErrNotConnected
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
@@ -64,16 +191,98 @@ func (c *Client) Store(ctx context.Context, name, secret string, funcs ...option
 		Name:                      name,
 		Secret:                    secret,
 		TtlSeconds:                opts.TtlSeconds,
-		ClientNonce:               c.options.Nonce,
+		ClientNonce:               c.nonce(),
 		AbsoluteExpirationSeconds: opts.AbsoluteExpirationSeconds,
+		NotBefore:                 opts.NotBefore,
+		MaxReads:                  opts.MaxReads,
+		Labels:                    opts.Labels,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to store secret: %w", err)
+		return nil, fmt.Errorf("failed to store secret: %w", err)
 	}
 
 	if !resp.Success {
-		return fmt.Errorf("server error: %s", resp.Error)
+		return nil, fmt.Errorf("server error: %s", resp.Error)
+	}
+
+	result := &StoreResult{EffectiveTTL: time.Duration(resp.EffectiveTtlSeconds) * time.Second}
+	if resp.AbsoluteExpiresAt > 0 {
+		deadline := time.Unix(resp.AbsoluteExpiresAt, 0)
+		result.AbsoluteExpiresAt = &deadline
+	}
+	return result, nil
+}
+
+// StoreVerified stores a secret like Store and then immediately reads it back
+// to confirm the round-trip, returning an error if the stored value cannot be
+// retrieved or does not match. This catches silent storage failures (e.g. a
+// keyring quota truncating a write) and is meant for critical provisioning
+// where a lost secret is worse than a slow store. The verification copy is
+// zero-wiped before returning.
+func (c *Client) StoreVerified(ctx context.Context, name, secret string, funcs ...options.StoreOptsFn) error {
+	if err := c.Store(ctx, name, secret, funcs...); err != nil {
+		return err
+	}
+
+	readback, err := c.getBytes(ctx, name)
+	if err != nil {
+		return fmt.Errorf("verifying stored secret: %w", err)
+	}
+	defer pb.ZeroBytes(readback)
+
+	if string(readback) != secret {
+		return fmt.Errorf("stored secret failed verification: read-back value does not match")
 	}
 
 	return nil
 }
+
+// StoreWithHandle stores a secret on the server and returns a single-use
+// retrieval handle for it. The handle is an unguessable token that another
+// process can redeem exactly once with GetByHandle without knowing the secret
+// name, and it dies with the secret. Handles only exist in server mode: the
+// ephemeral and fallback stores have no server to track redemption.
+func (c *Client) StoreWithHandle(ctx context.Context, name, secret string, funcs ...options.StoreOptsFn) (string, error) {
+	opts := &options.Store{}
+	for _, f := range funcs {
+		if err := f(opts); err != nil {
+			return "", err
+		}
+	}
+
+	if c.useMemory() || c.useFallback() {
+		return "", fmt.Errorf("retrieval handles are only supported in server mode")
+	}
+
+	if err := c.validateStoreExpiry(opts); err != nil {
+		return "", err
+	}
+
+	if c.client == nil {
+		return "", ErrNotConnected
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.Store(ctx, &pb.StoreRequest{
+		Name:                      name,
+		Secret:                    []byte(secret),
+		TtlSeconds:                opts.TtlSeconds,
+		ClientNonce:               c.nonce(),
+		AbsoluteExpirationSeconds: opts.AbsoluteExpirationSeconds,
+		NotBefore:                 opts.NotBefore,
+		MaxReads:                  opts.MaxReads,
+		Labels:                    opts.Labels,
+		ReturnHandle:              true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to store secret: %w", err)
+	}
+
+	if !resp.Success {
+		return "", fmt.Errorf("server error: %s", resp.Error)
+	}
+
+	return resp.Handle, nil
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func newFallbackTestClient(t *testing.T, nonce string) *Client {
+	t.Helper()
+
+	opts := options.DefaultClient
+	opts.NoServer = true
+	opts.Nonce = nonce
+
+	client := NewClient(opts)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	return client
+}
+
+func TestStoreConflictingExpiry(t *testing.T) {
+	ctx := context.Background()
+	client := newFallbackTestClient(t, "test-nonce-conflicting-expiry")
+
+	// Absolute expiration already in the past
+	past := time.Now().Add(-time.Minute).Unix()
+	err := client.Store(ctx, "conflicting-past", "value", options.WithAbsoluteExpiration(past))
+	if !errors.Is(err, ErrConflictingExpiry) {
+		t.Errorf("Expected ErrConflictingExpiry for a past deadline, got %v", err)
+	}
+
+	// Inactivity TTL that claims to extend past the absolute deadline
+	soon := time.Now().Add(10 * time.Second).Unix()
+	err = client.Store(ctx, "conflicting-ttl", "value",
+		options.WithTTL(3600), options.WithAbsoluteExpiration(soon))
+	if !errors.Is(err, ErrConflictingExpiry) {
+		t.Errorf("Expected ErrConflictingExpiry for TTL past the deadline, got %v", err)
+	}
+}
+
+func TestStoreCompatibleExpiry(t *testing.T) {
+	ctx := context.Background()
+	client := newFallbackTestClient(t, "test-nonce-compatible-expiry")
+
+	// A TTL that fits inside the absolute deadline is fine
+	deadline := time.Now().Add(time.Hour).Unix()
+	err := client.Store(ctx, "compatible-both", "value",
+		options.WithTTL(60), options.WithAbsoluteExpiration(deadline))
+	if err != nil {
+		t.Fatalf("Store failed for compatible settings: %v", err)
+	}
+	defer client.Delete(ctx, "compatible-both") //nolint:errcheck
+
+	// Either setting alone is always valid
+	if err := client.Store(ctx, "compatible-ttl", "value", options.WithTTL(60)); err != nil {
+		t.Fatalf("Store failed with only a TTL: %v", err)
+	}
+	defer client.Delete(ctx, "compatible-ttl") //nolint:errcheck
+
+	if err := client.Store(ctx, "compatible-abs", "value", options.WithAbsoluteExpiration(deadline)); err != nil {
+		t.Fatalf("Store failed with only an absolute expiration: %v", err)
+	}
+	defer client.Delete(ctx, "compatible-abs") //nolint:errcheck
+}
+
+// stickyStore is an ephemeral backend that accepts every put but silently
+// keeps the first value written for a name, simulating a storage backend that
+// reports success while losing the write.
+type stickyStore struct {
+	inner secretStore
+}
+
+func (s *stickyStore) put(ctx context.Context, name string, sec memSecret) error {
+	if _, ok, _ := s.inner.get(ctx, name); ok { //nolint:errcheck
+		return nil // Pretend to succeed without storing anything
+	}
+	return s.inner.put(ctx, name, sec)
+}
+
+func (s *stickyStore) get(ctx context.Context, name string) (memSecret, bool, error) {
+	return s.inner.get(ctx, name)
+}
+
+func (s *stickyStore) del(ctx context.Context, name string) {
+	s.inner.del(ctx, name)
+}
+
+func (s *stickyStore) wipe() {
+	s.inner.wipe()
+}
+
+func TestStoreVerified(t *testing.T) {
+	ctx := context.Background()
+	client := newInMemoryClient()
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	// A healthy backend round-trips fine
+	if err := client.StoreVerified(ctx, "verified-ok", "value-1", options.WithTTL(3600)); err != nil {
+		t.Fatalf("StoreVerified failed on a healthy backend: %v", err)
+	}
+
+	// A backend that drops the write must fail verification: the sticky store
+	// keeps the first value, so overwriting with a new one never lands.
+	client.mem = &stickyStore{inner: client.mem}
+	if err := client.StoreVerified(ctx, "verified-ok", "value-2", options.WithTTL(3600)); err == nil {
+		t.Errorf("Expected StoreVerified to detect the lost write")
+	}
+
+	// The original value must still be readable
+	got, err := client.Get(ctx, "verified-ok")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got != "value-1" {
+		t.Errorf("Expected value-1, got %q", got)
+	}
+}
+
+func TestStoreVerifiedFallback(t *testing.T) {
+	ctx := context.Background()
+	client := newFallbackTestClient(t, "test-nonce-store-verified")
+
+	name := "verified-fallback"
+	if err := client.StoreVerified(ctx, name, "fallback-value", options.WithTTL(300)); err != nil {
+		t.Fatalf("StoreVerified failed: %v", err)
+	}
+	defer client.Delete(ctx, name) //nolint:errcheck
+
+	got, err := client.Get(ctx, name)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got != "fallback-value" {
+		t.Errorf("Expected fallback-value, got %q", got)
+	}
+}
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+func TestStoreWithResultInMemory(t *testing.T) {
+	c := newInMemoryClient()
+
+	deadline := time.Now().Add(time.Hour).Unix()
+	result, err := c.StoreWithResult(t.Context(), "scheduled", "value",
+		options.WithAbsoluteExpiration(deadline))
+	if err != nil {
+		t.Fatalf("StoreWithResult failed: %v", err)
+	}
+
+	if result.AbsoluteExpiresAt == nil || result.AbsoluteExpiresAt.Unix() != deadline {
+		t.Errorf("Expected the resolved deadline %d, got %v", deadline, result.AbsoluteExpiresAt)
+	}
+}
+
+func TestStoreWithResultAppliesClientDefaultTTL(t *testing.T) {
+	c := newInMemoryClient()
+	before := time.Now()
+
+	// No expiry options: the single-expiry mode resolves the client's
+	// DefaultTTL into a concrete deadline the caller can schedule against
+	result, err := c.StoreWithResult(t.Context(), "defaulted", "value")
+	if err != nil {
+		t.Fatalf("StoreWithResult failed: %v", err)
+	}
+
+	if result.AbsoluteExpiresAt == nil {
+		t.Fatalf("Expected a resolved deadline")
+	}
+	want := before.Add(c.options.DefaultTTL)
+	if result.AbsoluteExpiresAt.Before(want.Add(-time.Minute)) ||
+		result.AbsoluteExpiresAt.After(want.Add(time.Minute)) {
+		t.Errorf("Expected a deadline near %v, got %v", want, result.AbsoluteExpiresAt)
+	}
+}
@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+package burnafter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	pb "github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/options"
+)
+
+// StoreReader stores a secret read from r, streaming it to the server in
+// chunks so neither side ever buffers the whole plaintext. This is the store
+// path for secrets larger than what Store comfortably handles (the server's
+// MaxSecretSize still applies to the total). Streaming needs a server to
+// stream to, so only server mode supports it; the usual store options (TTL,
+// absolute expiration, not-before, read budget, labels) all apply.
+func (c *Client) StoreReader(ctx context.Context, name string, r io.Reader, funcs ...options.StoreOptsFn) error {
+	opts := &options.Store{}
+	for _, f := range funcs {
+		if err := f(opts); err != nil {
+			return err
+		}
+	}
+
+	if c.useMemory() || c.useFallback() {
+		return fmt.Errorf("streaming store is only supported in server mode")
+	}
+
+	if err := c.validateStoreExpiry(opts); err != nil {
+		return err
+	}
+	if err := c.validateNotBefore(opts); err != nil {
+		return err
+	}
+	if err := c.validateMaxReads(opts); err != nil {
+		return err
+	}
+
+	// An overwrite invalidates any cached copy of the old value
+	if c.cache != nil {
+		c.cache.invalidate(name)
+	}
+
+	if c.client == nil {
+		return ErrNotConnected
+	}
+
+	// No fixed timeout here: a large secret legitimately takes longer than the
+	// unary RPCs, so the caller's context governs the deadline.
+	stream, err := c.client.StoreStream(ctx)
+	if err != nil {
+		return fmt.Errorf("opening store stream: %w", err)
+	}
+
+	// The first frame carries the store parameters, the plaintext follows in
+	// chunk frames
+	if err := stream.Send(&pb.StoreStreamRequest{
+		Frame: &pb.StoreStreamRequest_Info{
+			Info: &pb.StoreRequest{
+				Name:                      name,
+				TtlSeconds:                opts.TtlSeconds,
+				ClientNonce:               c.nonce(),
+				AbsoluteExpirationSeconds: opts.AbsoluteExpirationSeconds,
+				NotBefore:                 opts.NotBefore,
+				MaxReads:                  opts.MaxReads,
+				Labels:                    opts.Labels,
+			},
+		},
+	}); err != nil {
+		return fmt.Errorf("sending store parameters: %w", err)
+	}
+
+	// Send marshals the frame before returning, so one buffer can be reused
+	// for every chunk and wiped once at the end
+	buf := make([]byte, pb.StreamChunkSize)
+	defer pb.ZeroBytes(buf)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&pb.StoreStreamRequest{
+				Frame: &pb.StoreStreamRequest_Chunk{Chunk: buf[:n]},
+			}); err != nil {
+				return fmt.Errorf("sending secret chunk: %w", err)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("reading secret: %w", err)
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return fmt.Errorf("failed to store secret: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("server error: %s", resp.Error)
+	}
+	return nil
+}
+
+// GetWriter retrieves a secret and streams it into w chunk by chunk, the
+// retrieval counterpart of StoreReader. The plaintext never sits whole in
+// this process: each chunk is zero-wiped once written. Works for any secret
+// regardless of how it was stored, but like StoreReader it is server-mode
+// only.
+func (c *Client) GetWriter(ctx context.Context, name string, w io.Writer) error {
+	if c.useMemory() || c.useFallback() {
+		return fmt.Errorf("streaming retrieval is only supported in server mode")
+	}
+
+	if c.client == nil {
+		return ErrNotConnected
+	}
+
+	// The caller's context governs the deadline, as in StoreReader
+	stream, err := c.client.GetStream(ctx, &pb.GetRequest{
+		Name:        name,
+		ClientNonce: c.nonce(),
+	})
+	if err != nil {
+		return fmt.Errorf("opening get stream: %w", err)
+	}
+
+	// The first frame reports the outcome; the plaintext follows on success
+	first, err := stream.Recv()
+	if err != nil {
+		return fmt.Errorf("getting secret: %w", err)
+	}
+	if !first.Success {
+		return serverError(first.ErrorCode, first.Error)
+	}
+
+	writeChunk := func(chunk []byte) error {
+		defer pb.ZeroBytes(chunk)
+		// Handle partial writes: keep writing until the whole chunk is out
+		for written := 0; written < len(chunk); {
+			n, err := w.Write(chunk[written:])
+			if err != nil {
+				return fmt.Errorf("writing secret: %w", err)
+			}
+			written += n
+		}
+		return nil
+	}
+
+	if len(first.Chunk) > 0 {
+		if err := writeChunk(first.Chunk); err != nil {
+			return err
+		}
+	}
+
+	for {
+		frame, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("receiving secret chunk: %w", err)
+		}
+		if err := writeChunk(frame.Chunk); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package burnafter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/chainguard-dev/clog"
+
+	pb "github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/internal/server"
+)
+
+// generateSocketPath creates a socket path based on the client binary's SHA256
+// hash, under dir when one is configured (the SocketDir option) and under /tmp
+// otherwise. A non-empty namespace adds its own hash segment, giving each
+// logical namespace in the binary its own server endpoint; the empty default
+// keeps the historical path.
+func generateSocketPath(dir, namespace string) string {
+	if dir == "" {
+		dir = "/tmp"
+	}
+
+	hash, err := pb.GetCurrentBinaryHash()
+	if err != nil {
+		// Fallback to a default path if we can't compute the hash
+		return filepath.Join(dir, "burnafter"+namespaceSuffix(namespace)+".sock")
+	}
+
+	// Use first 16 characters of hash for the socket name
+	// This provides uniqueness while keeping the filename reasonable
+	return filepath.Join(dir, fmt.Sprintf("burnafter-%s%s.sock", hash[:16], namespaceSuffix(namespace)))
+}
+
+// validateSocketDir checks that a caller-supplied base directory (the
+// SocketDir option) exists, is a directory and belongs to the current user —
+// anything else means another local user could plant an impostor socket or
+// read fallback files placed there.
+func validateSocketDir(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("socket directory: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("socket directory %s is not a directory", dir)
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fmt.Errorf("socket directory %s: cannot read ownership", dir)
+	}
+	if int(stat.Uid) != fallbackCurrentUID() {
+		return fmt.Errorf("socket directory %s is owned by uid %d, not the current user", dir, stat.Uid)
+	}
+	return nil
+}
+
+// strictSocketPathCheck enforces the strict socket directory option before a
+// dial: refuse a socket sitting in a directory other local users could have
+// planted an impostor socket in.
+func strictSocketPathCheck(socketPath string) error {
+	return server.CheckSocketDir(socketPath)
+}
+
+// dialPeer opens a raw connection to the server endpoint at path, honoring
+// any deadline on ctx.
+func dialPeer(ctx context.Context, path string) (net.Conn, error) {
+	var d net.Dialer
+	return d.DialContext(ctx, "unix", path)
+}
+
+// removeStaleSocket deletes the socket file a crashed server left behind. A
+// path that holds a socket nobody answers on (the dial is refused) has no
+// server behind it: the previous one died without Run's cleanup, and leaving
+// the file would surface as a confusing "address already in use" when the
+// replacement tries to bind. Anything that is not a socket is left alone.
+func (c *Client) removeStaleSocket(ctx context.Context) {
+	info, err := os.Lstat(c.options.SocketPath)
+	if err != nil || info.Mode()&os.ModeSocket == 0 {
+		return
+	}
+
+	d := net.Dialer{Timeout: 1 * time.Second}
+	conn, err := d.DialContext(ctx, "unix", c.options.SocketPath)
+	if err == nil {
+		// A listener answered after all; not ours to remove
+		conn.Close() //nolint:errcheck,gosec
+		return
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		clog.FromContext(ctx).Debugf("removing stale server socket at %s", c.options.SocketPath)
+		os.Remove(c.options.SocketPath) //nolint:errcheck,gosec
+	}
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: Copyright 2026 Carabiner Systems, Inc
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+
+package burnafter
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+
+	pb "github.com/carabiner-dev/burnafter/internal/common"
+	"github.com/carabiner-dev/burnafter/internal/server"
+)
+
+// generateSocketPath creates the named pipe path the client and server
+// rendezvous on, keyed by the client binary's SHA256 hash. Pipes live in the
+// kernel's pipe namespace rather than the filesystem, so the SocketDir option
+// does not change where the endpoint goes (it still places fallback files).
+// A non-empty namespace adds its own hash segment, giving each logical
+// namespace in the binary its own pipe; the empty default keeps the
+// historical name.
+func generateSocketPath(_, namespace string) string {
+	hash, err := pb.GetCurrentBinaryHash()
+	if err != nil {
+		// Fallback to a default path if we can't compute the hash
+		return `\\.\pipe\burnafter` + namespaceSuffix(namespace)
+	}
+	return fmt.Sprintf(`\\.\pipe\burnafter-%s%s`, hash[:16], namespaceSuffix(namespace))
+}
+
+// validateSocketDir checks that a caller-supplied base directory (the
+// SocketDir option) exists and is a directory. Windows has no numeric file
+// owner to compare against, so the Unix ownership check does not apply;
+// fallback files placed there are still created with exclusive access.
+func validateSocketDir(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("socket directory: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("socket directory %s is not a directory", dir)
+	}
+	return nil
+}
+
+// strictSocketPathCheck enforces the strict socket directory option before a
+// dial. Named pipes do not sit in a filesystem directory another user could
+// tamper with, so there is nothing to refuse here.
+func strictSocketPathCheck(string) error {
+	return nil
+}
+
+// dialPeer opens a raw connection to the server's named pipe at path.
+func dialPeer(_ context.Context, path string) (net.Conn, error) {
+	return server.DialPipe(path)
+}
+
+// removeStaleSocket is a no-op on Windows: a named pipe disappears with the
+// process that created it, so a crashed server cannot leave a stale endpoint
+// behind.
+func (c *Client) removeStaleSocket(context.Context) {}